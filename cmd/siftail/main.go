@@ -29,6 +29,6 @@ func main() {
 	// Run the application
 	if err := cli.Run(config); err != nil {
 		fmt.Fprintf(os.Stderr, "siftail: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -53,3 +53,25 @@ func TestMain_BuildsAndPrintsHelp(t *testing.T) {
 		}
 	}
 }
+
+func TestMain_FatalInputExitStatus(t *testing.T) {
+	if err := exec.Command("go", "build", "-o", "siftail_fatal_test", ".").Run(); err != nil {
+		t.Fatalf("Failed to build siftail: %v", err)
+	}
+	defer func() {
+		_ = exec.Command("rm", "-f", "siftail_fatal_test").Run()
+	}()
+
+	// Captured stdout is not a terminal, so this runs headless
+	cmd := exec.Command("./siftail_fatal_test", "--cmd", "/nonexistent/siftail-command")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	_, err := cmd.Output()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 3 {
+		t.Fatalf("expected exit status 3, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "siftail: input failed") {
+		t.Errorf("unexpected stderr: %q", stderr.String())
+	}
+}
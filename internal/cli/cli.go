@@ -3,45 +3,111 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"maps"
+	"math"
+	"net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/germanoeich/siftail/internal/core"
 	"github.com/germanoeich/siftail/internal/dockerx"
 	"github.com/germanoeich/siftail/internal/input"
+	"github.com/germanoeich/siftail/internal/persist"
 	"github.com/germanoeich/siftail/internal/tui"
 )
 
 // Config holds the parsed command-line configuration
 type Config struct {
 	Mode        tui.Mode
-	FilePath    string
+	FilePath    string // file to tail, or the directory with --latest
+	Latest      bool   // tail the newest file in the FilePath directory
+	Glob        string // base-name pattern for --latest
 	BufferSize  int
 	FromStart   bool
-	NumLines    int // file mode prefill; if <0, read whole file
+	NumLines    int           // file mode prefill; if <0, read whole file
+	Since       time.Duration // docker and file modes: include the logs of the last Since
 	Theme       string
 	NoColor     bool
 	TimeFormat  string
+	TZ          string            // time zone for timestamps: "local", "utc" or an IANA name
+	NoMouse     bool              // start without mouse capture so native terminal selection works
+	KeepCR      bool              // keep the \r of CRLF line endings (file, stdin and command input)
+	ForceTUI    bool              // launch the TUI even when stdout is not a terminal
+	Snapshot    bool              // print the filtered file once and exit, without the TUI
+	IdleTimeout time.Duration     // pause auto-follow after this long without key input (0 = never)
+	BurstGap    time.Duration     // draw a separator between lines further apart than this (0 = off)
+	Chrono      bool              // order lines by their parsed timestamps instead of arrival
+	Aliases     map[string]string // container display names from --alias real=friendly
+	Links       bool              // emphasize URLs/paths; URLs become OSC 8 hyperlinks
+	Columns     []string          // JSON fields used as Markdown table columns
+	Profile     string            // built-in filter preset applied at startup (e.g. "ops")
+	Ops         bool              // shorthand for --profile ops --stats --error-nav
+	Stats       bool              // show lines/s and error rate in the status line
+	ErrorNav    bool              // ] and [ jump between error lines
+	DumpLevels  string            // "text" or "json": print the discovered level map and exit
+	Alerts      []string          // patterns whose matches are posted to AlertWebhook
+	AlertURL    string            // webhook receiving alert matches
+	Command     []string          // command mode: argv whose stdout is read
+	FilterIn    []string          // include filters applied at startup
+	FilterOut   []string          // exclude filters applied at startup
+	Highlight   []string          // highlights applied at startup
+	WinEvent    string            // command mode: Windows event log name read via wevtutil
 	ShowHelp    bool
 	ShowVersion bool
+
+	// Docker mode: how often new containers are picked up, and how often the
+	// container list shown in the UI is updated
+	DockerRefresh     time.Duration
+	DockerListRefresh time.Duration
+
+	// File mode: every file given, tailed as one merged view when there are
+	// several; FilePath is the first
+	FilePaths []string
+
+	// Snapshot output: "text" (the default) or "json", one event object per line
+	OutputFormat string
+
+	// When the UI falls behind the input, readers wait for it by default;
+	// with DropOnOverload lines are dropped instead so tailing stays live
+	DropOnOverload bool
+	// GroupStackTraces joins indented and "Caused by" continuation lines onto
+	// the line before them (file and stdin input)
+	GroupStackTraces bool
+	// Poll stats tailed files for changes instead of relying on file events
+	// (network filesystems); used automatically when events are unavailable
+	Poll bool
 }
 
+// defaultDockerListRefresh is how often the UI's container list is updated
+const defaultDockerListRefresh = 2 * time.Second
+
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() Config {
 	return Config{
 		BufferSize: 10000,
 		TimeFormat: "15:04:05.000",
+		TZ:         "local",
 		NoColor:    false,
 		FromStart:  true, // default to read entire file
 		NumLines:   -1,   // unset
 		Theme:      "",   // if empty, use persisted theme
+
+		DockerRefresh:     input.DefaultDockerRefresh,
+		DockerListRefresh: defaultDockerListRefresh,
 	}
 }
 
@@ -60,9 +126,63 @@ func ParseArgs(args []string) (Config, error) {
 	fs.BoolVar(&config.FromStart, "from-start", config.FromStart, "start reading from beginning of file (file mode only; default true)")
 	fs.IntVar(&config.NumLines, "n", config.NumLines, "prefill last N lines (file mode only; overrides --from-start)")
 	fs.IntVar(&config.NumLines, "num-lines", config.NumLines, "prefill last N lines (file mode only; overrides --from-start)")
+	fs.DurationVar(&config.Since, "since", config.Since, "include the logs of the last DURATION (docker mode; file mode prefills timestamped lines)")
+	fs.BoolVar(&config.Latest, "latest", config.Latest, "treat the argument as a directory and tail its newest file")
+	fs.StringVar(&config.Glob, "glob", config.Glob, "with --latest, only consider files matching this pattern (e.g. \"*.log\")")
 	fs.StringVar(&config.Theme, "theme", config.Theme, "UI theme (dark, dracula, nord, light)")
 	fs.BoolVar(&config.NoColor, "no-color", config.NoColor, "disable colored output")
 	fs.StringVar(&config.TimeFormat, "time-format", config.TimeFormat, "timestamp format for display")
+	fs.StringVar(&config.TZ, "tz", config.TZ, "time zone for timestamps: local, utc or a name like Europe/Berlin")
+	fs.DurationVar(&config.IdleTimeout, "idle-timeout", config.IdleTimeout, "pause following after this long without key input (0 disables)")
+	fs.DurationVar(&config.BurstGap, "burst-gap", config.BurstGap, "draw a separator between lines further apart than this (0 disables)")
+	fs.BoolVar(&config.Chrono, "chrono", config.Chrono, "order lines by their timestamps instead of arrival (merges files and containers)")
+	fs.DurationVar(&config.DockerRefresh, "docker-refresh", config.DockerRefresh, "how often to look for new containers (docker mode)")
+	fs.DurationVar(&config.DockerListRefresh, "docker-list-refresh", config.DockerListRefresh, "how often to update the container list in the UI (docker mode)")
+	fs.BoolVar(&config.Snapshot, "snapshot", config.Snapshot, "print the file's lines that pass the filter flags and exit (no TUI)")
+	fs.StringVar(&config.OutputFormat, "output", config.OutputFormat, "snapshot output format: text or json")
+	fs.BoolVar(&config.ForceTUI, "force-tui", config.ForceTUI, "launch the TUI even when stdout is redirected")
+	fs.Var((*dumpLevelsFlag)(&config.DumpLevels), "dump-levels", "print the level map discovered in the input and exit (=json for JSON)")
+	fs.Func("filter-in", "show only lines matching this pattern (repeatable)", func(v string) error {
+		config.FilterIn = append(config.FilterIn, v)
+		return nil
+	})
+	fs.Func("filter-out", "hide lines matching this pattern (repeatable)", func(v string) error {
+		config.FilterOut = append(config.FilterOut, v)
+		return nil
+	})
+	fs.Func("highlight", "highlight this pattern (repeatable)", func(v string) error {
+		config.Highlight = append(config.Highlight, v)
+		return nil
+	})
+	fs.Func("alert", "post lines matching this pattern to --alert-webhook (repeatable)", func(v string) error {
+		config.Alerts = append(config.Alerts, v)
+		return nil
+	})
+	fs.StringVar(&config.AlertURL, "alert-webhook", config.AlertURL, "URL that receives a JSON POST for each --alert match (rate limited)")
+	fs.StringVar(&config.Profile, "profile", config.Profile, "apply a built-in view at startup (ops: hide DEBUG/TRACE, highlight failures)")
+	fs.BoolVar(&config.Ops, "ops", config.Ops, "ops setup: --profile ops, --stats and --error-nav (each can be overridden)")
+	fs.BoolVar(&config.Stats, "stats", config.Stats, "show lines/s and error rate in the status line")
+	fs.BoolVar(&config.ErrorNav, "error-nav", config.ErrorNav, "] and [ jump to the next/previous error line")
+	fs.BoolVar(&config.Links, "links", config.Links, "emphasize URLs and paths; URLs become clickable OSC 8 links")
+	fs.Func("columns", "comma-separated JSON fields for Markdown table snapshots (e.g. time,level,msg)", func(v string) error {
+		config.Columns = nil
+		for _, col := range strings.Split(v, ",") {
+			col = strings.TrimSpace(col)
+			if col == "" {
+				return errors.New("empty column name")
+			}
+			config.Columns = append(config.Columns, col)
+		}
+		return nil
+	})
+	fs.Var(aliasFlag{&config.Aliases}, "alias", "show a container as a friendly name (real=friendly; repeatable)")
+	useCmd := fs.Bool("cmd", false, "run the remaining arguments as a command and read its output")
+	fs.StringVar(&config.WinEvent, "winevent", config.WinEvent, "read a Windows event log (e.g. System) via wevtutil")
+	fs.BoolVar(&config.KeepCR, "keep-cr", config.KeepCR, "keep the trailing \\r of CRLF lines instead of stripping it")
+	fs.BoolVar(&config.GroupStackTraces, "group-stack-traces", config.GroupStackTraces, "join stack trace continuation lines onto the line before them (file and stdin input)")
+	fs.BoolVar(&config.Poll, "poll", config.Poll, "check tailed files for changes periodically instead of waiting for file events (NFS)")
+	fs.BoolVar(&config.DropOnOverload, "drop-on-overload", config.DropOnOverload, "drop input lines instead of pausing the readers when the UI falls behind")
+	fs.BoolVar(&config.NoMouse, "no-mouse", config.NoMouse, "disable mouse capture (native terminal selection; no in-app drag-copy)")
 	fs.BoolVar(&config.ShowHelp, "h", config.ShowHelp, "show help message")
 	fs.BoolVar(&config.ShowHelp, "help", config.ShowHelp, "show help message")
 	fs.BoolVar(&config.ShowVersion, "v", config.ShowVersion, "show version information")
@@ -84,6 +204,10 @@ func ParseArgs(args []string) (Config, error) {
 		return config, nil
 	}
 
+	if config.Ops {
+		applyOpsDefaults(fs, &config)
+	}
+
 	// Validate buffer size
 	if config.BufferSize <= 0 {
 		return config, errors.New("buffer-size must be positive")
@@ -91,19 +215,230 @@ func ParseArgs(args []string) (Config, error) {
 
 	// Determine mode based on remaining arguments
 	remaining := fs.Args()
-	mode, filePath, err := determineMode(remaining)
+	if *useCmd || config.WinEvent != "" {
+		argv, err := commandArgs(*useCmd, config.WinEvent, remaining)
+		if err != nil {
+			return config, err
+		}
+		config.Mode = tui.ModeCommand
+		config.Command = argv
+		return config, nil
+	}
+	if config.Latest {
+		dir, err := determineLatestDir(remaining)
+		if err != nil {
+			return config, err
+		}
+		config.Mode = tui.ModeFile
+		config.FilePath = dir
+		if config.Glob == "" {
+			config.Glob = "*"
+		}
+		return config, nil
+	}
+	mode, filePaths, err := determineMode(remaining)
 	if err != nil {
 		return config, err
 	}
 
 	config.Mode = mode
-	config.FilePath = filePath
+	if len(filePaths) > 0 {
+		config.FilePath = filePaths[0]
+		config.FilePaths = filePaths
+	}
 
 	return config, nil
 }
 
-// determineMode analyzes arguments and stdin to determine the operational mode
-func determineMode(args []string) (tui.Mode, string, error) {
+// applyOpsDefaults turns on the parts of --ops not set explicitly, so e.g.
+// --ops --stats=false keeps everything but the stats.
+func applyOpsDefaults(fs *flag.FlagSet, config *Config) {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if !set["profile"] {
+		config.Profile = tui.ProfileOps
+	}
+	if !set["stats"] {
+		config.Stats = true
+	}
+	if !set["error-nav"] {
+		config.ErrorNav = true
+	}
+}
+
+// aliasFlag collects repeated --alias real=friendly values into a map
+type aliasFlag struct {
+	aliases *map[string]string
+}
+
+func (a aliasFlag) String() string {
+	if a.aliases == nil {
+		return ""
+	}
+	var parts []string
+	for name, alias := range *a.aliases {
+		parts = append(parts, name+"="+alias)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func (a aliasFlag) Set(value string) error {
+	name, alias, ok := strings.Cut(value, "=")
+	name, alias = strings.TrimSpace(name), strings.TrimSpace(alias)
+	if !ok || name == "" || alias == "" {
+		return fmt.Errorf("expected real=friendly, got %q", value)
+	}
+	if *a.aliases == nil {
+		*a.aliases = make(map[string]string)
+	}
+	(*a.aliases)[name] = alias
+	return nil
+}
+
+// dumpLevelsFlag is a boolean-style flag that also accepts a format:
+// --dump-levels (text) or --dump-levels=json.
+type dumpLevelsFlag string
+
+func (d *dumpLevelsFlag) String() string { return string(*d) }
+
+func (d *dumpLevelsFlag) IsBoolFlag() bool { return true }
+
+func (d *dumpLevelsFlag) Set(value string) error {
+	switch value {
+	case "true", "text":
+		*d = "text"
+	case "false":
+		*d = ""
+	case "json":
+		*d = "json"
+	default:
+		return fmt.Errorf("unknown format %q (want text or json)", value)
+	}
+	return nil
+}
+
+// containerAliases merges aliases from the settings file with those given on
+// the command line; the command line wins.
+func containerAliases(config Config) map[string]string {
+	aliases := make(map[string]string)
+	if sm, err := persist.NewSettingsManager(); err == nil {
+		if s, err := sm.Load(); err == nil {
+			maps.Copy(aliases, s.ContainerAliases)
+		}
+	}
+	maps.Copy(aliases, config.Aliases)
+	return aliases
+}
+
+// newLevelMap creates the level map with the reserved slots, overflow
+// behavior and label from the settings file. An invalid setting keeps the
+// default and is described in the returned note.
+func newLevelMap() (*core.LevelMap, string) {
+	levels := core.NewLevelMap()
+	var notes []string
+	if sm, err := persist.NewSettingsManager(); err == nil {
+		if s, err := sm.Load(); err == nil {
+			if err := levels.LoadMapping(s.LevelSlots); err != nil {
+				notes = append(notes, "Ignoring levelSlots: "+err.Error())
+			}
+			if strategy, err := core.ParseOverflowStrategy(s.LevelOverflow); err != nil {
+				notes = append(notes, "Ignoring levelOverflow: "+err.Error())
+			} else {
+				levels.SetOverflow(strategy, s.OverflowLabel)
+			}
+		}
+	}
+	return levels, strings.Join(notes, "; ")
+}
+
+// commandArgs returns the argv for command mode: the remaining arguments with
+// --cmd, or the wevtutil query for --winevent.
+func commandArgs(useCmd bool, winEvent string, args []string) ([]string, error) {
+	switch {
+	case useCmd && winEvent != "":
+		return nil, errors.New("--cmd and --winevent can't be combined")
+	case winEvent != "":
+		if len(args) > 0 {
+			return nil, errors.New("--winevent takes no other arguments")
+		}
+		return input.WinEventCommand(winEvent), nil
+	case len(args) == 0:
+		return nil, errors.New("--cmd needs a command to run")
+	default:
+		return args, nil
+	}
+}
+
+// newCommandReader creates the reader for command mode; Windows event logs
+// get the block parser, anything else is read line by line. detector may be
+// nil to leave levels the parser doesn't set unknown.
+func newCommandReader(config Config, detector core.SeverityDetector) *input.CommandReader {
+	var parser input.RecordParser
+	if config.WinEvent != "" {
+		parser = input.NewWinEventParser()
+	}
+	r := input.NewCommandReader(config.Command, parser)
+	r.SetKeepCR(config.KeepCR)
+	r.SetDetector(detector)
+	return r
+}
+
+// applyFilterFlags adds the --filter-in, --filter-out and --highlight patterns
+func applyFilterFlags(config Config, filters *core.Filters) error {
+	for _, group := range []struct {
+		flag     string
+		patterns []string
+		parse    func(string) (core.TextMatcher, error)
+		add      func(core.TextMatcher)
+	}{
+		{"--filter-in", config.FilterIn, core.NewIncludeMatcher, filters.AddInclude},
+		{"--filter-out", config.FilterOut, core.NewMatcher, filters.AddExclude},
+		{"--highlight", config.Highlight, core.NewMatcher, filters.AddHighlight},
+	} {
+		for _, p := range group.patterns {
+			matcher, err := group.parse(p)
+			if err != nil {
+				return fmt.Errorf("invalid %s pattern %q: %w", group.flag, p, err)
+			}
+			group.add(matcher)
+		}
+	}
+	return nil
+}
+
+// launchArgs returns the arguments that reopen the configured input, for
+// duplicating a session; nil for stdin, which can only be read once.
+func launchArgs(config Config) []string {
+	switch config.Mode {
+	case tui.ModeFile:
+		if config.Latest {
+			args := []string{"--latest"}
+			if config.Glob != "" && config.Glob != "*" {
+				args = append(args, "--glob", config.Glob)
+			}
+			return append(args, config.FilePath)
+		}
+		if len(config.FilePaths) > 1 {
+			return append([]string(nil), config.FilePaths...)
+		}
+		return []string{config.FilePath}
+	case tui.ModeDocker:
+		return []string{"docker"}
+	case tui.ModeCommand:
+		if config.WinEvent != "" {
+			return []string{"--winevent", config.WinEvent}
+		}
+		// --cmd must come last: everything after it is the command
+		return append([]string{"--cmd"}, config.Command...)
+	default:
+		return nil
+	}
+}
+
+// determineMode analyzes arguments and stdin to determine the operational
+// mode; in file mode it also returns the files to tail.
+func determineMode(args []string) (tui.Mode, []string, error) {
 	// Check if stdin has data (piped input)
 	stat, err := os.Stdin.Stat()
 	hasStdinData := err == nil && (stat.Mode()&os.ModeCharDevice) == 0
@@ -111,31 +446,47 @@ func determineMode(args []string) (tui.Mode, string, error) {
 	switch {
 	case len(args) == 0:
 		if hasStdinData {
-			return tui.ModeStdin, "", nil
+			return tui.ModeStdin, nil, nil
 		} else {
-			return 0, "", errors.New("no input specified and stdin is a terminal. Use -h for help")
+			return 0, nil, errors.New("no input specified and stdin is a terminal. Use -h for help")
 		}
 
 	case len(args) == 1 && args[0] == "docker":
 		if hasStdinData {
-			return 0, "", errors.New("cannot use docker mode with piped input")
+			return 0, nil, errors.New("cannot use docker mode with piped input")
 		}
-		return tui.ModeDocker, "", nil
+		return tui.ModeDocker, nil, nil
 
-	case len(args) == 1:
+	default:
 		if hasStdinData {
-			return 0, "", errors.New("cannot specify file path with piped input")
+			return 0, nil, errors.New("cannot specify file path with piped input")
 		}
-		// Validate file exists or is accessible
-		filePath := args[0]
-		if err := validateFilePath(filePath); err != nil {
-			return 0, "", fmt.Errorf("file access error: %w", err)
+		// Validate each file exists or is accessible
+		for _, filePath := range args {
+			if err := validateFilePath(filePath); err != nil {
+				return 0, nil, fmt.Errorf("file access error: %w", err)
+			}
 		}
-		return tui.ModeFile, filePath, nil
+		return tui.ModeFile, args, nil
+	}
+}
 
-	default:
-		return 0, "", errors.New("too many arguments. Use -h for help")
+// determineLatestDir validates the single directory argument used with --latest
+func determineLatestDir(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("--latest requires exactly one directory argument")
+	}
+	if stat, err := os.Stdin.Stat(); err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
+		return "", errors.New("cannot use --latest with piped input")
 	}
+	info, err := os.Stat(args[0])
+	if err != nil {
+		return "", fmt.Errorf("directory access error: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--latest expects a directory: %s", args[0])
+	}
+	return args[0], nil
 }
 
 // validateFilePath checks if a file path is accessible
@@ -150,6 +501,9 @@ func validateFilePath(path string) error {
 	}
 
 	// Check if it's a regular file
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory (use --latest to tail its newest file)", path)
+	}
 	if !info.Mode().IsRegular() {
 		return fmt.Errorf("not a regular file: %s", path)
 	}
@@ -166,51 +520,174 @@ func validateFilePath(path string) error {
 
 // Run executes the application with the given configuration
 func Run(config Config) error {
+	if config.DumpLevels != "" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return dumpLevels(ctx, config, os.Stdout)
+	}
+
+	if config.Snapshot {
+		return runSnapshot(config, os.Stdout)
+	}
+
+	// Redirected output gets plain lines instead of escape codes
+	if shouldRunHeadless(isTerminal(os.Stdout), config.ForceTUI) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return runHeadless(ctx, config, os.Stdout)
+	}
+
 	// Initialize core components
 	ring := core.NewRing(config.BufferSize)
 	filters := core.NewFilters()
 	search := core.NewSearchState()
-	levels := core.NewLevelMap()
+	levels, levelsNote := newLevelMap()
 
-	// Create TUI model
-	model := tui.NewModel(ring, filters, search, levels, config.Mode)
+	if err := applyFilterFlags(config, filters); err != nil {
+		return err
+	}
 
-	// Bubble Tea program (created before starting readers so we can send refresh msgs)
-	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	model, err := newModel(config, ring, filters, search, levels)
+	if err != nil {
+		return err
+	}
+	if levelsNote != "" {
+		model.SetStatus(levelsNote)
+	}
+
+	// Bubble Tea program (created before starting readers so we can send
+	// refresh msgs; they are held back until it runs)
+	program := tea.NewProgram(model, programOptions(config)...)
+	ui := newStartupUI(program)
 
 	// Wire input -> ring and notify UI
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Levels found in file and stdin lines fill the same map as docker's
+	detector := core.NewDefaultSeverityDetector(levels)
+
 	// Initialize data source based on mode
 	switch config.Mode {
 	case tui.ModeFile:
-		if err := startFileReader(ctx, config.FilePath, config.FromStart, config.NumLines, ring, program); err != nil {
+		if config.Latest {
+			model.SetSource(startLatestFileReader(ctx, config.FilePath, config.Glob, config.FromStart, config.KeepCR, config.GroupStackTraces, detector, ring, ui, config.DropOnOverload))
+			break
+		}
+		if len(config.FilePaths) > 1 {
+			model.SetSource(startMultiFileReader(ctx, config.FilePaths, config.FromStart, config.NumLines, config.Since, config.KeepCR, config.GroupStackTraces, config.Poll, detector, ring, ui, config.DropOnOverload))
+			break
+		}
+		src, err := startFileReader(ctx, config.FilePath, config.FromStart, config.NumLines, config.Since, config.KeepCR, config.GroupStackTraces, config.Poll, detector, ring, ui, config.DropOnOverload)
+		if err != nil {
 			return fmt.Errorf("failed to start file reader: %w", err)
 		}
+		model.SetSource(src)
 
 	case tui.ModeStdin:
-		if err := startStdinReader(ctx, ring, program); err != nil {
+		src, err := startStdinReader(ctx, config.KeepCR, config.GroupStackTraces, detector, ring, ui, config.DropOnOverload)
+		if err != nil {
 			return fmt.Errorf("failed to start stdin reader: %w", err)
 		}
+		model.SetSource(src)
+
+	case tui.ModeCommand:
+		model.SetSource(startCommandReader(ctx, config, detector, ring, ui))
 
 	case tui.ModeDocker:
-		if err := startDockerReader(ctx, ring, levels, program); err != nil {
+		if err := startDockerReader(ctx, config, ring, levels, ui); err != nil {
 			return fmt.Errorf("failed to start docker reader: %w", err)
 		}
 	}
 
-	// Apply theme prior to run. Use CLI if provided, else load persisted.
-	if config.Theme != "" {
-		model.SetTheme(config.Theme)
-	}
-
 	// Run the TUI (blocks until exit)
-	_, err := program.Run()
+	ui.start()
+	final, err := program.Run()
 
 	// Ensure readers are stopped
 	cancel()
-	return err
+	if err != nil {
+		return err
+	}
+	if m, ok := final.(interface{ FatalError() error }); ok && m.FatalError() != nil {
+		return &InputFailedError{Err: m.FatalError()}
+	}
+	return nil
+}
+
+// ExitInputFailed is the exit status when the input fails for good
+// mid-session, so scripts can tell it from other errors (1).
+const ExitInputFailed = 3
+
+// InputFailedError is returned by Run when a reader hit a non-recoverable
+// error, e.g. the file became unreadable or Docker could not be reached.
+type InputFailedError struct {
+	Err error
+}
+
+func (e *InputFailedError) Error() string { return "input failed: " + e.Err.Error() }
+
+func (e *InputFailedError) Unwrap() error { return e.Err }
+
+// ExitCode returns the process exit status for an error returned by Run
+func ExitCode(err error) int {
+	var failed *InputFailedError
+	if errors.As(err, &failed) {
+		return ExitInputFailed
+	}
+	return 1
+}
+
+// newModel creates the TUI model with the startup options from config
+func newModel(config Config, ring *core.Ring, filters *core.Filters, search *core.SearchState, levels *core.LevelMap) (*tui.Model, error) {
+	model := tui.NewModel(ring, filters, search, levels, config.Mode)
+	model.SetLaunchArgs(launchArgs(config))
+	model.SetMouseCapture(!config.NoMouse)
+	model.SetIdleTimeout(config.IdleTimeout)
+	model.SetBurstGap(config.BurstGap)
+	model.SetChronological(config.Chrono)
+	loc, err := loadTimeZone(config.TZ)
+	if err != nil {
+		return nil, err
+	}
+	model.SetLocation(loc)
+	model.SetTimeFormat(config.TimeFormat)
+	model.SetContainerAliases(containerAliases(config))
+	if config.Links {
+		model.SetLinkify(true)
+	}
+	model.SetColumns(config.Columns)
+	if err := model.SetAlerts(config.Alerts, config.AlertURL); err != nil {
+		return nil, err
+	}
+	if config.Profile != "" {
+		if err := model.ApplyProfile(config.Profile); err != nil {
+			return nil, err
+		}
+	}
+	model.SetStats(config.Stats)
+	model.SetErrorNav(config.ErrorNav)
+	if config.Mode == tui.ModeFile && !config.Latest && len(config.FilePaths) <= 1 && !input.IsGzip(config.FilePath) {
+		model.SetDiskPath(config.FilePath)
+	}
+	if config.Mode == tui.ModeFile && !config.Latest {
+		model.SetSourcePaths(sourcePaths(config))
+	}
+	// The last-used theme is restored by NewModel; --theme wins for this run
+	if config.Theme != "" {
+		model.SetTheme(config.Theme)
+	}
+	return model, nil
+}
+
+// programOptions returns the Bubble Tea options for the given configuration.
+// Mouse capture is skipped with --no-mouse so the terminal keeps native selection.
+func programOptions(config Config) []tea.ProgramOption {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !config.NoMouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	return opts
 }
 
 // uiRefresher is the minimal interface we need from a Bubble Tea program
@@ -218,16 +695,111 @@ type uiRefresher interface {
 	Send(msg tea.Msg)
 }
 
-// wireEventStream pumps events from a reader into the ring and notifies the UI
-func wireEventStream(ctx context.Context, events <-chan core.LogEvent, errs <-chan error, ring *core.Ring, ui uiRefresher) {
+// startupUI holds back the messages sent before the program runs:
+// Program.Send blocks until Run, which would hang startup (prefill progress,
+// the initial refresh). start delivers them in order once Run begins.
+type startupUI struct {
+	ui      uiRefresher
+	running atomic.Bool
+	mu      sync.Mutex
+	pending []tea.Msg
+}
+
+func newStartupUI(ui uiRefresher) *startupUI {
+	return &startupUI{ui: ui}
+}
+
+// Send queues msg until start; repeated progress updates collapse into the
+// latest one
+func (u *startupUI) Send(msg tea.Msg) {
+	if u.running.Load() {
+		u.ui.Send(msg)
+		return
+	}
+	u.mu.Lock()
+	if u.running.Load() {
+		u.mu.Unlock()
+		u.ui.Send(msg)
+		return
+	}
+	if _, ok := msg.(tui.LoadProgressMsg); ok && len(u.pending) > 0 {
+		if _, last := u.pending[len(u.pending)-1].(tui.LoadProgressMsg); last {
+			u.pending[len(u.pending)-1] = msg
+			u.mu.Unlock()
+			return
+		}
+	}
+	u.pending = append(u.pending, msg)
+	u.mu.Unlock()
+}
+
+// start delivers the queued messages from a goroutine, where Send may block
+// until the program runs; later messages wait for them to keep their order
+func (u *startupUI) start() {
+	go func() {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		for _, msg := range u.pending {
+			u.ui.Send(msg)
+		}
+		u.pending = nil
+		u.running.Store(true)
+	}()
+}
+
+// overloadQueueSize is how many events wait for the UI under the drop policy
+// before further ones are dropped
+const overloadQueueSize = 1000
+
+// overloadReportEvery is how often backpressure is reported to the UI
+const overloadReportEvery = time.Second
+
+// wireEventStream pumps events from a reader into the ring and notifies the
+// UI. When the UI falls behind, the reader blocks, or with drop set, events
+// beyond overloadQueueSize are dropped; either is reported as an OverloadMsg.
+// The returned channel is closed once the pump stopped appending, after ctx
+// is cancelled or the reader is done.
+func wireEventStream(ctx context.Context, events <-chan core.LogEvent, errs <-chan error, ring *core.Ring, ui uiRefresher, drop bool) <-chan struct{} {
+	in := events
+	var dropped atomic.Int64
+	if drop {
+		queue := make(chan core.LogEvent, overloadQueueSize)
+		go func() {
+			defer close(queue)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case e, ok := <-in:
+					if !ok {
+						return
+					}
+					select {
+					case queue <- e:
+					default:
+						dropped.Add(1)
+					}
+				}
+			}
+		}()
+		events = queue
+	}
+	if ui != nil {
+		go reportOverload(ctx, ui, func() tui.OverloadMsg {
+			return tui.OverloadMsg{Dropped: dropped.Swap(0), Paused: !drop && cap(in) > 0 && len(in) == cap(in)}
+		})
+	}
+
 	// Events
+	pumped := make(chan struct{})
 	go func() {
+		defer close(pumped)
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case e, ok := <-events:
-				if !ok {
+				if !ok || ctx.Err() != nil {
 					return
 				}
 				e = ring.Append(e)
@@ -250,37 +822,241 @@ func wireEventStream(ctx context.Context, events <-chan core.LogEvent, errs <-ch
 				if !ok {
 					return
 				}
+				// A fatal error ends the session; Run reports it after the
+				// TUI has restored the terminal
+				if input.IsFatal(err) && ui != nil {
+					ui.Send(tui.InputFailedMsg{Err: err})
+					return
+				}
 				// Print to stderr; model also shows count via status if desired later
 				fmt.Fprintf(os.Stderr, "input error: %v\n", err)
 			}
 		}
 	}()
+	return pumped
+}
+
+// reportOverload sends the backpressure sampled every overloadReportEvery,
+// skipping repeats of an idle report
+func reportOverload(ctx context.Context, ui uiRefresher, sample func() tui.OverloadMsg) {
+	tick := time.NewTicker(overloadReportEvery)
+	defer tick.Stop()
+	var last tui.OverloadMsg
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			msg := sample()
+			if msg != last {
+				ui.Send(msg)
+			}
+			last = msg
+		}
+	}
+}
+
+// readerSource wires a reader into the ring and implements tui.Source, so a
+// seekable input can be restarted from the beginning.
+type readerSource struct {
+	ctx  context.Context
+	ring *core.Ring
+	ui   uiRefresher
+	drop bool // drop lines rather than block when the UI falls behind
+	open func(fromStart bool) input.Reader
+
+	mu     sync.Mutex
+	reader input.Reader
+	cancel context.CancelFunc
+	pumped <-chan struct{} // closed once the current pump stopped
+}
+
+func newReaderSource(ctx context.Context, ring *core.Ring, ui uiRefresher, drop bool, open func(fromStart bool) input.Reader) *readerSource {
+	return &readerSource{ctx: ctx, ring: ring, ui: ui, drop: drop, open: open}
+}
+
+// start (re)opens the reader and pumps its events into the ring
+func (s *readerSource) start(fromStart bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.cancel = cancel
+	s.reader = s.open(fromStart)
+	events, errs := s.reader.Start(ctx)
+	s.pumped = wireEventStream(ctx, events, errs, s.ring, s.ui, s.drop)
+}
+
+// Seekable implements tui.Source
+func (s *readerSource) Seekable() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reader != nil && s.reader.Seekable()
+}
+
+// Restart implements tui.Source; it is a no-op for non-seekable readers.
+// The old pump is waited for, so none of its events land after the clear.
+func (s *readerSource) Restart() {
+	if !s.Seekable() {
+		return
+	}
+	s.mu.Lock()
+	s.cancel()
+	pumped := s.pumped
+	s.mu.Unlock()
+	<-pumped
+	s.ring.Clear()
+	s.start(true)
 }
 
 // startFileReader initializes file tailing for the given path
-func startFileReader(ctx context.Context, filePath string, fromStart bool, numLines int, ring *core.Ring, ui uiRefresher) error {
-	// If numLines specified, prefill last N lines and then tail from end
+func startFileReader(ctx context.Context, filePath string, fromStart bool, numLines int, since time.Duration, keepCR, group, poll bool, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher, drop bool) (*readerSource, error) {
+	// If numLines or since is specified, prefill those lines and then tail from end
 	if numLines >= 0 {
-		_ = prefillLastLines(filePath, numLines, 16*1024*1024, ring, ui)
+		_ = prefillLastLines(filePath, "", numLines, 16*1024*1024, keepCR, detector, ring, ui)
+		fromStart = false
+	} else if since > 0 {
+		_ = prefillSince(filePath, "", time.Now().Add(-since), 16*1024*1024, keepCR, detector, ring, ui)
 		fromStart = false
 	}
 
-	reader := input.NewFileReader(filePath, fromStart)
-	events, errs := reader.Start(ctx)
-	wireEventStream(ctx, events, errs, ring, ui)
-	return nil
+	// With nothing to read up front, end the UI's loading state right away;
+	// otherwise the first event from the reader does it.
+	if ui != nil {
+		if info, err := os.Stat(filePath); !fromStart || err != nil || info.Size() == 0 {
+			ui.Send(tui.RefreshCmd()())
+		}
+	}
+
+	src := newReaderSource(ctx, ring, ui, drop, func(fromStart bool) input.Reader {
+		r := input.NewFileReader(filePath, fromStart)
+		r.SetKeepCR(keepCR)
+		r.SetGroupStackTraces(group)
+		r.SetPoll(poll)
+		r.SetDetector(detector)
+		return r
+	})
+	src.start(fromStart)
+	return src, nil
+}
+
+// startMultiFileReader tails several files as one merged stream, tagging
+// each event with the name of the file it came from.
+func startMultiFileReader(ctx context.Context, paths []string, fromStart bool, numLines int, since time.Duration, keepCR, group, poll bool, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher, drop bool) *readerSource {
+	origins := fileOrigins(paths)
+	if numLines >= 0 {
+		for i, path := range paths {
+			_ = prefillLastLines(path, origins[i], numLines, 16*1024*1024, keepCR, detector, ring, ui)
+		}
+		fromStart = false
+	} else if since > 0 {
+		cutoff := time.Now().Add(-since)
+		for i, path := range paths {
+			_ = prefillSince(path, origins[i], cutoff, 16*1024*1024, keepCR, detector, ring, ui)
+		}
+		fromStart = false
+	}
+
+	src := newReaderSource(ctx, ring, ui, drop, func(fromStart bool) input.Reader {
+		readers := make([]input.Reader, len(paths))
+		for i, path := range paths {
+			r := input.NewFileReader(path, fromStart)
+			r.SetKeepCR(keepCR)
+			r.SetGroupStackTraces(group)
+			r.SetPoll(poll)
+			r.SetDetector(detector)
+			r.SetOrigin(origins[i])
+			readers[i] = r
+		}
+		return input.NewFanIn(readers...)
+	})
+	src.start(fromStart)
+	if ui != nil {
+		// Some of the files may be empty; don't wait on them
+		ui.Send(tui.RefreshCmd()())
+	}
+	return src
+}
+
+// sourcePaths maps the origin of file events to the path they were read from
+func sourcePaths(config Config) map[string]string {
+	if len(config.FilePaths) <= 1 {
+		return map[string]string{"": config.FilePath}
+	}
+	paths := make(map[string]string, len(config.FilePaths))
+	for i, origin := range fileOrigins(config.FilePaths) {
+		paths[origin] = config.FilePaths[i]
+	}
+	return paths
+}
+
+// fileOrigins returns the label shown for each file: its base name, or the
+// path as given when two files share a base name.
+func fileOrigins(paths []string) []string {
+	count := make(map[string]int)
+	for _, path := range paths {
+		count[filepath.Base(path)]++
+	}
+	origins := make([]string, len(paths))
+	for i, path := range paths {
+		origins[i] = filepath.Base(path)
+		if count[origins[i]] > 1 {
+			origins[i] = path
+		}
+	}
+	return origins
+}
+
+// startLatestFileReader tails the newest file in dir, switching as newer files appear
+func startLatestFileReader(ctx context.Context, dir, glob string, fromStart, keepCR, group bool, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher, drop bool) *readerSource {
+	src := newReaderSource(ctx, ring, ui, drop, func(fromStart bool) input.Reader {
+		r := input.NewLatestFileReader(dir, glob, fromStart)
+		r.SetKeepCR(keepCR)
+		r.SetGroupStackTraces(group)
+		r.SetDetector(detector)
+		return r
+	})
+	src.start(fromStart)
+	if ui != nil {
+		// The newest file may be empty or not exist yet; don't wait on it
+		ui.Send(tui.RefreshCmd()())
+	}
+	return src
 }
 
 // startStdinReader initializes stdin streaming
-func startStdinReader(ctx context.Context, ring *core.Ring, ui uiRefresher) error {
-	reader := input.NewStdinReader()
-	events, errs := reader.Start(ctx)
-	wireEventStream(ctx, events, errs, ring, ui)
-	return nil
+func startStdinReader(ctx context.Context, keepCR, group bool, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher, drop bool) (*readerSource, error) {
+	src := newReaderSource(ctx, ring, ui, drop, func(bool) input.Reader {
+		r := newStdinReader(keepCR, group)
+		r.SetDetector(detector)
+		return r
+	})
+	src.start(false)
+	return src, nil
+}
+
+// newStdinReader creates the stdin reader, keeping CRLF \r and grouping
+// stack traces when asked
+func newStdinReader(keepCR, group bool) *input.StdinReader {
+	r := input.NewStdinReader()
+	r.SetKeepCR(keepCR)
+	r.SetGroupStackTraces(group)
+	return r
+}
+
+// startCommandReader runs the configured command and streams its output
+func startCommandReader(ctx context.Context, config Config, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher) *readerSource {
+	src := newReaderSource(ctx, ring, ui, config.DropOnOverload, func(bool) input.Reader {
+		return newCommandReader(config, detector)
+	})
+	src.start(false)
+	return src
 }
 
 // startDockerReader initializes docker container streaming
-func startDockerReader(ctx context.Context, ring *core.Ring, levels *core.LevelMap, ui uiRefresher) error {
+func startDockerReader(ctx context.Context, config Config, ring *core.Ring, levels *core.LevelMap, ui uiRefresher) error {
 	// Create real docker client
 	real, err := dockerx.NewRealClient()
 	if err != nil {
@@ -293,14 +1069,16 @@ func startDockerReader(ctx context.Context, ring *core.Ring, levels *core.LevelM
 
 	detector := core.NewDefaultSeverityDetector(levels)
 	reader := input.NewDockerReader(real, detector)
+	reader.SetRefreshInterval(config.DockerRefresh)
+	reader.SetSince(config.Since)
 
 	events, errs := reader.Start(ctx)
-	wireEventStream(ctx, events, errs, ring, ui)
+	wireEventStream(ctx, events, errs, ring, ui, config.DropOnOverload)
 
 	// Periodically push container list snapshots to the UI
 	go func() {
 		// Send an initial snapshot soon after start
-		tick := time.NewTicker(2 * time.Second)
+		tick := time.NewTicker(cmp.Or(config.DockerListRefresh, defaultDockerListRefresh))
 		defer tick.Stop()
 		for {
 			// Build name->visible map (default visible=true)
@@ -328,23 +1106,110 @@ func startDockerReader(ctx context.Context, ring *core.Ring, levels *core.LevelM
 	return nil
 }
 
+// prefillProgressEvery controls how often prefillLastLines reports progress.
+const prefillProgressEvery = 10000
+
 // prefillLastLines reads up to the last N lines (bounded by maxBytes) and appends them to the ring.
 // This does not affect the tailer position; it's just an initial snapshot for user context.
-func prefillLastLines(path string, maxLines int, maxBytes int64, ring *core.Ring, ui uiRefresher) error {
-	f, err := os.Open(path)
+func prefillLastLines(path, origin string, maxLines int, maxBytes int64, keepCR bool, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher) error {
+	var progress func(lines int, bytes int64)
+	if ui != nil {
+		progress = func(lines int, bytes int64) {
+			ui.Send(tui.LoadProgressMsg{Lines: lines, Bytes: bytes})
+		}
+	}
+	base := prefillLineBase(path)
+	all, err := readLastLines(path, time.Time{}, maxLines, maxBytes, keepCR, progress)
 	if err != nil {
 		return err
 	}
+	appendPrefill(all, base, origin, detector, ring, ui)
+	return nil
+}
+
+// prefillSince appends the lines of path (bounded by maxBytes from the end and
+// by the ring's capacity) from the first one stamped at or after cutoff; the
+// lines that follow it are kept whether or not they have a timestamp, e.g.
+// stack traces.
+func prefillSince(path, origin string, cutoff time.Time, maxBytes int64, keepCR bool, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher) error {
+	var progress func(lines int, bytes int64)
+	if ui != nil {
+		progress = func(lines int, bytes int64) {
+			ui.Send(tui.LoadProgressMsg{Lines: lines, Bytes: bytes})
+		}
+	}
+	base := prefillLineBase(path)
+	all, err := readLastLines(path, cutoff, ring.Capacity(), maxBytes, keepCR, progress)
+	if err != nil {
+		return err
+	}
+	appendPrefill(all, base, origin, detector, ring, ui)
+	return nil
+}
+
+// prefillLineBase counts the lines path has now, when a prefilled line
+// number is first needed: prefilled lines are the last ones of those.
+func prefillLineBase(path string) *core.LineBase {
+	size := int64(math.MaxInt64)
+	if st, err := os.Stat(path); err == nil && !input.IsGzip(path) {
+		size = st.Size()
+	}
+	return core.NewLineBase(func() (int, error) {
+		n, _, err := input.CountLinesBefore(path, size)
+		return n, err
+	})
+}
+
+// appendPrefill appends prefilled lines to the ring in order, numbering them
+// as the last lines counted by base. Lines are sanitized like the readers do.
+func appendPrefill(all []string, base *core.LineBase, origin string, detector core.SeverityDetector, ring *core.Ring, ui uiRefresher) {
+	for i, line := range all {
+		line = core.SanitizeLine(line)
+		event := core.LogEvent{
+			Time:      time.Now(),
+			Source:    core.SourceFile,
+			Origin:    origin,
+			Line:      line,
+			Level:     core.SevUnknown,
+			LevelStr:  "",
+			Container: "",
+			LineNo:    i - len(all) + 1,
+			LineBase:  base,
+		}
+		if detector != nil {
+			event.LevelStr, event.Level, _ = detector.Detect(line)
+		}
+		ring.Append(event)
+	}
+	if ui != nil && len(all) > 0 {
+		ui.Send(tui.RefreshCmd()())
+	}
+}
+
+// readLastLines returns up to the last maxLines lines of path, reading at most
+// maxBytes from the end and, with a non-zero cutoff, skipping the lines before
+// the first one stamped at or after it. The \r of CRLF lines is stripped
+// unless keepCR is set. progress, if set, is called every
+// prefillProgressEvery lines.
+func readLastLines(path string, cutoff time.Time, maxLines int, maxBytes int64, keepCR bool, progress func(lines int, bytes int64)) ([]string, error) {
+	if input.IsGzip(path) {
+		return readLastGzipLines(path, cutoff, maxLines, keepCR, progress)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 
 	st, err := f.Stat()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	size := st.Size()
 	if size == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Determine how many bytes to read from the end
@@ -356,49 +1221,93 @@ func prefillLastLines(path string, maxLines int, maxBytes int64, ring *core.Ring
 	// Seek to start position for reading
 	start := size - readBytes
 	if _, err := f.Seek(start, 0); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Read chunk
 	buf := make([]byte, readBytes)
 	if _, err := io.ReadFull(f, buf); err != nil && err != io.ErrUnexpectedEOF {
-		return err
+		return nil, err
 	}
 
 	// Split into lines; if we started mid-line, drop the first partial
 	lines := bufio.NewScanner(bytes.NewReader(buf))
-	lines.Split(bufio.ScanLines)
-	var all []string
+	lines.Split(scanRawLines)
+	add, kept := keepLastLines(cutoff, maxLines)
+	var (
+		n       int
+		scanned int64
+	)
 	for lines.Scan() {
-		all = append(all, lines.Text())
+		scanned += int64(len(lines.Bytes()))
+		if n++; n > 1 || start == 0 {
+			_ = add(input.TrimLineEnd(lines.Text(), keepCR))
+		}
+		if progress != nil && n%prefillProgressEvery == 0 {
+			progress(n, scanned)
+		}
 	}
-	if len(all) == 0 {
+	return kept(), nil
+}
+
+// keepLastLines returns add, which keeps the last maxLines lines passed to it
+// from the first one stamped at or after cutoff (a zero cutoff keeps every
+// line), and kept, which returns them.
+func keepLastLines(cutoff time.Time, maxLines int) (add func(line string) error, kept func() []string) {
+	var last []string
+	add = fromCutoff(cutoff, func(line string) error {
+		if last = append(last, line); len(last) > maxLines {
+			last = last[1:]
+		}
 		return nil
+	})
+	return add, func() []string { return last }
+}
+
+// scanRawLines is bufio.ScanLines keeping the line ending, so the caller
+// decides whether the \r of a CRLF line stays
+func scanRawLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
 	}
-	// If we did not start at byte 0, the first scanned line is partial; drop it
-	if start > 0 {
-		all = all[1:]
-	}
-	// Keep only the last maxLines
-	if len(all) > maxLines {
-		all = all[len(all)-maxLines:]
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
 	}
+	return 0, nil, nil
+}
 
-	// Append to ring in order
-	for _, line := range all {
-		ring.Append(core.LogEvent{
-			Time:      time.Now(),
-			Source:    core.SourceFile,
-			Line:      line,
-			Level:     core.SevUnknown,
-			LevelStr:  "",
-			Container: "",
-		})
+// readLastGzipLines returns the last maxLines lines of a gzip-compressed
+// file from cutoff as readLastLines does; the file has to be decompressed
+// from the start, keeping no more than maxLines lines at a time.
+func readLastGzipLines(path string, cutoff time.Time, maxLines int, keepCR bool, progress func(lines int, bytes int64)) ([]string, error) {
+	r, err := input.OpenDecompressed(path)
+	if err != nil {
+		return nil, err
 	}
-	if ui != nil && len(all) > 0 {
-		ui.Send(tui.RefreshCmd()())
+	defer r.Close()
+
+	add, kept := keepLastLines(cutoff, maxLines)
+	var (
+		lines   int
+		scanned int64
+	)
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			scanned += int64(len(line))
+			_ = add(input.TrimLineEnd(line, keepCR))
+			if lines++; progress != nil && lines%prefillProgressEvery == 0 {
+				progress(lines, scanned)
+			}
+		}
+		if err == io.EOF {
+			return kept(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
-	return nil
 }
 
 // usage string for the CLI
@@ -406,13 +1315,23 @@ const usage = `siftail - a TUI for tailing and exploring logs
 
 USAGE:
   siftail [flags] [file]       # file mode - tail a file
+  siftail [flags] FILE FILE... # file mode - tail several files as one merged view
+  siftail --latest [flags] DIR # file mode - tail the newest file in DIR
   siftail docker               # docker mode - stream from all running containers
+  siftail --cmd COMMAND [ARGS] # command mode - run COMMAND and read its output
+  siftail --winevent LOG       # command mode - read a Windows event log (wevtutil)
   <command> | siftail          # stdin mode - read piped input as live stream
 
 EXAMPLES:
   siftail /var/log/app.log     # tail a file with rotation awareness
+  siftail a.log b.log          # tail both, each line tagged [a.log] / [b.log]
   siftail docker               # stream from all Docker containers
   journalctl -f | siftail      # tail systemd journal via stdin
+  siftail --cmd ssh web1 tail -F /var/log/app.log
+                               # tail a remote file; no shell quoting needed
+  siftail --winevent System    # Windows System log, one event per entry
+  siftail --latest --glob "app-*.log" /var/log/app
+                               # follow the newest rolled file
 
 FLAGS:
   -h, --help                   show this help message
@@ -420,9 +1339,86 @@ FLAGS:
   --buffer-size N              ring buffer size (default: 10000)
   --from-start                 start reading from beginning of file (file mode; default)
   -n, --num-lines N            prefill last N lines (file mode; overrides --from-start)
+  --since DURATION             include the last DURATION of logs (e.g. 10m): docker
+                               mode reads the backlog of the containers running at
+                               start; file mode prefills the lines from the first
+                               one timestamped within DURATION, then tails
+  --latest                     tail the newest file in a directory, switching when
+                               a newer one appears (new files are read from the start)
+  --glob PATTERN               with --latest, only consider matching file names
+  --cmd                        run the remaining arguments as a command and read
+                               its stdout (no shell; use sh -c '...' for pipes)
+  --winevent LOG               read a Windows event log (System, Application, ...)
+                               via "wevtutil qe LOG /f:text", one event per entry
   --theme NAME                 UI theme (dark, dracula, nord, light)
   --no-color                   disable colored output
-  --time-format FORMAT         timestamp format (default: "15:04:05.000")
+  --time-format FORMAT         timestamp format as a Go time layout
+                               (default: "15:04:05.000")
+  --tz ZONE                    time zone for timestamps: local (default), utc or
+                               a name like Europe/Berlin
+  --idle-timeout DURATION      pause following after no key input for DURATION
+                               (e.g. 10m); any key resumes (default: 0, disabled)
+  --burst-gap DURATION         draw a faint separator between lines more than
+                               DURATION apart (e.g. 5s; default: 0, disabled)
+  --chrono                     order lines by the timestamps they start with (or
+                               Docker's) instead of arrival, so several files or
+                               containers merge into one timeline; costs a sort
+                               per refresh
+  --dump-levels[=json]         print the level map discovered in the input (slot,
+                               name, enabled) and exit; docker mode runs until
+                               Ctrl+C
+  --docker-refresh DURATION    how often to re-list containers as a fallback to
+                               start events (docker mode; 1s to 1h, default 30s)
+  --docker-list-refresh DURATION
+                               how often the container list in the UI updates
+                               (docker mode; 250ms to 1m, default 2s)
+  --snapshot                   print the lines that pass --filter-in/--filter-out
+                               once and exit (file mode; honors -n and --since;
+                               --highlight matches are colored unless --no-color)
+  --output FORMAT              snapshot output: text (default) or json, one object
+                               per line with seq, time, source, container, level,
+                               levelStr and line (never colored)
+  --force-tui                  launch the TUI even when stdout is redirected (by
+                               default, redirected output gets plain lines)
+  --profile ops                start in the ops view: DEBUG/TRACE hidden, panic/
+                               exception/fatal highlighted ("opsKeywords" in
+                               config.json replaces the keywords)
+  --stats                      show lines/s and error rate in the status line
+  --error-nav                  ] and [ jump to the next/previous error line
+  --ops                        same as --profile ops --stats --error-nav; any of
+                               them can be overridden (e.g. --ops --stats=false)
+  --filter-in PATTERN          show only matching lines (text or /regex/; repeatable;
+                               +PATTERN must match on every shown line)
+  --filter-out PATTERN         hide matching lines (repeatable)
+  --highlight PATTERN          highlight matches (repeatable; field:status>=500
+                               compares a JSON/logfmt field)
+  --alert PATTERN              post new lines matching PATTERN (text or /regex/;
+                               repeatable) to --alert-webhook
+  --alert-webhook URL          receive a JSON POST per alert match (at most one
+                               per second; skipped matches are counted)
+  --links                      emphasize URLs and paths; URLs become clickable
+                               OSC 8 links where the terminal supports them
+  --columns F1,F2,...          JSON fields (dotted paths allowed) used as table
+                               columns when copying visible rows as Markdown (M)
+  --alias REAL=FRIENDLY        show container REAL as FRIENDLY (docker mode;
+                               repeatable; also "containerAliases" in config.json)
+  --keep-cr                    keep the trailing \r of CRLF lines (stripped by
+                               default in file, stdin and command input)
+  --group-stack-traces         join indented and "Caused by" continuation lines
+                               onto the line before them, making each stack
+                               trace one event (file and stdin input)
+  --poll                       check tailed files every 500ms instead of waiting
+                               for file events (NFS and other network
+                               filesystems); used automatically when events
+                               are unavailable or stay silent
+  --drop-on-overload           drop input lines instead of pausing the readers
+                               when the UI can't keep up (shown in the status line)
+  --no-mouse                   disable mouse capture; native terminal selection works,
+                               but in-app drag-to-copy and wheel scrolling are lost
+
+EXIT STATUS:
+  0 on a normal quit, 1 on usage or startup errors, 3 when the input fails for
+  good mid-session (file unreadable, Docker unreachable, command not started)
 
 HOTKEYS (once running):
   q, Ctrl+C                    quit
@@ -437,7 +1433,8 @@ HOTKEYS (once running):
 SEVERITY LEVELS:
   Default mapping: 1=DEBUG, 2=INFO, 3=WARN, 4=ERROR
   Custom levels automatically assigned to slots 5-9
-  Overflow levels grouped into 9:OTHER
+  Overflow levels grouped into 9:OTHER ("levelOverflow" and "overflowLabel"
+  in config.json: collapse, rotate to the latest level, or warn)
 `
 
 // ValidateConfig performs additional validation on the parsed configuration
@@ -450,6 +1447,93 @@ func ValidateConfig(config Config) error {
 		return errors.New("buffer-size too large (maximum: 1,000,000)")
 	}
 
+	if config.Glob != "" {
+		if !config.Latest {
+			return errors.New("--glob requires --latest")
+		}
+		if _, err := filepath.Match(config.Glob, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", config.Glob, err)
+		}
+	}
+
+	if config.Snapshot && config.Mode != tui.ModeFile {
+		return errors.New("--snapshot needs a file")
+	}
+	if config.Snapshot && len(config.FilePaths) > 1 {
+		return errors.New("--snapshot takes a single file")
+	}
+	switch config.OutputFormat {
+	case "", "text":
+	case "json":
+		if !config.Snapshot {
+			return errors.New("--output json requires --snapshot")
+		}
+	default:
+		return fmt.Errorf("unknown output format %q (want text or json)", config.OutputFormat)
+	}
+
+	if config.Profile != "" && config.Profile != tui.ProfileOps {
+		return fmt.Errorf("unknown profile %q (want %q)", config.Profile, tui.ProfileOps)
+	}
+
+	if (len(config.Alerts) > 0) != (config.AlertURL != "") {
+		return errors.New("--alert and --alert-webhook must be used together")
+	}
+	if config.AlertURL != "" {
+		u, err := url.Parse(config.AlertURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid alert webhook URL %q", config.AlertURL)
+		}
+	}
+	for _, p := range config.Alerts {
+		if _, err := core.NewMatcher(p); err != nil {
+			return fmt.Errorf("invalid alert pattern %q: %w", p, err)
+		}
+	}
+	if err := applyFilterFlags(config, core.NewFilters()); err != nil {
+		return err
+	}
+
+	// Zero keeps the default
+	if config.DockerRefresh != 0 && (config.DockerRefresh < time.Second || config.DockerRefresh > time.Hour) {
+		return errors.New("docker-refresh must be between 1s and 1h")
+	}
+	if config.DockerListRefresh != 0 && (config.DockerListRefresh < 250*time.Millisecond || config.DockerListRefresh > time.Minute) {
+		return errors.New("docker-list-refresh must be between 250ms and 1m")
+	}
+
+	if config.IdleTimeout < 0 {
+		return errors.New("idle-timeout must not be negative")
+	}
+	if config.BurstGap < 0 {
+		return errors.New("burst-gap must not be negative")
+	}
+	if config.Since < 0 {
+		return errors.New("since must not be negative")
+	}
+	if config.Since > 0 {
+		if config.Mode != tui.ModeFile && config.Mode != tui.ModeDocker {
+			return errors.New("--since works in docker and file modes only")
+		}
+		if config.Latest {
+			return errors.New("--since can't be combined with --latest")
+		}
+		if config.NumLines >= 0 {
+			return errors.New("--since and -n can't be combined")
+		}
+	}
+
+	if config.Poll && (config.Mode != tui.ModeFile || config.Latest) {
+		return errors.New("--poll works in file mode only, without --latest")
+	}
+	if config.GroupStackTraces && config.Mode != tui.ModeFile && config.Mode != tui.ModeStdin {
+		return errors.New("--group-stack-traces works in file and stdin modes only")
+	}
+
+	if _, err := loadTimeZone(config.TZ); err != nil {
+		return err
+	}
+
 	// Validate time format
 	if config.TimeFormat != "" {
 		// Try to format a test time to validate the format
@@ -462,14 +1546,33 @@ func ValidateConfig(config Config) error {
 	return nil
 }
 
-// parseTimeFormat validates a time format string
+// loadTimeZone resolves a --tz value; empty means local time
+func loadTimeZone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// parseTimeFormat validates a Go time layout by formatting the reference
+// time (Mon Jan 2 15:04:05 MST 2006, Unix 1136239445) with it; a layout
+// without any time element comes out unchanged. Date-only layouts also
+// reproduce the reference date in UTC, so a later date is tried as well.
 func parseTimeFormat(format string) (string, error) {
-	// This is a simplified validation - in a real implementation,
-	// we would use time.Parse with the Go reference time
-	// Mon Jan 2 15:04:05 MST 2006, which is Unix time 1136239445
 	if len(format) == 0 {
 		return "", errors.New("empty time format")
 	}
+	ref := time.Unix(1136239445, 0).UTC()
+	if ref.Format(format) == format && ref.AddDate(1, 1, 1).Format(format) == format {
+		return "", fmt.Errorf("%q has no time elements; use Go layout like 15:04:05", format)
+	}
 
 	return format, nil
 }
@@ -483,6 +1586,8 @@ func GetModeString(mode tui.Mode) string {
 		return "stdin"
 	case tui.ModeDocker:
 		return "docker"
+	case tui.ModeCommand:
+		return "command"
 	default:
 		return "unknown"
 	}
@@ -492,7 +1597,9 @@ func GetModeString(mode tui.Mode) string {
 func PrintConfig(config Config) {
 	fmt.Printf("Configuration:\n")
 	fmt.Printf("  Mode: %s\n", GetModeString(config.Mode))
-	if config.FilePath != "" {
+	if len(config.FilePaths) > 1 {
+		fmt.Printf("  File Paths: %s\n", strings.Join(config.FilePaths, ", "))
+	} else if config.FilePath != "" {
 		fmt.Printf("  File Path: %s\n", config.FilePath)
 	}
 	fmt.Printf("  Buffer Size: %d\n", config.BufferSize)
@@ -1,9 +1,25 @@
 package cli
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	xansi "github.com/charmbracelet/x/ansi"
+
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/input"
+	"github.com/germanoeich/siftail/internal/persist"
 	"github.com/germanoeich/siftail/internal/tui"
 )
 
@@ -37,6 +53,57 @@ func TestCLI_FileMode_StartsTailer(t *testing.T) {
 	}
 }
 
+func TestParseArgs_Latest(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := ParseArgs([]string{"--latest", "--glob", "*.log", dir})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if config.Mode != tui.ModeFile || !config.Latest || config.FilePath != dir || config.Glob != "*.log" {
+		t.Errorf("Unexpected config for --latest: %+v", config)
+	}
+	if err := ValidateConfig(config); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	if _, err := ParseArgs([]string{dir}); err == nil || !strings.Contains(err.Error(), "--latest") {
+		t.Errorf("Expected directory without --latest to suggest --latest, got %v", err)
+	}
+
+	file := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if _, err := ParseArgs([]string{"--latest", file}); err == nil {
+		t.Error("Expected error when --latest is given a file")
+	}
+
+	if err := ValidateConfig(Config{BufferSize: 10000, Glob: "*.log"}); err == nil {
+		t.Error("Expected error for --glob without --latest")
+	}
+	if err := ValidateConfig(Config{BufferSize: 10000, Latest: true, Glob: "[bad"}); err == nil {
+		t.Error("Expected error for malformed glob")
+	}
+}
+
+func TestParseArgs_Alias(t *testing.T) {
+	config, err := ParseArgs([]string{"--alias", "shop_api_1=api", "--alias", " 3f2a9c = worker ", "docker"})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	want := map[string]string{"shop_api_1": "api", "3f2a9c": "worker"}
+	if !reflect.DeepEqual(config.Aliases, want) {
+		t.Errorf("Expected aliases %v, got %v", want, config.Aliases)
+	}
+
+	for _, bad := range []string{"noequals", "=api", "shop_api_1="} {
+		if _, err := ParseArgs([]string{"--alias", bad, "docker"}); err == nil {
+			t.Errorf("Expected error for --alias %q", bad)
+		}
+	}
+}
+
 func TestCLI_DockerMode_StartsDockerReader_Fake(t *testing.T) {
 	// Test docker mode detection
 	args := []string{"docker"}
@@ -144,6 +211,33 @@ func TestParseArgs_ValidFlags(t *testing.T) {
 	}
 }
 
+func TestProgramOptions_NoMouse(t *testing.T) {
+	mouse := reflect.ValueOf(tea.WithMouseCellMotion()).Pointer()
+	hasMouse := func(opts []tea.ProgramOption) bool {
+		for _, opt := range opts {
+			if reflect.ValueOf(opt).Pointer() == mouse {
+				return true
+			}
+		}
+		return false
+	}
+
+	config, err := ParseArgs([]string{"--no-mouse", "docker"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.NoMouse {
+		t.Fatalf("expected --no-mouse to set NoMouse")
+	}
+	if hasMouse(programOptions(config)) {
+		t.Errorf("expected program options to exclude mouse capture with --no-mouse")
+	}
+
+	if !hasMouse(programOptions(DefaultConfig())) {
+		t.Errorf("expected program options to include mouse capture by default")
+	}
+}
+
 func TestParseArgs_HelpAndVersion(t *testing.T) {
 	testCases := []struct {
 		args          []string
@@ -224,6 +318,121 @@ func TestValidateConfig(t *testing.T) {
 			expectError: false,
 			description: "valid config",
 		},
+		{
+			config:      Config{BufferSize: 10000, TimeFormat: "2006-01-02"},
+			expectError: false,
+			description: "date-only time format",
+		},
+		{
+			config:      Config{BufferSize: 10000, TimeFormat: "hh:mm:ss"},
+			expectError: true,
+			description: "time format without Go layout elements",
+		},
+		{
+			config:      Config{BufferSize: 10000, IdleTimeout: -time.Second},
+			expectError: true,
+			description: "negative idle timeout",
+		},
+		{
+			config:      Config{BufferSize: 10000, BurstGap: -time.Second},
+			expectError: true,
+			description: "negative burst gap",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeDocker, NumLines: -1, Since: 10 * time.Minute},
+			expectError: false,
+			description: "since in docker mode",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeStdin, NumLines: -1, Since: 10 * time.Minute},
+			expectError: true,
+			description: "since in stdin mode",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeFile, NumLines: 100, Since: 10 * time.Minute},
+			expectError: true,
+			description: "since combined with -n",
+		},
+		{
+			config:      Config{BufferSize: 10000, DockerRefresh: 100 * time.Millisecond},
+			expectError: true,
+			description: "docker refresh too short",
+		},
+		{
+			config:      Config{BufferSize: 10000, DockerListRefresh: 2 * time.Minute},
+			expectError: true,
+			description: "docker list refresh too long",
+		},
+		{
+			config:      Config{BufferSize: 10000, DockerRefresh: 5 * time.Second, DockerListRefresh: 500 * time.Millisecond},
+			expectError: false,
+			description: "valid docker refresh intervals",
+		},
+		{
+			config:      Config{BufferSize: 10000, TZ: "Mars/Olympus_Mons"},
+			expectError: true,
+			description: "unknown time zone",
+		},
+		{
+			config:      Config{BufferSize: 10000, TZ: "UTC"},
+			expectError: false,
+			description: "utc time zone",
+		},
+		{
+			config:      Config{BufferSize: 10000, TZ: "America/New_York"},
+			expectError: false,
+			description: "named time zone",
+		},
+		{
+			config:      Config{BufferSize: 10000, Alerts: []string{"panic"}},
+			expectError: true,
+			description: "alert without webhook",
+		},
+		{
+			config:      Config{BufferSize: 10000, Alerts: []string{"panic"}, AlertURL: "hooks.example.com"},
+			expectError: true,
+			description: "alert webhook without scheme",
+		},
+		{
+			config:      Config{BufferSize: 10000, Alerts: []string{"panic", "/fatal/"}, AlertURL: "https://hooks.example.com/x"},
+			expectError: false,
+			description: "valid alert webhook",
+		},
+		{
+			config:      Config{BufferSize: 10000, FilterIn: []string{"+"}},
+			expectError: true,
+			description: "filter-in with nothing after +",
+		},
+		{
+			config:      Config{BufferSize: 10000, FilterOut: []string{"/[/"}},
+			expectError: true,
+			description: "invalid filter-out regex",
+		},
+		{
+			config:      Config{BufferSize: 10000, FilterIn: []string{"+api"}, FilterOut: []string{"/health/"}, Highlight: []string{"503", "/time(out)?/"}},
+			expectError: false,
+			description: "valid filter flags",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeStdin, GroupStackTraces: true},
+			expectError: false,
+			description: "group-stack-traces with stdin",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeDocker, GroupStackTraces: true},
+			expectError: true,
+			description: "group-stack-traces in docker mode",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeFile, Poll: true},
+			expectError: false,
+			description: "poll in file mode",
+		},
+		{
+			config:      Config{BufferSize: 10000, Mode: tui.ModeFile, Latest: true, Poll: true},
+			expectError: true,
+			description: "poll with --latest",
+		},
 	}
 
 	for i, tc := range testCases {
@@ -239,6 +448,39 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestParseArgs_RepeatableFilterFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := ParseArgs([]string{"--highlight", "503", "--filter-in", "api", "--highlight", "/time(out)?/", "--filter-out", "health", "--highlight", "cs:WARN", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config.Highlight, []string{"503", "/time(out)?/", "cs:WARN"}) {
+		t.Errorf("Highlight = %q", config.Highlight)
+	}
+	if !reflect.DeepEqual(config.FilterIn, []string{"api"}) || !reflect.DeepEqual(config.FilterOut, []string{"health"}) {
+		t.Errorf("FilterIn = %q, FilterOut = %q", config.FilterIn, config.FilterOut)
+	}
+
+	filters := core.NewFilters()
+	if err := applyFilterFlags(config, filters); err != nil {
+		t.Fatal(err)
+	}
+	if len(filters.Highlights) != 3 || len(filters.Include) != 1 || len(filters.Exclude) != 1 {
+		t.Fatalf("got %d highlights, %d includes, %d excludes", len(filters.Highlights), len(filters.Include), len(filters.Exclude))
+	}
+	if !filters.ShouldHighlight("request timed out") || !filters.ShouldHighlight("WARN disk") || filters.ShouldHighlight("warn disk") {
+		t.Error("highlights not applied as given")
+	}
+
+	config.Highlight = append(config.Highlight, "/(/")
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "--highlight") {
+		t.Errorf("expected an error naming --highlight, got %v", err)
+	}
+}
+
 func TestParseBufferSize(t *testing.T) {
 	testCases := []struct {
 		input    string
@@ -313,20 +555,634 @@ func TestDefaultConfig(t *testing.T) {
 
 func TestDetermineMode(t *testing.T) {
 	// Test with docker argument
-	mode, filePath, err := determineMode([]string{"docker"})
+	mode, filePaths, err := determineMode([]string{"docker"})
 	if err != nil {
 		t.Errorf("Unexpected error for docker mode: %v", err)
 	}
 	if mode != tui.ModeDocker {
 		t.Errorf("Expected ModeDocker, got %v", mode)
 	}
-	if filePath != "" {
-		t.Errorf("Expected empty file path for docker mode, got %s", filePath)
+	if filePaths != nil {
+		t.Errorf("Expected no file paths for docker mode, got %q", filePaths)
 	}
 
-	// Test with too many arguments
+	// Several files are all validated
 	_, _, err = determineMode([]string{"arg1", "arg2", "arg3"})
 	if err == nil {
-		t.Error("Expected error for too many arguments")
+		t.Error("Expected error for missing files")
+	}
+}
+
+func TestParseArgs_MultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"a.log", "b.log"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	config, err := ParseArgs(paths)
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if config.Mode != tui.ModeFile || config.FilePath != paths[0] || !reflect.DeepEqual(config.FilePaths, paths) {
+		t.Errorf("Expected file mode with both paths, got %v %q %q", config.Mode, config.FilePath, config.FilePaths)
+	}
+	if !reflect.DeepEqual(launchArgs(config), paths) {
+		t.Errorf("launchArgs = %q, want %q", launchArgs(config), paths)
+	}
+
+	if _, err := ParseArgs([]string{paths[0], filepath.Join(dir, "missing.log")}); err == nil {
+		t.Error("Expected error for a missing second file")
+	}
+}
+
+func TestFileOrigins_DisambiguateSameName(t *testing.T) {
+	got := fileOrigins([]string{"/var/log/a/app.log", "/var/log/b/app.log", "/tmp/db.log"})
+	want := []string{"/var/log/a/app.log", "/var/log/b/app.log", "db.log"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fileOrigins = %q, want %q", got, want)
+	}
+}
+
+func TestStartMultiFileReader_TagsOrigins(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")
+	if err := os.WriteFile(a, []byte("a1\na2\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.WriteFile(b, []byte("b1\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	ring := core.NewRing(100)
+	src := startMultiFileReader(ctx, []string{a, b}, true, -1, 0, false, false, false, nil, ring, nil, false)
+	waitForRingSize(t, ring, 3)
+	origins := make(map[string]string)
+	for _, e := range ring.Snapshot() {
+		origins[e.Line] = e.Origin
+	}
+	want := map[string]string{"a1": "a.log", "a2": "a.log", "b1": "b.log"}
+	if !reflect.DeepEqual(origins, want) {
+		t.Errorf("origins = %v, want %v", origins, want)
+	}
+	if !src.Seekable() {
+		t.Error("Expected merged files to be seekable")
+	}
+
+	// Prefill tags the lines too
+	ring = core.NewRing(100)
+	startMultiFileReader(ctx, []string{a, b}, true, 1, 0, false, false, false, nil, ring, nil, false)
+	waitForRingSize(t, ring, 2)
+	snap := ring.Snapshot()
+	if snap[0].Line != "a2" || snap[0].Origin != "a.log" || snap[1].Line != "b1" || snap[1].Origin != "b.log" {
+		t.Errorf("prefill = %+v", snap)
+	}
+}
+
+type recordingUI struct {
+	msgs []tea.Msg
+}
+
+func (r *recordingUI) Send(msg tea.Msg) { r.msgs = append(r.msgs, msg) }
+
+// chanUI forwards messages sent from reader goroutines
+type chanUI chan tea.Msg
+
+func (c chanUI) Send(msg tea.Msg) { c <- msg }
+
+func TestWireEventStream_DropsLinesForSlowUI(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan core.LogEvent, 50)
+	ring := core.NewRing(10 * overloadQueueSize)
+	// Nobody reads the UI yet, so the pump stalls on its first notification
+	ui := make(chanUI)
+	wireEventStream(ctx, events, make(chan error), ring, ui, true)
+
+	total := 3 * overloadQueueSize
+	for i := 0; i < total; i++ {
+		select {
+		case events <- core.LogEvent{Line: "line"}:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("reader blocked after %d lines despite the drop policy", i)
+		}
+	}
+
+	deadline := time.After(3 * overloadReportEvery)
+	for {
+		select {
+		case m := <-ui:
+			if o, ok := m.(tui.OverloadMsg); ok {
+				if o.Dropped == 0 {
+					t.Fatalf("overload report without drops: %+v", o)
+				}
+				if kept := ring.Size(); int64(kept)+o.Dropped > int64(total) {
+					t.Errorf("kept %d and dropped %d of %d lines", kept, o.Dropped, total)
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("no overload report")
+		}
+	}
+}
+
+func TestWireEventStream_FatalErrorEndsSession(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	ui := make(chanUI, 1)
+	wireEventStream(ctx, make(chan core.LogEvent), errs, core.NewRing(10), ui, false)
+
+	errs <- &input.FatalError{Err: errors.New("permission denied")}
+	var msg tui.InputFailedMsg
+	select {
+	case m := <-ui:
+		var ok bool
+		if msg, ok = m.(tui.InputFailedMsg); !ok {
+			t.Fatalf("expected InputFailedMsg, got %T", m)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("fatal error not forwarded to the UI")
+	}
+
+	model := tui.NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), tui.ModeFile)
+	final, cmd := model.Update(msg)
+	if cmd == nil {
+		t.Fatal("expected the model to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected a quit command")
+	}
+	if err := final.(tui.Model).FatalError(); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("FatalError() = %v", err)
+	}
+}
+
+func TestPrefillLastLines_ReportsProgress(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "siftail_prefill_*.log")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	total := prefillProgressEvery*2 + 5
+	for i := 0; i < total; i++ {
+		if _, err := tmpFile.WriteString("line\n"); err != nil {
+			t.Fatalf("Failed to write to temp file: %v", err)
+		}
+	}
+
+	ui := &recordingUI{}
+	ring := core.NewRing(total)
+	if err := prefillLastLines(tmpFile.Name(), "", total, 16*1024*1024, false, nil, ring, ui); err != nil {
+		t.Fatalf("prefillLastLines failed: %v", err)
+	}
+
+	var progress []tui.LoadProgressMsg
+	for _, msg := range ui.msgs {
+		if p, ok := msg.(tui.LoadProgressMsg); ok {
+			progress = append(progress, p)
+		}
+	}
+	if len(progress) != 2 {
+		t.Fatalf("Expected 2 progress messages, got %d", len(progress))
+	}
+	last := progress[len(progress)-1]
+	if last.Lines != prefillProgressEvery*2 || last.Bytes != int64(prefillProgressEvery*2*5) {
+		t.Errorf("Unexpected progress %+v", last)
+	}
+	if ring.Size() != total {
+		t.Errorf("Expected %d lines in ring, got %d", total, ring.Size())
+	}
+}
+
+func TestPrefillLastLines_StripsInputColors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	content := "\x1b[31mERROR\x1b[0m boom\n\x1b[1;32m[INFO]\x1b[m ready\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	ring := core.NewRing(10)
+	detector := core.NewDefaultSeverityDetector(core.NewLevelMap())
+	if err := prefillLastLines(path, "", 10, 16*1024*1024, false, detector, ring, nil); err != nil {
+		t.Fatalf("prefillLastLines failed: %v", err)
+	}
+	events := ring.Snapshot()
+	if len(events) != 2 || events[0].Line != "ERROR boom" || events[1].Line != "[INFO] ready" {
+		t.Fatalf("prefilled %+v, want the lines without color codes", events)
+	}
+	if events[1].Level != core.SevInfo {
+		t.Errorf("level = %v, want INFO detected on the clean line", events[1].Level)
+	}
+}
+
+func TestParseArgs_SinceDuration(t *testing.T) {
+	for arg, want := range map[string]time.Duration{"10m": 10 * time.Minute, "1h30m": 90 * time.Minute, "45s": 45 * time.Second} {
+		config, err := ParseArgs([]string{"--since", arg, "docker"})
+		if err != nil {
+			t.Errorf("--since %s: unexpected error: %v", arg, err)
+			continue
+		}
+		if config.Since != want {
+			t.Errorf("--since %s = %s, want %s", arg, config.Since, want)
+		}
+	}
+	if _, err := ParseArgs([]string{"--since", "10 minutes", "docker"}); err == nil {
+		t.Error("expected an error for a malformed duration")
+	}
+}
+
+func TestPrefillSince_KeepsLinesInWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	now := time.Now().UTC()
+	stamp := func(ago time.Duration) string { return now.Add(-ago).Format(time.RFC3339) }
+	content := strings.Join([]string{
+		stamp(time.Hour) + " old",
+		stamp(20*time.Minute) + " older than the window",
+		stamp(5*time.Minute) + " recent",
+		"  at continuation line",
+		stamp(time.Minute) + " newest",
+	}, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	ring := core.NewRing(100)
+	if err := prefillSince(path, "", now.Add(-10*time.Minute), 16*1024*1024, false, nil, ring, nil); err != nil {
+		t.Fatalf("prefillSince failed: %v", err)
+	}
+	var got []string
+	for _, e := range ring.Snapshot() {
+		got = append(got, e.Line)
+	}
+	if len(got) != 3 || !strings.HasSuffix(got[0], " recent") || got[1] != "  at continuation line" {
+		t.Errorf("prefilled %q, want the last three lines", got)
+	}
+	if first := ring.Snapshot()[0]; first.FileLine() != 3 {
+		t.Errorf("first prefilled line numbered %d, want 3", first.FileLine())
+	}
+}
+
+func TestReadLastGzipLines_SinceKeepsAtMostMaxLines(t *testing.T) {
+	now := time.Now().UTC()
+	stamp := func(ago time.Duration) string { return now.Add(-ago).Format(time.RFC3339) }
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	fmt.Fprintf(zw, "%s old\n", stamp(time.Hour))
+	for i := 5; i >= 1; i-- {
+		fmt.Fprintf(zw, "%s recent %d\n", stamp(time.Duration(i)*time.Minute), i)
+	}
+	zw.Close()
+	path := filepath.Join(t.TempDir(), "app.log.1.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	got, err := readLastGzipLines(path, now.Add(-10*time.Minute), 3, false, nil)
+	if err != nil {
+		t.Fatalf("readLastGzipLines failed: %v", err)
+	}
+	if len(got) != 3 || !strings.HasSuffix(got[0], " recent 3") || !strings.HasSuffix(got[2], " recent 1") {
+		t.Errorf("got %q, want the last three recent lines", got)
+	}
+	if got, _ := readLastGzipLines(path, now.Add(time.Minute), 3, false, nil); len(got) != 0 {
+		t.Errorf("got %q, want nothing after a future cutoff", got)
+	}
+}
+
+func waitForRingSize(t *testing.T, ring *core.Ring, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for ring.Size() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d lines in ring, have %d", want, ring.Size())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReaderSource_RestartBySeekability(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// File: restart clears the ring and re-reads from the start
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	ring := core.NewRing(100)
+	src, err := startFileReader(ctx, path, true, -1, 0, false, false, false, nil, ring, nil, false)
+	if err != nil {
+		t.Fatalf("startFileReader: %v", err)
+	}
+	waitForRingSize(t, ring, 3)
+	if !src.Seekable() {
+		t.Fatal("Expected file source to be seekable")
+	}
+	src.Restart()
+	waitForRingSize(t, ring, 3)
+	if oldest := ring.OldestSeq(); oldest != 4 {
+		t.Errorf("Expected re-read lines to get fresh sequences starting at 4, got %d", oldest)
+	}
+
+	// Stdin: restart is a no-op and keeps the in-ring history
+	ring = core.NewRing(100)
+	stdin := newReaderSource(ctx, ring, nil, false, func(bool) input.Reader {
+		return input.NewStdinReaderFromReader(strings.NewReader("x\ny\n"))
+	})
+	stdin.start(false)
+	waitForRingSize(t, ring, 2)
+	if stdin.Seekable() {
+		t.Fatal("Expected stdin source not to be seekable")
+	}
+	stdin.Restart()
+	time.Sleep(50 * time.Millisecond)
+	if ring.Size() != 2 || ring.OldestSeq() != 1 {
+		t.Errorf("Expected stdin history untouched, size=%d oldest=%d", ring.Size(), ring.OldestSeq())
+	}
+}
+
+// endlessReader emits its line until ctx ends, as a busy file would
+type endlessReader struct{ line string }
+
+func (r endlessReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
+	events := make(chan core.LogEvent, 100)
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case events <- core.LogEvent{Line: r.line}:
+			}
+		}
+	}()
+	return events, make(chan error)
+}
+
+func (endlessReader) Seekable() bool { return true }
+
+func TestReaderSource_RestartDropsOldEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// A slow UI keeps the pump busy notifying it when Restart comes
+	ui := make(chanUI)
+	go func() {
+		for range ui {
+			time.Sleep(100 * time.Microsecond)
+		}
+	}()
+	ring := core.NewRing(100000)
+	generation := 0
+	src := newReaderSource(ctx, ring, ui, false, func(bool) input.Reader {
+		generation++
+		return endlessReader{line: "gen-" + strconv.Itoa(generation)}
+	})
+	src.start(false)
+	for deadline := time.Now().Add(2 * time.Second); ring.Size() < 10; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the reader did not start")
+		}
+	}
+
+	src.Restart()
+	time.Sleep(20 * time.Millisecond)
+	for _, e := range ring.Snapshot() {
+		if e.Line != "gen-2" {
+			t.Fatalf("event %q of the old reader landed after the restart", e.Line)
+		}
+	}
+}
+
+func TestParseArgs_CommandMode(t *testing.T) {
+	config, err := ParseArgs([]string{"--cmd", "ssh", "web1", "tail", "-F", "/var/log/app.log"})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if config.Mode != tui.ModeCommand || !reflect.DeepEqual(config.Command, []string{"ssh", "web1", "tail", "-F", "/var/log/app.log"}) {
+		t.Errorf("Expected command mode with ssh argv, got mode %v argv %v", config.Mode, config.Command)
+	}
+
+	config, err = ParseArgs([]string{"--winevent", "System"})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if config.Mode != tui.ModeCommand || !reflect.DeepEqual(config.Command, []string{"wevtutil", "qe", "System", "/f:text"}) {
+		t.Errorf("Expected wevtutil command, got %v", config.Command)
+	}
+
+	for _, args := range [][]string{{"--cmd"}, {"--cmd", "--winevent", "System", "x"}, {"--winevent", "System", "extra"}} {
+		if _, err := ParseArgs(args); err == nil {
+			t.Errorf("Expected error for %v", args)
+		}
+	}
+}
+
+func TestParseArgs_OpsSetup(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // no persisted opsKeywords
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ParseArgs([]string{"--ops", path})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if config.Profile != tui.ProfileOps || !config.Stats || !config.ErrorNav {
+		t.Fatalf("Expected --ops to enable profile, stats and error nav, got %q %v %v", config.Profile, config.Stats, config.ErrorNav)
+	}
+
+	filters := core.NewFilters()
+	model, err := newModel(config, core.NewRing(10), filters, core.NewSearchState(), core.NewLevelMap())
+	if err != nil {
+		t.Fatalf("newModel: %v", err)
+	}
+	if len(filters.Highlights) == 0 {
+		t.Error("Expected --ops to add highlights")
+	}
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 200, Height: 10})
+	if view := updated.View(); !strings.Contains(view, "lines/s") {
+		t.Errorf("Expected stats in the status line, got %q", view)
+	}
+
+	// Each part can be overridden
+	config, err = ParseArgs([]string{"--ops", "--stats=false", "--profile=", path})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if config.Profile != "" || config.Stats || !config.ErrorNav {
+		t.Errorf("Expected only error nav left on, got %q %v %v", config.Profile, config.Stats, config.ErrorNav)
+	}
+}
+
+func TestLaunchArgs_ReopenSameInput(t *testing.T) {
+	cases := []struct {
+		config Config
+		want   []string
+	}{
+		{Config{Mode: tui.ModeFile, FilePath: "/var/log/app.log"}, []string{"/var/log/app.log"}},
+		{Config{Mode: tui.ModeFile, Latest: true, Glob: "*.log", FilePath: "/var/log"}, []string{"--latest", "--glob", "*.log", "/var/log"}},
+		{Config{Mode: tui.ModeDocker}, []string{"docker"}},
+		{Config{Mode: tui.ModeCommand, Command: []string{"ssh", "web1", "tail"}}, []string{"--cmd", "ssh", "web1", "tail"}},
+		{Config{Mode: tui.ModeCommand, WinEvent: "System", Command: []string{"wevtutil"}}, []string{"--winevent", "System"}},
+		{Config{Mode: tui.ModeStdin}, nil},
+	}
+	for _, c := range cases {
+		if got := launchArgs(c.config); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("launchArgs(%+v) = %q, want %q", c.config, got, c.want)
+		}
+	}
+}
+
+func TestNewModel_TimeFormat(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := ParseArgs([]string{"--time-format", "15:04", "--tz", "utc", path})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	config.Mode = tui.ModeStdin // no loading indicator in place of the lines
+
+	ring := core.NewRing(10)
+	ring.Append(core.LogEvent{Time: time.Date(2024, 5, 1, 12, 34, 56, 0, time.UTC), Line: "deployed"})
+	model, err := newModel(config, ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap())
+	if err != nil {
+		t.Fatalf("newModel: %v", err)
+	}
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	// The first render tick fills the viewport
+	for _, cmd := range model.Init()().(tea.BatchMsg) {
+		updated, _ = updated.Update(cmd())
+	}
+	view := xansi.Strip(updated.View())
+	if !strings.Contains(view, "12:34 deployed") || strings.Contains(view, "12:34:56") {
+		t.Errorf("Expected an HH:MM timestamp, got:\n%s", view)
+	}
+}
+
+func TestNewLevelMap_SettingsReachModel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	sm, err := persist.NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.Save(persist.Settings{Theme: "dark", LevelSlots: map[string]int{"AUDIT": 6}, LevelOverflow: "sideways"}); err != nil {
+		t.Fatal(err)
+	}
+
+	levels, note := newLevelMap()
+	if !strings.HasPrefix(note, "Ignoring levelOverflow: ") {
+		t.Errorf("note = %q", note)
+	}
+	config := Config{Mode: tui.ModeStdin, BufferSize: 10}
+	model, err := newModel(config, core.NewRing(10), core.NewFilters(), core.NewSearchState(), levels)
+	if err != nil {
+		t.Fatalf("newModel: %v", err)
+	}
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 10})
+	if view := xansi.Strip(updated.View()); !strings.Contains(view, "6: AUDIT") {
+		t.Errorf("Expected the configured slot in the level bar, got:\n%s", view)
+	}
+}
+
+// gatedUI blocks every Send until released, like Program.Send before Run
+type gatedUI struct {
+	release chan struct{}
+	msgs    chan tea.Msg
+}
+
+func newGatedUI() *gatedUI {
+	return &gatedUI{release: make(chan struct{}), msgs: make(chan tea.Msg, 100)}
+}
+
+func (g *gatedUI) Send(msg tea.Msg) {
+	<-g.release
+	g.msgs <- msg
+}
+
+// returnsPromptly fails the test if start blocks, e.g. on a UI that isn't
+// running yet
+func returnsPromptly(t *testing.T, start func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		start()
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("startup blocked on the UI before it runs")
+	}
+}
+
+func TestStartFileReader_DoesNotBlockBeforeRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gate := newGatedUI()
+	ui := newStartupUI(gate)
+	ring := core.NewRing(100)
+
+	returnsPromptly(t, func() {
+		if _, err := startFileReader(ctx, path, false, 2, 0, false, false, false, nil, ring, ui, false); err != nil {
+			t.Error(err)
+		}
+	})
+	if ring.Size() != 2 {
+		t.Errorf("prefilled %d lines, want 2", ring.Size())
+	}
+
+	// Once the program runs, the held back messages arrive
+	ui.start()
+	close(gate.release)
+	select {
+	case <-gate.msgs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("held back messages were not delivered")
+	}
+}
+
+func TestStartLatestAndMultiFileReaders_DoNotBlockBeforeRun(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.log")
+	b := filepath.Join(dir, "b.log")
+	stamp := time.Now().Format(time.RFC3339)
+	for _, path := range []string{a, b} {
+		if err := os.WriteFile(path, []byte(stamp+" hello\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ui := newStartupUI(newGatedUI())
+	returnsPromptly(t, func() {
+		startLatestFileReader(ctx, dir, "*.log", true, false, false, nil, core.NewRing(100), ui, false)
+	})
+	ring := core.NewRing(100)
+	returnsPromptly(t, func() {
+		startMultiFileReader(ctx, []string{a, b}, false, -1, time.Hour, false, false, false, nil, ring, ui, false)
+	})
+	if ring.Size() != 2 {
+		t.Errorf("prefilled %d lines since the cutoff, want 2", ring.Size())
 	}
 }
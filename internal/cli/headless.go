@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/dockerx"
+	"github.com/germanoeich/siftail/internal/input"
+	"github.com/germanoeich/siftail/internal/tui"
+)
+
+// shouldRunHeadless reports whether to dump plain lines instead of starting
+// the TUI: escape codes would pollute redirected output, so a non-terminal
+// stdout goes headless unless --force-tui is set.
+func shouldRunHeadless(stdoutIsTTY, forceTUI bool) bool {
+	return !stdoutIsTTY && !forceTUI
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+// runHeadless writes the input as plain, sanitized lines to w. Files are
+// dumped once (honoring -n and --since), one after another with a [name] prefix when there
+// are several; stdin and command output are copied until EOF;
+// docker streams until ctx is cancelled.
+func runHeadless(ctx context.Context, config Config, w io.Writer) error {
+	out := bufio.NewWriter(w)
+	defer out.Flush()
+
+	switch config.Mode {
+	case tui.ModeFile:
+		path := config.FilePath
+		if config.Latest {
+			newest, err := input.NewestMatchingFile(config.FilePath, config.Glob)
+			if err != nil {
+				return err
+			}
+			path = newest
+		}
+		cutoff := sinceCutoff(config)
+		if len(config.FilePaths) > 1 {
+			origins := fileOrigins(config.FilePaths)
+			for i, path := range config.FilePaths {
+				err := eachFileLine(path, config.NumLines, config.KeepCR, config.GroupStackTraces, fromCutoff(cutoff, func(line string) error {
+					_, err := fmt.Fprintf(out, "[%s] %s\n", origins[i], line)
+					return err
+				}))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return eachFileLine(path, config.NumLines, config.KeepCR, config.GroupStackTraces, fromCutoff(cutoff, func(line string) error {
+			_, err := fmt.Fprintln(out, line)
+			return err
+		}))
+
+	case tui.ModeStdin:
+		events, errs := newStdinReader(config.KeepCR, config.GroupStackTraces).Start(ctx)
+		return dumpEvents(ctx, events, errs, nil, out)
+
+	case tui.ModeCommand:
+		events, errs := newCommandReader(config, nil).Start(ctx)
+		return dumpEvents(ctx, events, errs, nil, out)
+
+	case tui.ModeDocker:
+		real, err := dockerx.NewRealClient()
+		if err != nil {
+			return fmt.Errorf("failed to start docker reader: %w", err)
+		}
+		reader := input.NewDockerReader(real, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+		reader.SetSince(config.Since)
+		events, errs := reader.Start(ctx)
+		return dumpEvents(ctx, events, errs, containerAliases(config), out)
+	}
+	return nil
+}
+
+// sinceCutoff returns the time --since reaches back to, or zero without it
+func sinceCutoff(config Config) time.Time {
+	if config.Since <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-config.Since)
+}
+
+// fromCutoff wraps fn to skip the lines before the first one stamped at or
+// after cutoff, as prefill does; a zero cutoff skips nothing
+func fromCutoff(cutoff time.Time, fn func(line string) error) func(line string) error {
+	if cutoff.IsZero() {
+		return fn
+	}
+	started := false
+	return func(line string) error {
+		if !started {
+			if t, ok := core.LineTime(line); !ok || t.Before(cutoff) {
+				return nil
+			}
+			started = true
+		}
+		return fn(line)
+	}
+}
+
+// eachFileLine calls fn with each sanitized line of the file, or of its last
+// numLines lines when numLines >= 0. With group, stack traces come as one
+// multi-line event, as the readers group them.
+func eachFileLine(path string, numLines int, keepCR, group bool, fn func(line string) error) error {
+	if !group {
+		return eachSanitizedLine(path, numLines, keepCR, fn)
+	}
+	add, flush := input.GroupTraces(fn)
+	if err := eachSanitizedLine(path, numLines, keepCR, add); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// eachSanitizedLine calls fn with each sanitized line, see eachFileLine
+func eachSanitizedLine(path string, numLines int, keepCR bool, fn func(line string) error) error {
+	if numLines >= 0 {
+		lines, err := readLastLines(path, time.Time{}, numLines, 16*1024*1024, keepCR, nil)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			if err := fn(core.SanitizeLine(line)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	f, err := input.OpenDecompressed(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		lineBytes, err := reader.ReadBytes('\n')
+		if len(lineBytes) > 0 {
+			if ferr := fn(core.SanitizeLine(input.TrimLineEnd(string(lineBytes), keepCR))); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// dumpLevels runs severity detection over the input and prints the resulting
+// level map. Files and stdin are read to the end; docker streams until ctx is
+// cancelled.
+func dumpLevels(ctx context.Context, config Config, w io.Writer) error {
+	levels, note := newLevelMap()
+	if note != "" {
+		fmt.Fprintln(os.Stderr, note)
+	}
+	detector := core.NewDefaultSeverityDetector(levels)
+
+	var err error
+	switch config.Mode {
+	case tui.ModeFile:
+		paths := []string{config.FilePath}
+		if config.Latest {
+			if paths[0], err = input.NewestMatchingFile(config.FilePath, config.Glob); err != nil {
+				return err
+			}
+		} else if len(config.FilePaths) > 1 {
+			paths = config.FilePaths
+		}
+		for _, path := range paths {
+			err = eachFileLine(path, config.NumLines, config.KeepCR, config.GroupStackTraces, fromCutoff(sinceCutoff(config), func(line string) error {
+				detector.Detect(line)
+				return nil
+			}))
+			if err != nil {
+				break
+			}
+		}
+
+	case tui.ModeStdin:
+		events, _ := newStdinReader(config.KeepCR, config.GroupStackTraces).Start(ctx)
+		for e := range events {
+			detector.Detect(e.Line)
+		}
+
+	case tui.ModeCommand:
+		// The command reader detects levels itself
+		events, _ := newCommandReader(config, detector).Start(ctx)
+		for range events {
+		}
+
+	case tui.ModeDocker:
+		real, derr := dockerx.NewRealClient()
+		if derr != nil {
+			return fmt.Errorf("failed to start docker reader: %w", derr)
+		}
+		// The docker reader detects levels itself
+		reader := input.NewDockerReader(real, detector)
+		reader.SetSince(config.Since)
+		events, _ := reader.Start(ctx)
+		for range events {
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, core.FormatLevelLegend(levels.Slots(), config.DumpLevels == "json"))
+	return err
+}
+
+// dumpEvents writes events until the stream ends or ctx is cancelled,
+// prefixing container lines with their alias when one is set. Reader errors
+// go to stderr so they don't mix with the dumped lines.
+func dumpEvents(ctx context.Context, events <-chan core.LogEvent, errs <-chan error, aliases map[string]string, out *bufio.Writer) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			line := e.Line
+			if e.Container != "" {
+				name := e.Container
+				if alias, ok := aliases[name]; ok {
+					name = alias
+				}
+				line = "[" + name + "] " + line
+			}
+			if _, err := fmt.Fprintln(out, line); err != nil {
+				return err
+			}
+			// Keep piped consumers fed when the input goes quiet
+			if len(events) == 0 {
+				if err := out.Flush(); err != nil {
+					return err
+				}
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if input.IsFatal(err) {
+				return &InputFailedError{Err: err}
+			}
+			fmt.Fprintf(os.Stderr, "input error: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/tui"
+)
+
+func TestShouldRunHeadless(t *testing.T) {
+	testCases := []struct {
+		stdoutIsTTY bool
+		forceTUI    bool
+		expected    bool
+	}{
+		{stdoutIsTTY: true, forceTUI: false, expected: false},
+		{stdoutIsTTY: false, forceTUI: false, expected: true},
+		{stdoutIsTTY: false, forceTUI: true, expected: false},
+		{stdoutIsTTY: true, forceTUI: true, expected: false},
+	}
+
+	for i, tc := range testCases {
+		if got := shouldRunHeadless(tc.stdoutIsTTY, tc.forceTUI); got != tc.expected {
+			t.Errorf("Test case %d: shouldRunHeadless(%t, %t) = %t, want %t", i, tc.stdoutIsTTY, tc.forceTUI, got, tc.expected)
+		}
+	}
+}
+
+func TestIsTerminal_Pipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if isTerminal(w) {
+		t.Error("Expected a pipe not to be treated as a terminal")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer f.Close()
+	if isTerminal(f) {
+		t.Error("Expected a regular file not to be treated as a terminal")
+	}
+}
+
+func TestRunHeadless_FileDump(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("one\ntwo\x1b[2K\nthree"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: -1}
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got, want := out.String(), "one\ntwo\nthree\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	out.Reset()
+	config.NumLines = 1
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got, want := out.String(), "three\n"; got != want {
+		t.Errorf("Expected last line only %q, got %q", want, got)
+	}
+}
+
+func TestRunHeadless_LatestWithoutGlob(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.log"), []byte("newest\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	config, err := ParseArgs([]string{"--latest", dir})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got := out.String(); got != "newest\n" {
+		t.Errorf("Expected the newest file dumped, got %q", got)
+	}
+	out.Reset()
+	if err := dumpLevels(context.Background(), config, &out); err != nil {
+		t.Errorf("dumpLevels failed: %v", err)
+	}
+}
+
+func TestRunHeadless_Since(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	now := time.Now().UTC()
+	stamp := func(ago time.Duration) string { return now.Add(-ago).Format(time.RFC3339) }
+	content := stamp(time.Hour) + " old\n" +
+		stamp(5*time.Minute) + " recent\n" +
+		"  at continuation line\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: -1, Since: 10 * time.Minute}
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got, want := out.String(), stamp(5*time.Minute)+" recent\n  at continuation line\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRunHeadless_KeepCR(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(plain, []byte("one\r\ntwo\r\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("one\r\ntwo\r\n"))
+	zw.Close()
+	zipped := filepath.Join(dir, "app.log.1.gz")
+	if err := os.WriteFile(zipped, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	for _, path := range []string{plain, zipped} {
+		for _, numLines := range []int{-1, 1} {
+			for _, keepCR := range []bool{false, true} {
+				var out bytes.Buffer
+				config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: numLines, KeepCR: keepCR}
+				if err := runHeadless(context.Background(), config, &out); err != nil {
+					t.Fatalf("runHeadless failed: %v", err)
+				}
+				want := "one\ntwo\n"
+				if numLines == 1 {
+					want = "two\n"
+				}
+				if keepCR {
+					want = strings.ReplaceAll(want, "\n", "\r\n")
+				}
+				if got := out.String(); got != want {
+					t.Errorf("%s, -n %d, keepCR %v: expected %q, got %q", filepath.Base(path), numLines, keepCR, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestRunHeadless_GroupStackTraces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	content := "ERROR boom\n\tat a.B(B.java:1)\nCaused by: x\nINFO next\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	// Lines come out unchanged; a snapshot filters the trace as one event
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: -1, GroupStackTraces: true}
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got := out.String(); got != content {
+		t.Errorf("Expected %q, got %q", content, got)
+	}
+
+	out.Reset()
+	config.FilterOut = []string{"INFO"}
+	config.OutputFormat = "json"
+	config.BufferSize = 10
+	if err := runSnapshot(config, &out); err != nil {
+		t.Fatalf("runSnapshot failed: %v", err)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 1 || !strings.Contains(out.String(), `"line":"ERROR boom\n\tat a.B(B.java:1)\nCaused by: x"`) {
+		t.Errorf("expected the trace as one JSON event, got %q", out.String())
+	}
+}
+
+func TestRunHeadless_GzipFile(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("one\ntwo\nthree\n"))
+	zw.Close()
+	path := filepath.Join(t.TempDir(), "app.log.1.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: -1}
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got, want := out.String(), "one\ntwo\nthree\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	out.Reset()
+	config.NumLines = 2
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got, want := out.String(), "two\nthree\n"; got != want {
+		t.Errorf("Expected last lines %q, got %q", want, got)
+	}
+}
+
+func TestRunHeadless_MultipleFilesPrefixed(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")
+	if err := os.WriteFile(a, []byte("one\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.WriteFile(b, []byte("two\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeFile, FilePath: a, FilePaths: []string{a, b}, NumLines: -1}
+	if err := runHeadless(context.Background(), config, &out); err != nil {
+		t.Fatalf("runHeadless failed: %v", err)
+	}
+	if got, want := out.String(), "[a.log] one\n[b.log] two\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRunHeadless_FatalInputError(t *testing.T) {
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeCommand, Command: []string{"/nonexistent/siftail-command"}}
+	err := runHeadless(context.Background(), config, &out)
+	if ExitCode(err) != ExitInputFailed || !strings.Contains(err.Error(), "input failed") {
+		t.Errorf("expected an input failure, got %v", err)
+	}
+	if ExitCode(errors.New("usage")) != 1 {
+		t.Error("expected other errors to exit with 1")
+	}
+}
+
+func TestDumpLevels_ReflectsDiscoveredLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	lines := "[INFO] up\n[TRACE] tick\nlevel=notice msg=hi\n[AUDIT] login\n[ERROR] boom\n"
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var out bytes.Buffer
+	config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: -1, DumpLevels: "text"}
+	if err := dumpLevels(context.Background(), config, &out); err != nil {
+		t.Fatalf("dumpLevels failed: %v", err)
+	}
+	want := strings.Join([]string{
+		"SLOT  LEVEL   ENABLED",
+		"1     DEBUG   yes",
+		"2     INFO    yes",
+		"3     WARN    yes",
+		"4     ERROR   yes",
+		"5     TRACE   yes",
+		"6     NOTICE  yes",
+		"7     AUDIT   yes",
+		"9     OTHER   yes",
+	}, "\n") + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("Unexpected legend:\n%s\nwant:\n%s", got, want)
+	}
+
+	out.Reset()
+	config.DumpLevels = "json"
+	if err := dumpLevels(context.Background(), config, &out); err != nil {
+		t.Fatalf("dumpLevels failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"slot": 6,`+"\n"+`    "name": "NOTICE"`) {
+		t.Errorf("Expected NOTICE in slot 6 in JSON, got %s", out.String())
+	}
+}
+
+func TestParseArgs_DumpLevels(t *testing.T) {
+	for args, want := range map[string]string{"--dump-levels": "text", "--dump-levels=json": "json"} {
+		config, err := ParseArgs([]string{args, "docker"})
+		if err != nil {
+			t.Fatalf("%s: %v", args, err)
+		}
+		if config.DumpLevels != want {
+			t.Errorf("%s: expected %q, got %q", args, want, config.DumpLevels)
+		}
+	}
+	if _, err := ParseArgs([]string{"--dump-levels=yaml", "docker"}); err == nil {
+		t.Error("Expected error for unknown dump format")
+	}
+}
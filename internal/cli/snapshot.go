@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/input"
+)
+
+// ANSI codes around highlighted lines in snapshot output
+const (
+	snapshotHighlightOn  = "\x1b[1;33m"
+	snapshotHighlightOff = "\x1b[0m"
+)
+
+// runSnapshot reads the file once (the last --num-lines lines when set, from
+// the first line stamped within --since when set), keeping the last --buffer-size lines that pass the filter flags, and writes
+// them to w. Lines matching a --highlight are colored unless --no-color;
+// --output json writes one event object per line instead.
+func runSnapshot(config Config, w io.Writer) error {
+	filters := core.NewFilters()
+	if err := applyFilterFlags(config, filters); err != nil {
+		return err
+	}
+
+	path := config.FilePath
+	if config.Latest {
+		newest, err := input.NewestMatchingFile(config.FilePath, config.Glob)
+		if err != nil {
+			return err
+		}
+		path = newest
+	}
+	plan := core.VisiblePlan{Include: filters}
+	detector := core.NewDefaultSeverityDetector(core.NewLevelMap())
+	// kept is a ring of the matching lines; next is its oldest once full
+	var (
+		kept []core.LogEvent
+		next int
+		seq  uint64
+	)
+	err := eachFileLine(path, config.NumLines, config.KeepCR, config.GroupStackTraces, fromCutoff(sinceCutoff(config), func(line string) error {
+		seq++
+		e := core.LogEvent{Seq: seq, Source: core.SourceFile, Line: line}
+		if !core.ShouldShowEvent(e, plan) {
+			return nil
+		}
+		e.LevelStr, e.Level, _ = detector.Detect(line)
+		e.Time, _ = core.LineTime(line)
+		if len(kept) < config.BufferSize {
+			kept = append(kept, e)
+		} else {
+			kept[next] = e
+			next = (next + 1) % len(kept)
+		}
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	visible := slices.Concat(kept[next:], kept[:next])
+	if config.Chrono {
+		core.SortChronological(visible, nil)
+	}
+	out := bufio.NewWriter(w)
+	if config.OutputFormat == "json" {
+		if err := writeEventsJSON(out, visible); err != nil {
+			return err
+		}
+		return out.Flush()
+	}
+	for _, e := range visible {
+		line := e.Line
+		if !config.NoColor && filters.ShouldHighlight(line) {
+			line = snapshotHighlightOn + line + snapshotHighlightOff
+		}
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return out.Flush()
+}
+
+// writeEventsJSON writes each event as a JSON object on its own line.
+// Control characters in lines are escaped by the encoder; HTML escaping is
+// off so <, > and & stay readable.
+func writeEventsJSON(w io.Writer, events []core.LogEvent) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, e := range events {
+		if err := enc.Encode(core.NewEventJSON(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/tui"
+)
+
+func TestRunSnapshot_PrintsFilteredLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("GET /healthz 200\nGET /api 500 error\nPOST /api 200\nGET /api 503 error\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	config, err := ParseArgs([]string{"--snapshot", "--no-color", "--filter-in", "/api", "--filter-out", "POST", path})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("ValidateConfig: %v", err)
+	}
+	var out bytes.Buffer
+	if err := runSnapshot(config, &out); err != nil {
+		t.Fatalf("runSnapshot: %v", err)
+	}
+	if got, want := out.String(), "GET /api 500 error\nGET /api 503 error\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// -n limits what is read; highlights are colored unless --no-color
+	config.NumLines = 2
+	config.NoColor = false
+	config.Highlight = []string{"503"}
+	out.Reset()
+	if err := runSnapshot(config, &out); err != nil {
+		t.Fatalf("runSnapshot: %v", err)
+	}
+	if got, want := out.String(), snapshotHighlightOn+"GET /api 503 error"+snapshotHighlightOff+"\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	config.FilePath = filepath.Join(t.TempDir(), "missing.log")
+	if err := runSnapshot(config, &out); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+	if err := ValidateConfig(Config{BufferSize: 1000, Snapshot: true, Mode: tui.ModeStdin}); err == nil {
+		t.Error("Expected --snapshot to require a file")
+	}
+}
+
+func TestRunSnapshot_BuffersOnlyMatchingLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("hit 1\nhit 2\nmiss\nmiss\nhit 3\nmiss\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	config := Config{Mode: tui.ModeFile, FilePath: path, NumLines: -1, BufferSize: 2, Snapshot: true, NoColor: true, FilterIn: []string{"hit"}}
+	var out bytes.Buffer
+	if err := runSnapshot(config, &out); err != nil {
+		t.Fatalf("runSnapshot: %v", err)
+	}
+	if got, want := out.String(), "hit 2\nhit 3\n"; got != want {
+		t.Errorf("Expected the last two matches %q, got %q", want, got)
+	}
+}
+
+func TestRunSnapshot_Since(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	now := time.Now().UTC()
+	stamp := func(ago time.Duration) string { return now.Add(-ago).Format(time.RFC3339) }
+	content := stamp(time.Hour) + " old error\n" + stamp(5*time.Minute) + " recent error\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	config, err := ParseArgs([]string{"--snapshot", "--no-color", "--since", "10m", "--filter-in", "error", path})
+	if err != nil {
+		t.Fatalf("Failed to parse args: %v", err)
+	}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("ValidateConfig: %v", err)
+	}
+	var out bytes.Buffer
+	if err := runSnapshot(config, &out); err != nil {
+		t.Fatalf("runSnapshot: %v", err)
+	}
+	if got, want := out.String(), stamp(5*time.Minute)+" recent error\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRunSnapshot_JSONOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	content := "2024-05-01T12:00:00Z ERROR db \"primary\" down\n" +
+		"plain\tline with <tags> & \x01 control\n" +
+		"DEBUG skipped\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := ParseArgs([]string{"--snapshot", "--output", "json", "--filter-out", "DEBUG", "--highlight", "db", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("ValidateConfig: %v", err)
+	}
+	var colored bytes.Buffer
+	if err := runSnapshot(config, &colored); err != nil {
+		t.Fatalf("runSnapshot: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(colored.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %q", colored.String())
+	}
+	var events []map[string]any
+	for _, line := range lines {
+		var ev map[string]any
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		for _, key := range []string{"seq", "time", "source", "container", "level", "line"} {
+			if _, ok := ev[key]; !ok {
+				t.Errorf("%q lacks %q", line, key)
+			}
+		}
+		events = append(events, ev)
+	}
+	if events[0]["levelStr"] != "ERROR" || events[0]["level"] != float64(core.SevError) || events[0]["time"] != "2024-05-01T12:00:00Z" || events[0]["source"] != "file" {
+		t.Errorf("first event = %v", events[0])
+	}
+	if events[1]["time"] != nil || events[1]["seq"] != float64(2) {
+		t.Errorf("second event = %v", events[1])
+	}
+	if strings.Contains(lines[1], "\x01") || strings.Contains(colored.String(), "\x1b") {
+		t.Errorf("control characters should be escaped: %q", lines[1])
+	}
+
+	config.NoColor = true
+	var plain bytes.Buffer
+	if err := runSnapshot(config, &plain); err != nil {
+		t.Fatal(err)
+	}
+	if plain.String() != colored.String() {
+		t.Errorf("--no-color changed JSON output:\n%s\nvs\n%s", plain.String(), colored.String())
+	}
+
+	for _, args := range [][]string{{"--output", "json", path}, {"--snapshot", "--output", "yaml", path}} {
+		config, err := ParseArgs(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateConfig(config); err == nil {
+			t.Errorf("expected %v to be rejected", args)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// EventTime is the time an event's line was written: the timestamp the line
+// starts with (LineTime), or Docker's own timestamp. Other inputs only know
+// when a line arrived, so they report false.
+func EventTime(e LogEvent) (time.Time, bool) {
+	if t, ok := LineTime(e.Line); ok {
+		return t, true
+	}
+	if e.Source == SourceDocker && !e.Time.IsZero() {
+		return e.Time, true
+	}
+	return time.Time{}, false
+}
+
+// SortChronological stable-sorts events by timeOf (EventTime when nil).
+// A line without a time, like a stack trace continuation, takes the time of
+// the previous line from the same source so it stays with it; before any
+// timed line it falls back to its arrival time.
+func SortChronological(events []LogEvent, timeOf func(LogEvent) (time.Time, bool)) {
+	if timeOf == nil {
+		timeOf = EventTime
+	}
+	type source struct {
+		kind      SourceKind
+		container string
+		origin    string
+	}
+	last := make(map[source]time.Time)
+	keys := make(map[uint64]time.Time, len(events))
+	for _, e := range events {
+		src := source{e.Source, e.Container, e.Origin}
+		t, ok := timeOf(e)
+		if ok {
+			last[src] = t
+		} else if prev, seen := last[src]; seen {
+			t = prev
+		} else {
+			t = e.Time
+		}
+		keys[e.Seq] = t
+	}
+	slices.SortStableFunc(events, func(a, b LogEvent) int {
+		return keys[a.Seq].Compare(keys[b.Seq])
+	})
+}
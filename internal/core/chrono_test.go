@@ -0,0 +1,48 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComputeVisible_Chronological(t *testing.T) {
+	arrived := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	// b.log lags: its older lines arrive after a.log's newer ones
+	events := []LogEvent{
+		{Seq: 1, Origin: "a.log", Line: "2024-05-01T12:00:01Z a first"},
+		{Seq: 2, Origin: "a.log", Line: "2024-05-01T12:00:04Z a panic"},
+		{Seq: 3, Origin: "a.log", Line: "\tat main.go:12"},
+		{Seq: 4, Origin: "b.log", Line: "2024-05-01T12:00:02Z b first"},
+		{Seq: 5, Origin: "b.log", Line: "2024-05-01T12:00:03Z b second"},
+		{Seq: 6, Source: SourceDocker, Container: "api", Time: time.Date(2024, 5, 1, 12, 0, 2, 500, time.UTC), Line: "api ready"},
+		{Seq: 7, Origin: "b.log", Line: "2024-05-01T12:00:05Z b DEBUG"},
+		{Seq: 8, Origin: "c.log", Time: arrived, Line: "no timestamp at all"},
+	}
+	seqs := func(plan VisiblePlan) []uint64 {
+		var out []uint64
+		for _, e := range ComputeVisible(events, plan) {
+			out = append(out, e.Seq)
+		}
+		return out
+	}
+
+	if got := seqs(VisiblePlan{}); !reflect.DeepEqual(got, []uint64{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("arrival order: got %v", got)
+	}
+	// The trace line stays with its panic; the untimed line sorts by arrival
+	want := []uint64{1, 4, 6, 5, 2, 3, 7, 8}
+	if got := seqs(VisiblePlan{Chronological: true}); !reflect.DeepEqual(got, want) {
+		t.Errorf("chronological: got %v, want %v", got, want)
+	}
+
+	f := NewFilters()
+	m, _ := NewMatcher("DEBUG")
+	f.AddExclude(m)
+	if got := seqs(VisiblePlan{Include: f, Chronological: true}); !reflect.DeepEqual(got, []uint64{1, 4, 6, 5, 2, 3, 8}) {
+		t.Errorf("filtered chronological: got %v", got)
+	}
+	if events[0].Seq != 1 || events[6].Seq != 7 {
+		t.Error("sorting must not reorder the input slice")
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import "time"
+
+// EventJSON is the JSON shape of an event, shared by the J copy and
+// --output json
+type EventJSON struct {
+	Seq       uint64     `json:"seq"`
+	Time      *time.Time `json:"time"` // null when the line has no timestamp
+	Source    string     `json:"source"`
+	Container string     `json:"container"`
+	Origin    string     `json:"origin,omitempty"`
+	Stream    string     `json:"stream,omitempty"`
+	Level     uint8      `json:"level"`
+	LevelStr  string     `json:"levelStr,omitempty"`
+	Line      string     `json:"line"`
+}
+
+// sourceNames are the JSON names of the input kinds
+var sourceNames = map[SourceKind]string{
+	SourceStdin:   "stdin",
+	SourceFile:    "file",
+	SourceDocker:  "docker",
+	SourceCommand: "command",
+}
+
+// NewEventJSON returns the JSON shape of e
+func NewEventJSON(e LogEvent) EventJSON {
+	ev := EventJSON{
+		Seq:       e.Seq,
+		Source:    sourceNames[e.Source],
+		Container: e.Container,
+		Origin:    e.Origin,
+		Stream:    e.Stream,
+		Level:     uint8(e.Level),
+		LevelStr:  e.LevelStr,
+		Line:      e.Line,
+	}
+	if !e.Time.IsZero() {
+		t := e.Time
+		ev.Time = &t
+	}
+	return ev
+}
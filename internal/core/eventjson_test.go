@@ -0,0 +1,37 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNewEventJSON(t *testing.T) {
+	e := LogEvent{
+		Seq:       7,
+		Time:      time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC),
+		Source:    SourceDocker,
+		Container: "api",
+		Stream:    "stderr",
+		Level:     SevError,
+		LevelStr:  "ERROR",
+		Line:      "boom",
+	}
+	data, err := json.Marshal(NewEventJSON(e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"seq":7,"time":"2025-03-04T10:20:30Z","source":"docker","container":"api","stream":"stderr","level":4,"levelStr":"ERROR","line":"boom"}`
+	if string(data) != want {
+		t.Errorf("got\n %s\nwant\n %s", data, want)
+	}
+
+	// No timestamp, level or container
+	data, err = json.Marshal(NewEventJSON(LogEvent{Seq: 1, Source: SourceFile, Line: "plain"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"seq":1,"time":null,"source":"file","container":"","level":0,"line":"plain"}`; string(data) != want {
+		t.Errorf("got\n %s\nwant\n %s", data, want)
+	}
+}
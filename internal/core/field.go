@@ -0,0 +1,196 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fieldPrefix marks a pattern as a structured field rule (field:status>=500)
+const fieldPrefix = "field:"
+
+// fieldOps are tried in order, so two-character operators win over their
+// one-character prefixes
+var fieldOps = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+// leadingNumberRe reads the number a value with a unit starts with (1234ms)
+var leadingNumberRe = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)`)
+
+// fieldRule compares a JSON or logfmt field of a line with a value, e.g.
+// "status >= 500" or "http.method == POST". A numeric rule value compares as
+// a number and never matches a non-numeric field value; other values compare
+// as strings (== and != ignore case).
+// A /regex/ in place of the field name takes the value from its first
+// capture group; a "?" after the name lets lines without the field match.
+type fieldRule struct {
+	path     string
+	re       *regexp.Regexp // value extractor for /regex/ rules
+	optional bool           // lines without the field match
+	op       string
+	value    string
+	num      float64
+	isNum    bool
+}
+
+// parseFieldRule parses "field op value", "field? op value" or
+// "/regex/ op value"
+func parseFieldRule(s string) (*fieldRule, error) {
+	start := 0
+	if strings.HasPrefix(s, "/") {
+		start = regexRuleEnd(s)
+		if start < 0 {
+			return nil, fmt.Errorf("field rule %q needs an operator after the /regex/", s)
+		}
+	}
+	at, op := -1, ""
+	for _, candidate := range fieldOps {
+		if i := strings.Index(s[start:], candidate); i >= 0 && (at < 0 || start+i < at) {
+			at, op = start+i, candidate
+		}
+	}
+	if at < 0 {
+		return nil, fmt.Errorf("field rule %q needs an operator (%s)", s, strings.Join(fieldOps, " "))
+	}
+	path := strings.TrimSpace(s[:at])
+	value := strings.Trim(strings.TrimSpace(s[at+len(op):]), `"'`)
+	optional := false
+	if rest, ok := strings.CutSuffix(path, "?"); ok {
+		path, optional = strings.TrimSpace(rest), true
+	}
+	if path == "" {
+		return nil, errors.New("field rule needs a field name")
+	}
+	if op == "=" {
+		op = "=="
+	}
+	r := &fieldRule{path: path, optional: optional, op: op, value: value}
+	if start > 0 {
+		re, err := regexp.Compile(path[1 : len(path)-1])
+		if err != nil {
+			return nil, err
+		}
+		r.re = re
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		r.num, r.isNum = n, true
+	}
+	return r, nil
+}
+
+// regexRuleEnd returns the index just past the "/" closing the regex a rule
+// starts with: the first one followed by an optional "?" and an operator.
+func regexRuleEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] != '/' || i == 1 {
+			continue
+		}
+		rest := strings.TrimLeft(s[i+1:], " ?")
+		for _, op := range fieldOps {
+			if strings.HasPrefix(rest, op) {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// match reports whether the line has the field and it satisfies the rule;
+// lines without it match only optional rules
+func (r *fieldRule) match(line string) bool {
+	v, ok := r.lookup(line)
+	if !ok {
+		return r.optional
+	}
+	if r.isNum {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return compare(n, r.num, r.op)
+		}
+		if lead := leadingNumberRe.FindString(v); lead != "" {
+			n, _ := strconv.ParseFloat(lead, 64)
+			return compare(n, r.num, r.op)
+		}
+		// A number can't be compared with text
+		return false
+	}
+	switch r.op {
+	case "==":
+		return strings.EqualFold(v, r.value)
+	case "!=":
+		return !strings.EqualFold(v, r.value)
+	}
+	return compare(v, r.value, r.op)
+}
+
+// lookup extracts the rule's field from a line
+func (r *fieldRule) lookup(line string) (string, bool) {
+	if r.re == nil {
+		return lineField(line, r.path)
+	}
+	m := r.re.FindStringSubmatch(line)
+	switch {
+	case m == nil:
+		return "", false
+	case len(m) > 1:
+		return m[1], true
+	}
+	return m[0], true
+}
+
+func compare[T float64 | string](a, b T, op string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	}
+	return false
+}
+
+// lineField looks up a field in a JSON object line (dotted paths reach into
+// nested objects) or in the key=value pairs of a logfmt line
+func lineField(line, path string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(trimmed), &obj); err != nil {
+			return "", false
+		}
+		var v any = obj
+		for _, key := range strings.Split(path, ".") {
+			m, ok := v.(map[string]any)
+			if !ok {
+				return "", false
+			}
+			if v, ok = m[key]; !ok {
+				return "", false
+			}
+		}
+		switch val := v.(type) {
+		case string:
+			return val, true
+		case float64:
+			return strconv.FormatFloat(val, 'f', -1, 64), true
+		case bool:
+			return strconv.FormatBool(val), true
+		}
+		return "", false
+	}
+
+	for _, part := range strings.Fields(line) {
+		if key, value, ok := strings.Cut(part, "="); ok && key == path {
+			return strings.Trim(value, `"'`), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,113 @@
+package core
+
+import "testing"
+
+func TestFieldRule_StatusHighlight(t *testing.T) {
+	f := NewFilters()
+	m, err := NewMatcher("field:status >= 500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.AddHighlight(m)
+
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{`{"status":503,"msg":"upstream down"}`, true},
+		{`{"status":500}`, true},
+		{`{"status":404,"msg":"status 500 expected"}`, false},
+		{`{"status":"502"}`, true},
+		{`{"msg":"no status"}`, false},
+		{`time=1 status=500 path=/api`, true},
+		{`time=1 status=200 path=/api`, false},
+		{`status: 500`, false},
+		{`{"status":503`, false},
+		{`{"status":"unknown"}`, false},
+		{`{"status":"ok"}`, false},
+	}
+	for _, tt := range tests {
+		if got := f.ShouldHighlight(tt.line); got != tt.want {
+			t.Errorf("ShouldHighlight(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFieldRule_Operators(t *testing.T) {
+	line := `{"http":{"method":"POST","status":201},"user":"Alice","cached":false,"ms":12.5}`
+	tests := []struct {
+		rule string
+		want bool
+	}{
+		{"http.method == post", true},
+		{"http.method = POST", true},
+		{"http.method != GET", true},
+		{"http.status < 300", true},
+		{"http.status > 201", false},
+		{"http.status <= 201", true},
+		{"ms > 12", true},
+		{"ms >= 12.6", false},
+		{`user == "alice"`, true},
+		{"user > Bob", false},
+		{"cached == false", true},
+		{"http.missing == x", false},
+		{"http == x", false},
+		{"user != 5", false},
+		{"user < 5", false},
+	}
+	for _, tt := range tests {
+		m, err := NewMatcher("field:" + tt.rule)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.rule, err)
+		}
+		if !m.IsField() || m.IsRegex() {
+			t.Fatalf("%q: not a field rule", tt.rule)
+		}
+		if got := m.Match(line); got != tt.want {
+			t.Errorf("%q: got %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"field:status", "field:>= 500"} {
+		if _, err := NewMatcher(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	if m, _ := NewMatcher("status >= 500"); m.IsField() {
+		t.Error("a pattern without field: should stay a substring match")
+	}
+}
+
+func TestFieldRule_NumbersWithUnitsAndRegex(t *testing.T) {
+	tests := []struct {
+		rule string
+		line string
+		want bool
+	}{
+		{"latency > 1000", "GET /api latency=1234ms", true},
+		{"latency > 1000", "GET /api latency=87ms", false},
+		{"latency > 1000", "GET /health", false},
+		{"latency? > 1000", "GET /health", true},
+		{"latency? > 1000", "GET /api latency=87ms", false},
+		{`/took (\d+)ms/ >= 500`, "request took 730ms", true},
+		{`/took (\d+)ms/ >= 500`, "request took 30ms", false},
+		{`/took (\d+)ms/ >= 500`, "request failed", false},
+		{`/took (\d+)ms/? >= 500`, "request failed", true},
+		{`/retries=\d+/ == retries=3`, "job retries=3", true},
+		{`/a=(\d+)/<=2`, "a=2", true},
+	}
+	for _, tt := range tests {
+		m, err := NewMatcher("field:" + tt.rule)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.rule, err)
+		}
+		if got := m.Match(tt.line); got != tt.want {
+			t.Errorf("%q.Match(%q) = %v, want %v", tt.rule, tt.line, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"field:/(/ > 1", "field:/latency/"} {
+		if _, err := NewMatcher(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -400,3 +401,179 @@ func TestFilter_Integration_WithVisibility(t *testing.T) {
 		}
 	}
 }
+
+func TestFilter_Generations(t *testing.T) {
+	f := NewFilters()
+	m, _ := NewMatcher("x")
+
+	fg, hg := f.FilterGeneration(), f.HighlightGeneration()
+	f.AddHighlight(m)
+	if f.FilterGeneration() != fg || f.HighlightGeneration() == hg {
+		t.Error("Expected highlight change to bump only the highlight generation")
+	}
+
+	fg, hg = f.FilterGeneration(), f.HighlightGeneration()
+	f.AddInclude(m)
+	f.AddExclude(m)
+	if f.FilterGeneration() != fg+2 || f.HighlightGeneration() != hg {
+		t.Error("Expected include/exclude changes to bump only the filter generation")
+	}
+
+	fg, hg = f.FilterGeneration(), f.HighlightGeneration()
+	f.ClearIncludes()
+	f.ClearExcludes()
+	f.ClearHighlights()
+	if f.FilterGeneration() != fg+2 || f.HighlightGeneration() != hg+1 {
+		t.Error("Expected clears to bump their generations")
+	}
+}
+
+func TestFilterIn_RequiredAndOptional(t *testing.T) {
+	mustInclude := func(f *Filters, pattern string) {
+		t.Helper()
+		m, err := NewIncludeMatcher(pattern)
+		if err != nil {
+			t.Fatalf("NewIncludeMatcher(%q): %v", pattern, err)
+		}
+		f.AddInclude(m)
+	}
+	lines := []string{
+		"error: timeout talking to db",
+		"error: disk full",
+		"warn: timeout retrying",
+		"info: ok",
+		"fatal: timeout",
+	}
+	cases := []struct {
+		name     string
+		patterns []string
+		want     []bool
+	}{
+		{"required only", []string{"+timeout"}, []bool{true, false, true, false, true}},
+		{"two required", []string{"+error", "+timeout"}, []bool{true, false, false, false, false}},
+		{"required and optional", []string{"+timeout", "error", "fatal"}, []bool{true, false, false, false, true}},
+		{"regex required", []string{"+/^(warn|fatal):/", "timeout"}, []bool{false, false, true, false, true}},
+		{"optional only stays OR", []string{"disk", "ok"}, []bool{false, true, false, true, false}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewFilters()
+			for _, p := range tc.patterns {
+				mustInclude(f, p)
+			}
+			for i, line := range lines {
+				if got := f.ShouldShowLine(line); got != tc.want[i] {
+					t.Errorf("%q: got %t, want %t", line, got, tc.want[i])
+				}
+			}
+		})
+	}
+
+	// Excludes still win over required includes
+	f := NewFilters()
+	mustInclude(f, "+timeout")
+	excl, _ := NewMatcher("fatal")
+	f.AddExclude(excl)
+	if f.ShouldShowLine("fatal: timeout") {
+		t.Error("exclude should hide a line matching a required include")
+	}
+
+	m, _ := NewIncludeMatcher(" +timeout")
+	if !m.Required() || m.Raw() != " +timeout" || !m.Match("TIMEOUT") {
+		t.Errorf("unexpected required matcher: required=%t raw=%q", m.Required(), m.Raw())
+	}
+	if m, _ := NewIncludeMatcher("timeout"); m.Required() {
+		t.Error("pattern without + should be optional")
+	}
+	if _, err := NewIncludeMatcher("+ "); err == nil {
+		t.Error("expected error for a bare +")
+	}
+}
+
+// shouldShowLinePerMatcher is ShouldShowLine as it was before the folded
+// line cache: every substring matcher lowercases the line itself.
+func shouldShowLinePerMatcher(f *Filters, line string) bool {
+	for _, exclude := range f.Exclude {
+		if exclude.Match(line) {
+			return false
+		}
+	}
+	hasOptional, matchedOptional := false, false
+	for _, include := range f.Include {
+		if include.required {
+			if !include.Match(line) {
+				return false
+			}
+			continue
+		}
+		hasOptional = true
+		matchedOptional = matchedOptional || include.Match(line)
+	}
+	return !hasOptional || matchedOptional
+}
+
+// manyFilters builds n optional includes and n excludes mixing substring,
+// case-sensitive and regex patterns
+func manyFilters(n int) *Filters {
+	f := NewFilters()
+	for i := 0; i < n; i++ {
+		var in, out string
+		switch i % 3 {
+		case 0:
+			in, out = fmt.Sprintf("Service-%d", i), fmt.Sprintf("healthz-%d", i)
+		case 1:
+			in, out = fmt.Sprintf("ID=%d c", i), fmt.Sprintf("Debug %d c", i)
+		default:
+			in, out = fmt.Sprintf("/user-%d\\b/", i), fmt.Sprintf("/(?-i)TRACE %d/", i)
+		}
+		include, _ := NewIncludeMatcher(in)
+		exclude, _ := NewMatcher(out)
+		f.AddInclude(include)
+		f.AddExclude(exclude)
+	}
+	required, _ := NewIncludeMatcher("+request")
+	f.AddInclude(required)
+	return f
+}
+
+func manyLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("2025-01-02 REQUEST service-%d id=%d user-%d Debug %d HEALTHZ-%d ÄÖÜ straße", i%40, i%37, i%29, i%31, i%43)
+	}
+	return lines
+}
+
+func TestShouldShowLine_FoldedMatchesPerMatcher(t *testing.T) {
+	f := manyFilters(30)
+	shown := 0
+	for _, line := range append(manyLines(500), "", "request", "REQUEST İSTANBUL user-2", "trace 2 request") {
+		got, want := f.ShouldShowLine(line), shouldShowLinePerMatcher(f, line)
+		if got != want {
+			t.Fatalf("ShouldShowLine(%q) = %v, per-matcher %v", line, got, want)
+		}
+		if got {
+			shown++
+		}
+	}
+	if shown == 0 {
+		t.Fatal("expected some lines shown; the comparison is vacuous")
+	}
+}
+
+func BenchmarkShouldShowLine_ManyFilters(b *testing.B) {
+	f := manyFilters(30)
+	lines := manyLines(1000)
+	b.Run("per-matcher", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			shouldShowLinePerMatcher(f, lines[i%len(lines)])
+		}
+	})
+	b.Run("folded", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			f.ShouldShowLine(lines[i%len(lines)])
+		}
+	})
+}
@@ -1,61 +1,253 @@
 package core
 
 import (
+	"errors"
 	"regexp"
+	"regexp/syntax"
 	"sort"
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 // TextMatcher provides fast case-insensitive substring matching with optional regex support.
 // Patterns wrapped in /.../  are treated as regular expressions.
+// A trailing "c" on a regex (/Foo/c) or a "cs:" prefix (cs:ERROR) matches case exactly.
+// A "field:" prefix (field:status>=500) compares a JSON or logfmt field instead.
+// A leading "!" (!debug, !/foo/) inverts the match; "\!" matches a literal "!"
+// and "\cs:" a literal "cs:".
 type TextMatcher struct {
-	raw     string         // original user input
-	isRegex bool           // true if pattern is wrapped in /.../
-	pattern *regexp.Regexp // compiled regex (nil for substring matching)
-	lowered string         // lowercased version for case-insensitive substring matching
+	raw           string         // original user input
+	isRegex       bool           // true if pattern is wrapped in /.../
+	pattern       *regexp.Regexp // compiled regex (nil for substring matching)
+	lowered       string         // substring to match; lowercased unless case-sensitive
+	caseSensitive bool
+	required      bool       // include filter that every shown line must match ("+" prefix)
+	field         *fieldRule // structured field rule (nil for text matching)
+	negate        bool       // "!" prefix: match lines the pattern does not match
+	prefix        string     // literal of an anchored regex like /^api/, tested without the regex
 }
 
 // NewMatcher creates a new TextMatcher from user input.
 // Patterns wrapped in /.../  are treated as regular expressions.
 // All other patterns are treated as case-insensitive substrings.
 func NewMatcher(s string) (TextMatcher, error) {
+	return NewMatcherWithCase(s, false)
+}
+
+// NewMatcherWithCase creates a TextMatcher like NewMatcher, matching case
+// exactly when caseSensitive is true.
+func NewMatcherWithCase(s string, caseSensitive bool) (TextMatcher, error) {
 	// Keep original input for Raw() method
 	original := s
 	s = strings.TrimSpace(s)
 
 	if s == "" {
-		return TextMatcher{raw: original}, nil
+		return TextMatcher{raw: original, caseSensitive: caseSensitive}, nil
+	}
+	if rest, ok := strings.CutPrefix(s, "!"); ok && strings.TrimSpace(rest) != "" {
+		m, err := NewMatcherWithCase(rest, caseSensitive)
+		if err != nil {
+			return TextMatcher{}, err
+		}
+		m.raw, m.negate = original, !m.negate
+		return m, nil
+	}
+	if rest, ok := strings.CutPrefix(s, `\!`); ok {
+		s = "!" + rest
+	}
+	if rest, ok := strings.CutPrefix(s, fieldPrefix); ok {
+		rule, err := parseFieldRule(rest)
+		if err != nil {
+			return TextMatcher{}, err
+		}
+		return TextMatcher{raw: original, field: rule}, nil
+	}
+	if rest, ok := strings.CutPrefix(s, `\cs:`); ok {
+		s = "cs:" + rest
+	} else if pattern, ok := splitCaseFlag(s); ok {
+		s, caseSensitive = pattern, true
 	}
 
 	// Check if this is a regex pattern (wrapped in /.../
 	if len(s) >= 3 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/") {
-		pattern := s[1 : len(s)-1]                     // extract pattern between slashes
-		regex, err := regexp.Compile("(?i)" + pattern) // case-insensitive regex
+		pattern := s[1 : len(s)-1] // extract pattern between slashes
+		if !caseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		regex, err := regexp.Compile(pattern)
 		if err != nil {
 			return TextMatcher{}, err
 		}
 		return TextMatcher{
-			raw:     original,
-			isRegex: true,
-			pattern: regex,
+			raw:           original,
+			isRegex:       true,
+			pattern:       regex,
+			caseSensitive: caseSensitive,
+			prefix:        anchoredLiteral(pattern),
 		}, nil
 	}
 
 	// Substring matching - store lowercased version of trimmed string
+	if !caseSensitive {
+		s = strings.ToLower(s)
+	}
 	return TextMatcher{
-		raw:     original,
-		isRegex: false,
-		lowered: strings.ToLower(s),
+		raw:           original,
+		isRegex:       false,
+		lowered:       s,
+		caseSensitive: caseSensitive,
 	}, nil
 }
 
+// anchoredLiteral returns the literal of a regex that is just a start anchor
+// and a literal (^api, ^user\.login), or "" for any other regex. Without
+// case sensitivity only ASCII literals qualify, see matchPrefix.
+func anchoredLiteral(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	re = re.Simplify()
+	if re.Op != syntax.OpConcat || len(re.Sub) != 2 || re.Sub[0].Op != syntax.OpBeginText || re.Sub[1].Op != syntax.OpLiteral {
+		return ""
+	}
+	lit := re.Sub[1]
+	for _, r := range lit.Rune {
+		if r == utf8.RuneError || (lit.Flags&syntax.FoldCase != 0 && r >= utf8.RuneSelf) {
+			return ""
+		}
+	}
+	if lit.Flags&syntax.FoldCase != 0 {
+		return strings.ToLower(string(lit.Rune))
+	}
+	return string(lit.Rune)
+}
+
+// matchPrefix tests an anchored literal regex with strings.HasPrefix; sure is
+// false when only the regex can tell, i.e. a case-insensitive prefix meeting
+// non-ASCII text, whose folding the regex handles.
+func (m TextMatcher) matchPrefix(line string) (matched, sure bool) {
+	if m.caseSensitive {
+		return strings.HasPrefix(line, m.prefix), true
+	}
+	// Non-ASCII runes take at least two bytes, so a shorter line can't match
+	if len(line) < len(m.prefix) {
+		return false, true
+	}
+	head := line[:len(m.prefix)]
+	for i := 0; i < len(head); i++ {
+		if head[i] >= utf8.RuneSelf {
+			return false, false
+		}
+	}
+	return strings.EqualFold(head, m.prefix), true
+}
+
+// splitCaseFlag strips the case-sensitivity flag from a trimmed pattern:
+// "/Foo/c" becomes "/Foo/" and "cs:ERROR" becomes "ERROR". The regex may not
+// contain an unescaped "/", so a path like "/usr/lib/c" stays a substring.
+func splitCaseFlag(s string) (string, bool) {
+	if len(s) >= 4 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/c") && !hasUnescapedSlash(s[1:len(s)-2]) {
+		return s[:len(s)-1], true
+	}
+	if rest, ok := strings.CutPrefix(s, "cs:"); ok && strings.TrimSpace(rest) != "" {
+		return strings.TrimSpace(rest), true
+	}
+	return s, false
+}
+
+// hasUnescapedSlash reports whether pattern has a "/" not preceded by "\"
+func hasUnescapedSlash(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '/':
+			return true
+		}
+	}
+	return false
+}
+
+// TrimCaseFlag returns s without a /.../c or cs: case-sensitivity flag,
+// keeping a leading "!" negation.
+func TrimCaseFlag(s string) string {
+	if rest, ok := strings.CutPrefix(strings.TrimSpace(s), "!"); ok {
+		return "!" + TrimCaseFlag(rest)
+	}
+	if pattern, ok := splitCaseFlag(strings.TrimSpace(s)); ok {
+		return pattern
+	}
+	return s
+}
+
+// NewIncludeMatcher creates a matcher for an include filter. A leading "+"
+// (e.g. "+timeout") marks it as required: lines must match every required
+// include, in addition to at least one of the others if there are any. Raw
+// keeps the "+".
+func NewIncludeMatcher(s string) (TextMatcher, error) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "+") {
+		return NewMatcher(s)
+	}
+	if strings.TrimSpace(trimmed[1:]) == "" {
+		return TextMatcher{}, errors.New("nothing to match after +")
+	}
+	m, err := NewMatcher(trimmed[1:])
+	if err != nil {
+		return TextMatcher{}, err
+	}
+	m.raw = s
+	m.required = true
+	return m, nil
+}
+
+// NewSubstringMatcher creates a case-insensitive substring matcher from s,
+// never treating it as a regex even when it is wrapped in slashes.
+func NewSubstringMatcher(s string) TextMatcher {
+	return TextMatcher{raw: s, lowered: strings.ToLower(strings.TrimSpace(s))}
+}
+
 // Match returns true if the line matches this matcher's pattern.
 // Uses case-insensitive substring matching for non-regex patterns.
 func (m TextMatcher) Match(line string) bool {
+	return m.matchFolded(&foldedLine{line: line})
+}
+
+// foldedLine lowercases a line at most once for all the matchers tried on it
+type foldedLine struct {
+	line    string
+	lowered string
+	folded  bool
+}
+
+func (l *foldedLine) lower() string {
+	if !l.folded {
+		l.lowered, l.folded = strings.ToLower(l.line), true
+	}
+	return l.lowered
+}
+
+// matchFolded is Match sharing the line's lowercased copy; regexes and
+// case-sensitive patterns test the raw line.
+func (m TextMatcher) matchFolded(l *foldedLine) bool {
+	if m.negate {
+		inner := m
+		inner.negate = false
+		return !inner.matchFolded(l)
+	}
+	if m.field != nil {
+		return m.field.match(l.line)
+	}
 	if m.isRegex {
+		if m.prefix != "" {
+			if matched, sure := m.matchPrefix(l.line); sure {
+				return matched
+			}
+		}
 		// For regex patterns, let the compiled regex decide (empty regex matches everything)
-		return m.pattern.MatchString(line)
+		return m.pattern.MatchString(l.line)
 	}
 
 	// For substring patterns, empty or whitespace-only patterns don't match anything
@@ -63,8 +255,11 @@ func (m TextMatcher) Match(line string) bool {
 		return false
 	}
 
+	if m.caseSensitive {
+		return strings.Contains(l.line, m.lowered)
+	}
 	// Case-insensitive substring matching
-	return strings.Contains(strings.ToLower(line), m.lowered)
+	return strings.Contains(l.lower(), m.lowered)
 }
 
 // Raw returns the original user input used to create this matcher
@@ -72,19 +267,58 @@ func (m TextMatcher) Raw() string {
 	return m.raw
 }
 
+// Needle returns the substring a non-regex matcher looks for, without the
+// "!", "+" or "cs:" markers of Raw; lowercased unless case-sensitive
+func (m TextMatcher) Needle() string {
+	return m.lowered
+}
+
+// Regexp returns the compiled regex of a /.../ matcher, or nil
+func (m TextMatcher) Regexp() *regexp.Regexp {
+	return m.pattern
+}
+
+// CaseSensitive returns true if this matcher matches case exactly
+func (m TextMatcher) CaseSensitive() bool {
+	return m.caseSensitive
+}
+
+// Required returns true for include filters every shown line must match
+func (m TextMatcher) Required() bool {
+	return m.required
+}
+
 // IsRegex returns true if this matcher uses regular expression matching
 func (m TextMatcher) IsRegex() bool {
 	return m.isRegex
 }
 
+// Negated returns true for "!" matchers, which match lines the pattern
+// does not match
+func (m TextMatcher) Negated() bool {
+	return m.negate
+}
+
+// IsField returns true if this matcher is a structured field rule, which
+// matches whole lines rather than spans of text
+func (m TextMatcher) IsField() bool {
+	return m.field != nil
+}
+
 // Filters manages the three types of text filtering: include, exclude, and highlight.
-// Include filters: line is shown if it matches ANY include pattern (OR logic)
+// Include filters: line is shown if it matches ALL required includes and ANY
+// of the optional ones (OR logic; no optional includes means no constraint)
 // Exclude filters: line is hidden if it matches ANY exclude pattern (OR logic)
 // Highlights: visual marking without affecting line visibility
 type Filters struct {
-	Include    []TextMatcher // OR over includes - line shown if matches any
+	Include    []TextMatcher // required includes AND-ed, optional ones OR-ed
 	Exclude    []TextMatcher // OR over excludes - line hidden if matches any
 	Highlights []TextMatcher // visual highlighting only, no effect on visibility
+
+	// Generation counters bumped by the mutators below so renderers can tell
+	// when cached visibility or highlighting is stale.
+	filterGen    uint64
+	highlightGen uint64
 }
 
 // NewFilters creates an empty Filters struct
@@ -98,71 +332,93 @@ func NewFilters() *Filters {
 
 // ShouldShowLine determines if a line should be visible based on include/exclude filters.
 // Returns true if:
-// - No include filters are set, OR the line matches at least one include filter
+// - The line matches every required include filter, AND
+// - No optional include filters are set, OR the line matches at least one of them
 // AND
 // - The line does not match any exclude filter
 func (f *Filters) ShouldShowLine(line string) bool {
+	folded := &foldedLine{line: line}
+
 	// Check exclude filters first (if any exclude matches, hide the line)
 	for _, exclude := range f.Exclude {
-		if exclude.Match(line) {
+		if exclude.matchFolded(folded) {
 			return false
 		}
 	}
 
-	// If no include filters, show the line (as long as it didn't match excludes)
-	if len(f.Include) == 0 {
-		return true
-	}
-
-	// Check if line matches any include filter
+	hasOptional, matchedOptional := false, false
 	for _, include := range f.Include {
-		if include.Match(line) {
-			return true
+		if include.required {
+			if !include.matchFolded(folded) {
+				return false
+			}
+			continue
+		}
+		hasOptional = true
+		if !matchedOptional && include.matchFolded(folded) {
+			matchedOptional = true
 		}
 	}
 
-	// Has include filters but line didn't match any
-	return false
+	// Without optional includes, passing the required ones is enough
+	return !hasOptional || matchedOptional
 }
 
 // ShouldHighlight returns true if the line matches any highlight pattern
 func (f *Filters) ShouldHighlight(line string) bool {
+	folded := &foldedLine{line: line}
 	for _, highlight := range f.Highlights {
-		if highlight.Match(line) {
+		if highlight.matchFolded(folded) {
 			return true
 		}
 	}
 	return false
 }
 
+// FilterGeneration changes whenever include or exclude filters change
+func (f *Filters) FilterGeneration() uint64 {
+	return f.filterGen
+}
+
+// HighlightGeneration changes whenever highlight patterns change
+func (f *Filters) HighlightGeneration() uint64 {
+	return f.highlightGen
+}
+
 // AddInclude adds a new include filter
 func (f *Filters) AddInclude(matcher TextMatcher) {
 	f.Include = append(f.Include, matcher)
+	f.filterGen++
 }
 
 // AddExclude adds a new exclude filter
 func (f *Filters) AddExclude(matcher TextMatcher) {
 	f.Exclude = append(f.Exclude, matcher)
+	f.filterGen++
 }
 
 // AddHighlight adds a new highlight pattern
 func (f *Filters) AddHighlight(matcher TextMatcher) {
 	f.Highlights = append(f.Highlights, matcher)
+	f.highlightGen++
 }
 
 // ClearIncludes removes all include filters
 func (f *Filters) ClearIncludes() {
 	f.Include = f.Include[:0]
+	f.filterGen++
 }
 
 // ClearExcludes removes all exclude filters
 func (f *Filters) ClearExcludes() {
 	f.Exclude = f.Exclude[:0]
+	f.filterGen++
 }
 
 // ClearHighlights removes all highlight patterns
 func (f *Filters) ClearHighlights() {
 	f.Highlights = f.Highlights[:0]
+	f.highlightGen++
 }
 
 // FindIndex maintains a sorted list of sequence numbers for events that match
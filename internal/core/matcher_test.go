@@ -143,6 +143,191 @@ func TestMatcher_SubstringAndRegex(t *testing.T) {
 	}
 }
 
+func TestMatcher_CaseSensitive(t *testing.T) {
+	tests := []struct {
+		pattern string
+		line    string
+		want    bool
+	}{
+		{"Error", "Error: disk full", true},
+		{"Error", "error: disk full", false},
+		{"/ERR(OR)?/", "ERR 42", true},
+		{"/ERR(OR)?/", "err 42", false},
+	}
+	for _, tt := range tests {
+		m, err := NewMatcherWithCase(tt.pattern, true)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.pattern, err)
+		}
+		if !m.CaseSensitive() {
+			t.Errorf("expected %q to be case-sensitive", tt.pattern)
+		}
+		if got := m.Match(tt.line); got != tt.want {
+			t.Errorf("Match(%q) with case-sensitive %q = %v, want %v", tt.line, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestMatcher_CaseFlag(t *testing.T) {
+	tests := []struct {
+		pattern string
+		line    string
+		want    bool
+		cs      bool
+	}{
+		{"/Foo/c", "Foo bar", true, true},
+		{"/Foo/c", "foo bar", false, true},
+		{"/Foo/", "foo bar", true, false},
+		{"/Foo/", "FOO bar", true, false},
+		{"cs:ERROR", "ERROR: disk", true, true},
+		{"cs:ERROR", "error: disk", false, true},
+		{"cs: /Err(or)?/", "Err 1", true, true},
+		{"cs: /Err(or)?/", "err 1", false, true},
+		{"cs:", "cs: here", true, false}, // nothing after the prefix: plain substring
+		{"/usr/c", "/usr/c", true, true},
+		{"/usr/lib/c", "/USR/LIB/crt1.o", true, false}, // a path, not /re/c
+		{`/a\/b/c`, "a/b", true, true},
+		{`/a\/b/c`, "A/B", false, true},
+		{`\cs:Error`, "CS:ERROR 1", true, false}, // escaped: literal "cs:"
+		{`\cs:Error`, "Error 1", false, false},
+	}
+	for _, tt := range tests {
+		m, err := NewMatcher(tt.pattern)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.pattern, err)
+		}
+		if got := m.Match(tt.line); got != tt.want {
+			t.Errorf("%q.Match(%q) = %v, want %v", tt.pattern, tt.line, got, tt.want)
+		}
+		if m.CaseSensitive() != tt.cs {
+			t.Errorf("%q: CaseSensitive() = %v, want %v", tt.pattern, m.CaseSensitive(), tt.cs)
+		}
+		if m.Raw() != tt.pattern {
+			t.Errorf("Raw() = %q, want %q", m.Raw(), tt.pattern)
+		}
+	}
+
+	if got := TrimCaseFlag("/Foo/c"); got != "/Foo/" {
+		t.Errorf("TrimCaseFlag(/Foo/c) = %q", got)
+	}
+	if got := TrimCaseFlag("cs:ERROR"); got != "ERROR" {
+		t.Errorf("TrimCaseFlag(cs:ERROR) = %q", got)
+	}
+	if got := TrimCaseFlag("plain"); got != "plain" {
+		t.Errorf("TrimCaseFlag(plain) = %q", got)
+	}
+	if got := TrimCaseFlag("!cs:ERROR"); got != "!ERROR" {
+		t.Errorf("TrimCaseFlag(!cs:ERROR) = %q", got)
+	}
+	if got := TrimCaseFlag(`\!cs:x`); got != `\!cs:x` {
+		t.Errorf("TrimCaseFlag(\\!cs:x) = %q", got)
+	}
+	for _, keep := range []string{"/usr/lib/c", `\cs:x`} {
+		if got := TrimCaseFlag(keep); got != keep {
+			t.Errorf("TrimCaseFlag(%s) = %q", keep, got)
+		}
+	}
+}
+
+func TestMatcher_Negated(t *testing.T) {
+	tests := []struct {
+		pattern string
+		line    string
+		want    bool
+		negated bool
+	}{
+		{"!debug", "INFO ready", true, true},
+		{"!debug", "DEBUG cache warm", false, true},
+		{"! debug ", "debug cache warm", false, true},
+		{"!/err(or)?/", "all good", true, true},
+		{"!/err(or)?/", "ERR disk full", false, true},
+		{"!cs:ERROR", "error: disk", true, true},
+		{"!cs:ERROR", "ERROR: disk", false, true},
+		{`\!important`, "!important notice", true, false},
+		{`\!important`, "important notice", false, false},
+		{"!", "hey!", true, false}, // nothing after the "!": plain substring
+	}
+	for _, tt := range tests {
+		m, err := NewMatcher(tt.pattern)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.pattern, err)
+		}
+		if got := m.Match(tt.line); got != tt.want {
+			t.Errorf("%q.Match(%q) = %v, want %v", tt.pattern, tt.line, got, tt.want)
+		}
+		if m.Negated() != tt.negated {
+			t.Errorf("%q: Negated() = %v, want %v", tt.pattern, m.Negated(), tt.negated)
+		}
+		if m.Raw() != tt.pattern {
+			t.Errorf("Raw() = %q, want %q", m.Raw(), tt.pattern)
+		}
+	}
+	if _, err := NewMatcher("!/(/"); err == nil {
+		t.Error("expected an invalid negated regex to fail")
+	}
+
+	// "contains api but not health" as an AND of required includes
+	f := NewFilters()
+	for _, p := range []string{"+api", "+!health"} {
+		m, err := NewIncludeMatcher(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.AddInclude(m)
+	}
+	for line, want := range map[string]bool{
+		"GET /api/users":  true,
+		"GET /api/health": false,
+		"GET /metrics":    false,
+	} {
+		if got := f.ShouldShowLine(line); got != want {
+			t.Errorf("ShouldShowLine(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestMatcher_AnchoredPrefixFastPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		prefix  string // literal taken by the fast path; "" keeps the regex
+	}{
+		{"/^api/", "api"},
+		{`/^user\.login/`, "user.login"},
+		{"/^API/c", "API"},
+		{"/^Ünïcode/c", "Ünïcode"},
+		{"/^ünïcode/", ""}, // case folding of non-ASCII is left to the regex
+		{"/api/", ""},
+		{"/^api.*/", ""},
+		{"/^api$/", ""},
+		{"/(?m)^api/", ""},
+		{"/^(api|web)/", ""},
+	}
+	lines := []string{
+		"api GET /users", "API 500", "Api", "ap", "", " api", "web api",
+		"\u212Aelvin", // KELVIN SIGN, which folds to an ASCII k
+		"user.login ok", "userXlogin", "USER.LOGIN", "Ünïcode", "ünïcode", "\xffapi",
+	}
+	for _, tt := range tests {
+		m, err := NewMatcher(tt.pattern)
+		if err != nil {
+			t.Fatalf("NewMatcher(%q): %v", tt.pattern, err)
+		}
+		if m.prefix != tt.prefix {
+			t.Errorf("%q: prefix = %q, want %q", tt.pattern, m.prefix, tt.prefix)
+		}
+		for _, line := range lines {
+			if got, want := m.Match(line), m.pattern.MatchString(line); got != want {
+				t.Errorf("%q.Match(%q) = %v, regex says %v", tt.pattern, line, got, want)
+			}
+		}
+	}
+
+	m, _ := NewMatcher("/^kelvin/")
+	if !m.Match("\u212Aelvin") {
+		t.Error("expected the case-insensitive prefix to match a KELVIN SIGN")
+	}
+}
+
 func TestFilters_IncludeExclude(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -472,6 +657,27 @@ func TestFindIndexing_NextPrevAcrossStream(t *testing.T) {
 	}
 }
 
+func BenchmarkMatcher_AnchoredPrefix(b *testing.B) {
+	lines := manyLines(1000)
+	fast, err := NewMatcher("/^2025-01-02 request service-1/")
+	if err != nil {
+		b.Fatal(err)
+	}
+	slow := fast
+	slow.prefix = ""
+	for _, bm := range []struct {
+		name string
+		m    TextMatcher
+	}{{"regex", slow}, {"prefix", fast}} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bm.m.Match(lines[i%len(lines)])
+			}
+		})
+	}
+}
+
 func TestFindIndex_AddMatchSorted(t *testing.T) {
 	findIndex := NewFindIndex(10)
 	matcher, _ := NewMatcher("test")
@@ -13,6 +13,8 @@ type Ring struct {
 	head int    // next write position
 	size int    // current number of elements (0 <= size <= cap)
 	seq  uint64 // monotonically increasing sequence number
+
+	onAppend func(LogEvent) // see OnAppend
 }
 
 // NewRing creates a new ring buffer with the specified capacity
@@ -49,16 +51,32 @@ func (r *Ring) Append(e LogEvent) LogEvent {
 		r.size++
 	}
 
+	if r.onAppend != nil {
+		r.onAppend(e)
+	}
 	return e
 }
 
+// OnAppend sets a function called with each appended event once it has its
+// sequence number, in sequence order. It runs under the ring's lock and must
+// not call back into the ring.
+func (r *Ring) OnAppend(fn func(LogEvent)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onAppend = fn
+}
+
 // Snapshot returns a stable copy of all current events in chronological order
 // (oldest to newest). The returned slice is independent of the internal buffer
 // and safe to use without locking.
 func (r *Ring) Snapshot() []LogEvent {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.ordered()
+}
 
+// ordered returns the buffered events oldest first; the caller holds the lock
+func (r *Ring) ordered() []LogEvent {
 	if r.size == 0 {
 		return nil
 	}
@@ -121,6 +139,37 @@ func (r *Ring) GetBySeq(seq uint64) (LogEvent, bool) {
 	return LogEvent{}, false
 }
 
+// Clear drops all buffered events. The sequence counter is kept so sequence
+// numbers stay unique across a clear.
+func (r *Ring) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf = make([]LogEvent, r.cap)
+	r.head = 0
+	r.size = 0
+}
+
+// Resize changes the capacity, keeping the newest min(size, newCap) events
+// in order with their sequence numbers. A non-positive capacity is ignored.
+func (r *Ring) Resize(newCap int) {
+	if newCap <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.ordered()
+	if len(kept) > newCap {
+		kept = kept[len(kept)-newCap:]
+	}
+	r.buf = make([]LogEvent, newCap)
+	copy(r.buf, kept)
+	r.cap = newCap
+	r.size = len(kept)
+	r.head = r.size % newCap
+}
+
 // Capacity returns the maximum number of events the ring can hold
 func (r *Ring) Capacity() int {
 	r.mu.RLock()
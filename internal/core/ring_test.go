@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -405,3 +406,79 @@ func BenchmarkRing_GetBySeq(b *testing.B) {
 		_ = found
 	}
 }
+
+func TestRing_ClearKeepsSequence(t *testing.T) {
+	ring := NewRing(3)
+	for i := 0; i < 5; i++ {
+		ring.Append(LogEvent{Line: "line"})
+	}
+
+	ring.Clear()
+	if ring.Size() != 0 || ring.Snapshot() != nil || ring.OldestSeq() != 0 {
+		t.Fatalf("Expected empty ring after Clear, size=%d", ring.Size())
+	}
+	if _, ok := ring.GetBySeq(5); ok {
+		t.Error("Expected cleared events to be gone")
+	}
+
+	e := ring.Append(LogEvent{Line: "after"})
+	if e.Seq != 6 {
+		t.Errorf("Expected sequence to continue at 6, got %d", e.Seq)
+	}
+	if got, ok := ring.GetBySeq(6); !ok || got.Line != "after" {
+		t.Errorf("Expected to find appended event after clear, got %+v ok=%t", got, ok)
+	}
+}
+
+func TestRing_ResizeGrowAndShrink(t *testing.T) {
+	ring := NewRing(4)
+	for i := 1; i <= 6; i++ {
+		ring.Append(LogEvent{Line: fmt.Sprintf("line-%d", i)})
+	}
+
+	checkSeqs := func(from, to uint64) {
+		t.Helper()
+		snap := ring.Snapshot()
+		if len(snap) != int(to-from+1) || ring.Size() != len(snap) {
+			t.Fatalf("expected events %d..%d, snapshot has %d (size %d)", from, to, len(snap), ring.Size())
+		}
+		for i, e := range snap {
+			if want := from + uint64(i); e.Seq != want {
+				t.Errorf("snapshot[%d].Seq = %d, want %d", i, e.Seq, want)
+			}
+		}
+		for seq := from; seq <= to; seq++ {
+			if e, ok := ring.GetBySeq(seq); !ok || e.Line != fmt.Sprintf("line-%d", seq) {
+				t.Errorf("GetBySeq(%d) = %+v, %t", seq, e, ok)
+			}
+		}
+		if ring.OldestSeq() != from {
+			t.Errorf("OldestSeq = %d, want %d", ring.OldestSeq(), from)
+		}
+	}
+
+	// Growing a wrapped ring keeps everything and leaves room
+	ring.Resize(8)
+	if ring.Capacity() != 8 {
+		t.Fatalf("Capacity = %d, want 8", ring.Capacity())
+	}
+	checkSeqs(3, 6)
+	for i := 7; i <= 12; i++ {
+		ring.Append(LogEvent{Line: fmt.Sprintf("line-%d", i)})
+	}
+	checkSeqs(5, 12)
+
+	// Shrinking keeps the newest events and wraps at the new capacity
+	ring.Resize(3)
+	checkSeqs(10, 12)
+	if _, ok := ring.GetBySeq(9); ok {
+		t.Error("expected events dropped by the shrink to be gone")
+	}
+	ring.Append(LogEvent{Line: "line-13"})
+	checkSeqs(11, 13)
+
+	ring.Resize(0)
+	if ring.Capacity() != 3 {
+		t.Errorf("Resize(0) changed the capacity to %d", ring.Capacity())
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"sort"
 	"sync"
 )
 
@@ -12,13 +13,20 @@ type SearchState struct {
 	Matcher TextMatcher // current find pattern
 	HitSeqs []uint64    // sorted sequence numbers of matching events
 	Cursor  int         // current index into HitSeqs (-1 if none)
+
+	// Last current hit per pattern, so a find used again resumes there
+	lastCurrent map[string]uint64
 }
 
+// searchMemory caps how many patterns remember their last current hit
+const searchMemory = 16
+
 // NewSearchState creates a new SearchState
 func NewSearchState() *SearchState {
 	return &SearchState{
-		HitSeqs: make([]uint64, 0),
-		Cursor:  -1,
+		HitSeqs:     make([]uint64, 0),
+		Cursor:      -1,
+		lastCurrent: make(map[string]uint64),
 	}
 }
 
@@ -41,6 +49,7 @@ func (s *SearchState) SetMatcher(matcher TextMatcher) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.remember()
 	s.Matcher = matcher
 	s.HitSeqs = s.HitSeqs[:0] // clear existing hits
 	s.Cursor = -1
@@ -224,10 +233,58 @@ func (s *SearchState) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.remember()
 	s.HitSeqs = s.HitSeqs[:0]
 	s.Cursor = -1
 }
 
+// remember records the current hit of the current pattern; the caller
+// holds the lock. The pattern whose hit is oldest makes room when full.
+func (s *SearchState) remember() {
+	if s.Cursor < 0 || s.Cursor >= len(s.HitSeqs) {
+		return
+	}
+	if s.lastCurrent == nil {
+		s.lastCurrent = make(map[string]uint64)
+	}
+	raw := s.Matcher.Raw()
+	if _, ok := s.lastCurrent[raw]; !ok && len(s.lastCurrent) >= searchMemory {
+		oldest := ""
+		for r, seq := range s.lastCurrent {
+			if oldest == "" || seq < s.lastCurrent[oldest] {
+				oldest = r
+			}
+		}
+		delete(s.lastCurrent, oldest)
+	}
+	s.lastCurrent[raw] = s.HitSeqs[s.Cursor]
+}
+
+// RestoreCursor moves the cursor back to where the current pattern was last
+// left, or to the next hit after it, and returns that hit's sequence. It
+// returns 0 when the pattern has no remembered position or that position
+// has been evicted (older than every hit).
+func (s *SearchState) RestoreCursor() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw := s.Matcher.Raw()
+	seq, ok := s.lastCurrent[raw]
+	if !ok || len(s.HitSeqs) == 0 {
+		return 0
+	}
+	if seq < s.HitSeqs[0] {
+		delete(s.lastCurrent, raw)
+		return 0
+	}
+	i := sort.Search(len(s.HitSeqs), func(i int) bool { return s.HitSeqs[i] >= seq })
+	if i == len(s.HitSeqs) {
+		i-- // the remembered hit is gone; the nearest is the last one
+	}
+	s.Cursor = i
+	return s.HitSeqs[i]
+}
+
 // GetSnapshot returns a read-only snapshot of the current state
 func (s *SearchState) GetSnapshot() (active bool, hitSeqs []uint64, cursor int) {
 	s.mu.RLock()
@@ -357,3 +357,54 @@ func TestHighlight_DoesNotAffectVisibility(t *testing.T) {
 		t.Errorf("Position tracking should work: got (%d, %d)", current, total)
 	}
 }
+
+func TestSearch_RestoreCursorAfterClear(t *testing.T) {
+	s := NewSearchState()
+	timeout, _ := NewMatcher("timeout")
+	s.SetMatcher(timeout)
+	for _, seq := range []uint64{10, 20, 30, 40} {
+		s.AddHit(seq)
+	}
+	s.SetCurrentBySeq(30)
+
+	// Clearing, then searching something else, then coming back
+	s.Clear()
+	other, _ := NewMatcher("refused")
+	s.SetMatcher(other)
+	s.AddHit(25)
+	if got := s.RestoreCursor(); got != 0 {
+		t.Errorf("a new pattern should not restore, got #%d", got)
+	}
+	s.JumpToFirst()
+
+	again, _ := NewMatcher("timeout")
+	s.SetMatcher(again)
+	for _, seq := range []uint64{10, 20, 30, 40, 50} {
+		s.AddHit(seq)
+	}
+	if got := s.RestoreCursor(); got != 30 || s.Current() != 30 {
+		t.Errorf("RestoreCursor = #%d (current #%d), want #30", got, s.Current())
+	}
+
+	// The remembered hit itself is gone: resume at the next one
+	s.Clear()
+	s.SetMatcher(again)
+	for _, seq := range []uint64{20, 35, 50} {
+		s.AddHit(seq)
+	}
+	if got := s.RestoreCursor(); got != 35 {
+		t.Errorf("RestoreCursor without the exact hit = #%d, want #35", got)
+	}
+
+	// Evicted: every hit is newer than the remembered one
+	s.SetCurrentBySeq(35)
+	s.Clear()
+	s.SetMatcher(again)
+	s.AddHit(100)
+	if got := s.RestoreCursor(); got != 0 {
+		t.Errorf("an evicted position should not restore, got #%d", got)
+	}
+	if got := s.RestoreCursor(); got != 0 {
+		t.Errorf("the evicted position should be forgotten, got #%d", got)
+	}
+}
@@ -2,7 +2,10 @@ package core
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +18,7 @@ const (
 	SourceStdin SourceKind = iota
 	SourceFile
 	SourceDocker
+	SourceCommand
 )
 
 // Severity represents the severity level of a log entry
@@ -35,9 +39,84 @@ type LogEvent struct {
 	Time      time.Time
 	Source    SourceKind
 	Container string // docker only; empty otherwise
+	Origin    string // file name when tailing several files; empty otherwise
+	Stream    string // docker "stdout" or "stderr"; empty otherwise
 	Line      string // raw
 	LevelStr  string // original parsed token, e.g. "warn", "TRACE"
 	Level     Severity
+	LineNo    int // 1-based line number in the source file; 0 when unknown
+	// LineBase, when set, makes LineNo relative: the line is LineNo lines
+	// past the ones LineBase counts, see FileLine
+	LineBase *LineBase
+}
+
+// FileLine returns the 1-based line number of e in its source file, or 0 when
+// unknown. A relative LineNo counts the lines of its LineBase first.
+func (e LogEvent) FileLine() int {
+	if e.LineBase == nil {
+		return e.LineNo
+	}
+	n, ok := e.LineBase.Lines()
+	if !ok || n+e.LineNo <= 0 {
+		return 0
+	}
+	return n + e.LineNo
+}
+
+// LineBase is a line count that is only taken when first needed, so readers
+// starting at the end of a large file need not read all of it up front
+type LineBase struct {
+	once  sync.Once
+	count func() (int, error)
+	n     int
+	ok    bool
+}
+
+// NewLineBase creates a LineBase counted by count
+func NewLineBase(count func() (int, error)) *LineBase {
+	return &LineBase{count: count}
+}
+
+// Lines returns the count, taking it on the first call; ok is false when
+// counting failed
+func (b *LineBase) Lines() (int, bool) {
+	b.once.Do(func() {
+		n, err := b.count()
+		b.n, b.ok = n, err == nil
+	})
+	return b.n, b.ok
+}
+
+// OverflowStrategy decides what happens to new levels once slots 5-8 are taken
+type OverflowStrategy int
+
+const (
+	// OverflowCollapse groups every overflowing level into slot 9 under the
+	// overflow label (default)
+	OverflowCollapse OverflowStrategy = iota
+	// OverflowRotate also groups them into slot 9, but the slot shows the most
+	// recent distinct overflowing level
+	OverflowRotate
+	// OverflowWarn collapses like OverflowCollapse and records the levels that
+	// overflowed so the UI can warn that the slots are full
+	OverflowWarn
+)
+
+// DefaultOverflowLabel is the name of slot 9 unless renamed
+const DefaultOverflowLabel = "OTHER"
+
+// ParseOverflowStrategy parses "collapse", "rotate" or "warn"; empty means collapse.
+func ParseOverflowStrategy(s string) (OverflowStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "collapse":
+		return OverflowCollapse, nil
+	case "rotate":
+		return OverflowRotate, nil
+	case "warn":
+		return OverflowWarn, nil
+	default:
+		return OverflowCollapse, fmt.Errorf("unknown level overflow %q (want collapse, rotate or warn)", s)
+	}
 }
 
 // LevelMap manages the dynamic mapping between level names and numeric indices 1-9
@@ -46,18 +125,25 @@ type LevelMap struct {
 	IndexToName []string       // positions 1..9 (0 unused)
 	NameToIndex map[string]int // uppercased -> 1..9
 	Enabled     map[int]bool   // current visibility by index (default true)
+
+	overflow      OverflowStrategy
+	overflowLabel string
+	overflowed    []string   // distinct levels grouped into slot 9, in arrival order
+	firstSeq      [10]uint64 // sequence of the first event seen in each slot
+	hideNew       []string   // level names whose new slot starts disabled
 }
 
 // NewLevelMap creates a new LevelMap with default mappings
 func NewLevelMap() *LevelMap {
 	lm := &LevelMap{
-		IndexToName: make([]string, 10), // 0-9, but we only use 1-9
-		NameToIndex: make(map[string]int),
-		Enabled:     make(map[int]bool),
+		IndexToName:   make([]string, 10), // 0-9, but we only use 1-9
+		NameToIndex:   make(map[string]int),
+		Enabled:       make(map[int]bool),
+		overflowLabel: DefaultOverflowLabel,
 	}
 
 	// Set up default mappings: 1=DEBUG, 2=INFO, 3=WARN, 4=ERROR
-	defaults := []string{"", "DEBUG", "INFO", "WARN", "ERROR", "", "", "", "", "OTHER"}
+	defaults := []string{"", "DEBUG", "INFO", "WARN", "ERROR", "", "", "", "", DefaultOverflowLabel}
 	copy(lm.IndexToName, defaults)
 
 	for i := 1; i <= 9; i++ {
@@ -70,6 +156,84 @@ func NewLevelMap() *LevelMap {
 	return lm
 }
 
+// LoadMapping reserves slots 5-8 for the given level names (e.g. TRACE: 5),
+// so they keep their slot whatever order they appear in; call it before
+// detection starts. DEBUG, INFO, WARN and ERROR may only name their own slots
+// 1-4. An invalid mapping is rejected as a whole and changes nothing.
+func (lm *LevelMap) LoadMapping(mapping map[string]int) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	taken := make(map[int]string, len(mapping))
+	names := make(map[string]int, len(mapping))
+	for name, slot := range mapping {
+		normalized := strings.ToUpper(strings.Trim(name, "[]<>: "))
+		switch {
+		case normalized == "":
+			return fmt.Errorf("empty level name for slot %d", slot)
+		case slot < 1 || slot > 9:
+			return fmt.Errorf("level %s: slot %d is out of range 1-9", normalized, slot)
+		case slot == 9:
+			return fmt.Errorf("level %s: slot 9 collects overflowing levels", normalized)
+		case slot <= 4 && lm.IndexToName[slot] != normalized:
+			return fmt.Errorf("level %s: slot %d is reserved for %s", normalized, slot, lm.IndexToName[slot])
+		}
+		if i, ok := lm.NameToIndex[normalized]; ok && i <= 4 && i != slot {
+			return fmt.Errorf("level %s can't move from slot %d", normalized, i)
+		}
+		if other, ok := taken[slot]; ok {
+			return fmt.Errorf("levels %s and %s both map to slot %d", other, normalized, slot)
+		}
+		if _, ok := names[normalized]; ok {
+			return fmt.Errorf("level %s is mapped twice", normalized)
+		}
+		taken[slot] = normalized
+		names[normalized] = slot
+	}
+
+	for name, slot := range names {
+		if slot <= 4 {
+			continue
+		}
+		lm.IndexToName[slot] = name
+		lm.NameToIndex[name] = slot
+	}
+	return nil
+}
+
+// SetOverflow sets how levels beyond slot 8 are handled and renames slot 9;
+// an empty label keeps OTHER. Levels reported as "OTHER" still map to slot 9.
+func (lm *LevelMap) SetOverflow(strategy OverflowStrategy, label string) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	label = strings.ToUpper(strings.TrimSpace(label))
+	if label == "" {
+		label = DefaultOverflowLabel
+	}
+	if lm.IndexToName[9] == lm.overflowLabel {
+		lm.IndexToName[9] = label
+	}
+	lm.overflow = strategy
+	lm.overflowLabel = label
+	lm.NameToIndex[label] = 9
+}
+
+// Overflowed returns the distinct levels grouped into slot 9 because slots
+// 5-8 were taken, in the order they arrived.
+func (lm *LevelMap) Overflowed() []string {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return append([]string(nil), lm.overflowed...)
+}
+
+// Overflow returns how levels beyond slot 8 are handled
+func (lm *LevelMap) Overflow() OverflowStrategy {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return lm.overflow
+}
+
 // GetOrAssignIndex returns the index for a level name, assigning a new slot if needed
 func (lm *LevelMap) GetOrAssignIndex(levelStr string) int {
 	lm.mu.Lock()
@@ -87,17 +251,26 @@ func (lm *LevelMap) GetOrAssignIndex(levelStr string) int {
 		if lm.IndexToName[i] == "" {
 			lm.IndexToName[i] = normalized
 			lm.NameToIndex[normalized] = i
-			lm.Enabled[i] = true // default enabled
+			lm.Enabled[i] = !slices.Contains(lm.hideNew, normalized)
 			return i
 		}
 	}
 
-	// All slots full, map to OTHER (slot 9)
-	// Keep label at position 9 as OTHER always.
-	lm.IndexToName[9] = "OTHER"
-	lm.NameToIndex["OTHER"] = 9
+	// All slots full, map to slot 9. It keeps the overflow label, except
+	// when rotating, where it shows the latest overflowing level and its
+	// discovery line.
+	if lm.overflow == OverflowRotate {
+		if lm.IndexToName[9] != normalized {
+			lm.firstSeq[9] = 0
+		}
+		lm.IndexToName[9] = normalized
+	} else {
+		lm.IndexToName[9] = lm.overflowLabel
+	}
+	lm.NameToIndex[lm.overflowLabel] = 9
 	lm.Enabled[9] = true
 	lm.NameToIndex[normalized] = 9
+	lm.overflowed = append(lm.overflowed, normalized)
 
 	return 9
 }
@@ -111,6 +284,78 @@ func (lm *LevelMap) IsEnabled(level Severity) bool {
 	return lm.Enabled[index]
 }
 
+// IsEventEnabled reports whether an event's level bucket is enabled. Levels
+// learned dynamically (e.g. TRACE in slot 5) are looked up by name, since
+// their Severity is SevUnknown.
+func (lm *LevelMap) IsEventEnabled(e LogEvent) bool {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+
+	return lm.Enabled[lm.eventIndex(e)]
+}
+
+// eventIndex returns the slot of an event; the caller holds the lock
+func (lm *LevelMap) eventIndex(e LogEvent) int {
+	index := lm.severityToIndex(e.Level)
+	if e.Level == SevUnknown && e.LevelStr != "" {
+		if i, ok := lm.NameToIndex[strings.ToUpper(strings.Trim(e.LevelStr, "[]<>: "))]; ok {
+			index = i
+		}
+	}
+	return index
+}
+
+// NoteEvent records e as the discovery line of its slot when it is the
+// first event seen there. Events have to be noted in sequence order, as
+// they are appended (see Ring.OnAppend). A rotating slot 9 only notes the
+// level it currently shows.
+func (lm *LevelMap) NoteEvent(e LogEvent) {
+	if e.Seq == 0 {
+		return
+	}
+	lm.mu.RLock()
+	index := lm.eventIndex(e)
+	skip := lm.firstSeq[index] != 0 ||
+		(index == 9 && lm.overflow == OverflowRotate && lm.IndexToName[9] != strings.ToUpper(strings.Trim(e.LevelStr, "[]<>: ")))
+	lm.mu.RUnlock()
+	if skip {
+		return
+	}
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	if lm.firstSeq[index] == 0 {
+		lm.firstSeq[index] = e.Seq
+	}
+}
+
+// DiscoverySeq returns the sequence of the first event noted in a slot
+// (1-9), or 0 when none was
+func (lm *LevelMap) DiscoverySeq(index int) uint64 {
+	if index < 1 || index > 9 {
+		return 0
+	}
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return lm.firstSeq[index]
+}
+
+// SlotIndex returns the slot a level name maps to
+func (lm *LevelMap) SlotIndex(name string) (int, bool) {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	i, ok := lm.NameToIndex[strings.ToUpper(strings.Trim(name, "[]<>: "))]
+	return i, ok
+}
+
+// HideOnDiscovery makes the given level names start disabled when they are
+// first assigned a slot, so a view hiding e.g. TRACE keeps hiding it when
+// TRACE only shows up later. Focus and EnableAll drop the names again.
+func (lm *LevelMap) HideOnDiscovery(names []string) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	lm.hideNew = names
+}
+
 // Toggle enables/disables a severity level by index (1-9)
 func (lm *LevelMap) Toggle(index int) {
 	if index < 1 || index > 9 {
@@ -132,6 +377,7 @@ func (lm *LevelMap) Focus(index int) {
 	for i := 1; i <= 9; i++ {
 		lm.Enabled[i] = (i == index)
 	}
+	lm.hideNew = nil
 }
 
 // EnableAll sets all severity buckets 1..9 to enabled.
@@ -141,6 +387,7 @@ func (lm *LevelMap) EnableAll() {
 	for i := 1; i <= 9; i++ {
 		lm.Enabled[i] = true
 	}
+	lm.hideNew = nil
 }
 
 // GetSnapshot returns a read-only snapshot of the current state
@@ -159,6 +406,50 @@ func (lm *LevelMap) GetSnapshot() (indexToName []string, enabled map[int]bool) {
 	return
 }
 
+// LevelSlot describes one named bucket of a LevelMap
+type LevelSlot struct {
+	Slot    int    `json:"slot"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Slots returns the named buckets in slot order
+func (lm *LevelMap) Slots() []LevelSlot {
+	names, enabled := lm.GetSnapshot()
+	var out []LevelSlot
+	for i := 1; i < len(names); i++ {
+		if names[i] != "" {
+			out = append(out, LevelSlot{Slot: i, Name: names[i], Enabled: enabled[i]})
+		}
+	}
+	return out
+}
+
+// FormatLevelLegend renders slots as aligned text, or as JSON when asJSON is set
+func FormatLevelLegend(slots []LevelSlot, asJSON bool) string {
+	if asJSON {
+		if slots == nil {
+			slots = []LevelSlot{}
+		}
+		data, _ := json.MarshalIndent(slots, "", "  ")
+		return string(data)
+	}
+	width := len("LEVEL")
+	for _, s := range slots {
+		width = max(width, len(s.Name))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "SLOT  %-*s  ENABLED", width, "LEVEL")
+	for _, s := range slots {
+		enabled := "no"
+		if s.Enabled {
+			enabled = "yes"
+		}
+		fmt.Fprintf(&b, "\n%-4d  %-*s  %s", s.Slot, width, s.Name, enabled)
+	}
+	return b.String()
+}
+
 // severityToIndex maps the severity enum to an index (1-4 for defaults)
 func (lm *LevelMap) severityToIndex(level Severity) int {
 	switch level {
@@ -207,7 +498,7 @@ func (d *DefaultSeverityDetector) Detect(line string) (levelStr string, level Se
 	trimmed := strings.TrimSpace(line)
 
 	// Try JSON first (fast check)
-	if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
+	if IsJSONObjectLine(trimmed) {
 		if levelStr, level, ok := d.detectJSON(trimmed); ok {
 			return levelStr, level, true
 		}
@@ -218,6 +509,11 @@ func (d *DefaultSeverityDetector) Detect(line string) (levelStr string, level Se
 		return levelStr, level, true
 	}
 
+	// Try a syslog priority prefix (<13>)
+	if levelStr, level, ok := d.detectSyslogPriority(trimmed); ok {
+		return levelStr, level, true
+	}
+
 	// Try bracketed/common patterns
 	if levelStr, level, ok := d.detectBracketed(line); ok {
 		return levelStr, level, true
@@ -226,6 +522,13 @@ func (d *DefaultSeverityDetector) Detect(line string) (levelStr string, level Se
 	return "", SevUnknown, false
 }
 
+// IsJSONObjectLine reports whether a line is shaped like a JSON object
+// ({...}), the cheap check done before parsing it
+func IsJSONObjectLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}")
+}
+
 // detectJSON tries to parse the line as JSON and extract level
 func (d *DefaultSeverityDetector) detectJSON(line string) (string, Severity, bool) {
 	var obj map[string]interface{}
@@ -296,6 +599,29 @@ func (d *DefaultSeverityDetector) detectLogfmt(line string) (string, Severity, b
 	return "", SevUnknown, false
 }
 
+// syslogSeverityNames maps syslog severities 0-7 (emerg, alert, crit, err,
+// warning, notice, info, debug) to the default levels
+var syslogSeverityNames = [8]string{"ERROR", "ERROR", "ERROR", "ERROR", "WARN", "INFO", "INFO", "DEBUG"}
+
+// detectSyslogPriority reads a leading syslog priority <N>, where N is
+// facility*8+severity (0-191)
+func (d *DefaultSeverityDetector) detectSyslogPriority(line string) (string, Severity, bool) {
+	rest, ok := strings.CutPrefix(line, "<")
+	if !ok {
+		return "", SevUnknown, false
+	}
+	end := strings.IndexByte(rest, '>')
+	if end < 1 || end > 3 {
+		return "", SevUnknown, false
+	}
+	pri, err := strconv.Atoi(rest[:end])
+	if err != nil || pri < 0 || pri > 191 || rest[0] == '+' || rest[0] == '-' {
+		return "", SevUnknown, false
+	}
+	levelStr := syslogSeverityNames[pri%8]
+	return levelStr, d.stringToSeverity(levelStr), true
+}
+
 // detectBracketed uses regex to find bracketed or word-boundary level indicators
 func (d *DefaultSeverityDetector) detectBracketed(line string) (string, Severity, bool) {
 	// Try known patterns first
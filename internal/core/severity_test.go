@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -191,6 +193,33 @@ func TestSeverity_Detect_Logfmt(t *testing.T) {
 	}
 }
 
+func TestSeverity_Detect_SyslogPriority(t *testing.T) {
+	detector := NewDefaultSeverityDetector(NewLevelMap())
+	tests := []struct {
+		line    string
+		wantStr string
+		wantSev Severity
+		wantOk  bool
+	}{
+		{"<11> message", "ERROR", SevError, true}, // user.err
+		{"<14> message", "INFO", SevInfo, true},   // user.info
+		{"<12>Oct 11 22:14:15 host app: disk 91%", "WARN", SevWarn, true},
+		{"<191>1 2024-05-01T12:00:00Z host app - - - ok", "DEBUG", SevDebug, true},
+		{"<0> kernel panic", "ERROR", SevError, true},
+		{"<13> [ERROR] priority wins", "INFO", SevInfo, true},
+		{"<192> out of range", "", SevUnknown, false},
+		{"<+1> signed", "", SevUnknown, false},
+		{"<> empty", "", SevUnknown, false},
+		{"<ERROR> not a priority", "ERROR", SevError, true},
+	}
+	for _, tt := range tests {
+		str, sev, ok := detector.Detect(tt.line)
+		if str != tt.wantStr || sev != tt.wantSev || ok != tt.wantOk {
+			t.Errorf("Detect(%q) = %q, %v, %v; want %q, %v, %v", tt.line, str, sev, ok, tt.wantStr, tt.wantSev, tt.wantOk)
+		}
+	}
+}
+
 func TestSeverity_Detect_Bracketed(t *testing.T) {
 	lm := NewLevelMap()
 	detector := NewDefaultSeverityDetector(lm)
@@ -398,6 +427,71 @@ func TestSeverity_OverflowToOther(t *testing.T) {
 	}
 }
 
+// fillCustomSlots takes slots 5-8 so the next new level overflows
+func fillCustomSlots(lm *LevelMap) {
+	for _, level := range []string{"NOTICE", "ALERT", "CUSTOM1", "CUSTOM2"} {
+		lm.GetOrAssignIndex(level)
+	}
+}
+
+func TestLevelMap_OverflowStrategies(t *testing.T) {
+	cases := []struct {
+		strategy  OverflowStrategy
+		label     string
+		wantSlot9 string // after FOO then BAR overflow
+	}{
+		{OverflowCollapse, "", "OTHER"},
+		{OverflowCollapse, "misc", "MISC"},
+		{OverflowRotate, "", "BAR"},
+		{OverflowWarn, "", "OTHER"},
+	}
+	for _, c := range cases {
+		lm := NewLevelMap()
+		lm.SetOverflow(c.strategy, c.label)
+		fillCustomSlots(lm)
+
+		for _, level := range []string{"FOO", "BAR", "foo"} {
+			if index := lm.GetOrAssignIndex(level); index != 9 {
+				t.Errorf("strategy %d: %s mapped to %d, want 9", c.strategy, level, index)
+			}
+		}
+		indexToName, _ := lm.GetSnapshot()
+		if indexToName[9] != c.wantSlot9 {
+			t.Errorf("strategy %d label %q: slot 9 = %q, want %q", c.strategy, c.label, indexToName[9], c.wantSlot9)
+		}
+		if got := lm.Overflowed(); !reflect.DeepEqual(got, []string{"FOO", "BAR"}) {
+			t.Errorf("strategy %d: overflowed = %q", c.strategy, got)
+		}
+		// Numeric levels reported as OTHER stay in slot 9 whatever its name
+		if index := lm.GetOrAssignIndex("OTHER"); index != 9 {
+			t.Errorf("strategy %d: OTHER mapped to %d", c.strategy, index)
+		}
+	}
+}
+
+func TestLevelMap_RenamedLabelBeforeOverflow(t *testing.T) {
+	lm := NewLevelMap()
+	lm.SetOverflow(OverflowCollapse, "Misc")
+	indexToName, _ := lm.GetSnapshot()
+	if indexToName[9] != "MISC" {
+		t.Errorf("slot 9 = %q, want MISC", indexToName[9])
+	}
+	if index := lm.GetOrAssignIndex("misc"); index != 9 {
+		t.Errorf("MISC mapped to %d, want 9", index)
+	}
+}
+
+func TestParseOverflowStrategy(t *testing.T) {
+	for in, want := range map[string]OverflowStrategy{"": OverflowCollapse, "collapse": OverflowCollapse, "Rotate": OverflowRotate, "warn": OverflowWarn} {
+		if got, err := ParseOverflowStrategy(in); err != nil || got != want {
+			t.Errorf("ParseOverflowStrategy(%q) = %d, %v", in, got, err)
+		}
+	}
+	if _, err := ParseOverflowStrategy("drop"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
 func TestLevelMap_Toggle(t *testing.T) {
 	lm := NewLevelMap()
 
@@ -426,6 +520,25 @@ func TestLevelMap_Toggle(t *testing.T) {
 	}
 }
 
+func TestLevelMap_HideOnDiscovery(t *testing.T) {
+	lm := NewLevelMap()
+	lm.HideOnDiscovery([]string{"TRACE"})
+
+	trace := lm.GetOrAssignIndex("trace")
+	notice := lm.GetOrAssignIndex("NOTICE")
+	_, enabled := lm.GetSnapshot()
+	if enabled[trace] || !enabled[notice] {
+		t.Errorf("expected TRACE hidden and NOTICE shown on discovery, got %v", enabled)
+	}
+
+	lm = NewLevelMap()
+	lm.HideOnDiscovery([]string{"TRACE"})
+	lm.EnableAll()
+	if _, enabled := lm.GetSnapshot(); !enabled[lm.GetOrAssignIndex("TRACE")] {
+		t.Error("expected EnableAll to drop the hidden names")
+	}
+}
+
 func TestLevelMap_GetOrAssignIndex(t *testing.T) {
 	lm := NewLevelMap()
 
@@ -454,6 +567,42 @@ func TestLevelMap_GetOrAssignIndex(t *testing.T) {
 	}
 }
 
+func TestLevelMap_LoadMapping(t *testing.T) {
+	lm := NewLevelMap()
+	if err := lm.LoadMapping(map[string]int{"audit": 8, "[TRACE]": 5, "DEBUG": 1}); err != nil {
+		t.Fatalf("LoadMapping: %v", err)
+	}
+	// New levels skip the reserved slots; reserved ones keep theirs
+	for _, tt := range []struct {
+		level string
+		want  int
+	}{{"NOTICE", 6}, {"AUDIT", 8}, {"FATAL", 7}, {"trace", 5}, {"ALERT", 9}} {
+		if got := lm.GetOrAssignIndex(tt.level); got != tt.want {
+			t.Errorf("GetOrAssignIndex(%q) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+	if i, ok := lm.SlotIndex("audit"); !ok || i != 8 {
+		t.Errorf("SlotIndex(audit) = %d, %v", i, ok)
+	}
+
+	for name, mapping := range map[string]map[string]int{
+		"duplicate slot":  {"TRACE": 5, "AUDIT": 5},
+		"built-in slot":   {"TRACE": 1},
+		"moved built-in":  {"ERROR": 6},
+		"overflow slot":   {"TRACE": 9},
+		"out of range":    {"TRACE": 10},
+		"same name twice": {"trace": 5, "TRACE": 6},
+	} {
+		lm := NewLevelMap()
+		if err := lm.LoadMapping(mapping); err == nil {
+			t.Errorf("%s: expected an error for %v", name, mapping)
+		}
+		if got := lm.GetOrAssignIndex("TRACE"); got != 5 {
+			t.Errorf("%s: a rejected mapping changed the defaults (TRACE in %d)", name, got)
+		}
+	}
+}
+
 func TestSeverity_ThreadSafety(t *testing.T) {
 	lm := NewLevelMap()
 	detector := NewDefaultSeverityDetector(lm)
@@ -530,3 +679,75 @@ func TestSeverity_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestLevelMap_DiscoverySeq(t *testing.T) {
+	lm := NewLevelMap()
+	detector := NewDefaultSeverityDetector(lm)
+	ring := NewRing(10)
+	ring.OnAppend(lm.NoteEvent)
+	appendLines := func(lines ...string) {
+		for _, line := range lines {
+			e := LogEvent{Line: line}
+			e.LevelStr, e.Level, _ = detector.Detect(line)
+			ring.Append(e)
+		}
+	}
+	appendLines("[INFO] boot", "[NOTICE] disk at 80%", "[INFO] ready", "[NOTICE] disk at 90%")
+
+	slot, ok := lm.SlotIndex("notice")
+	if !ok || slot != 5 {
+		t.Fatalf("NOTICE slot = %d, %t; want 5", slot, ok)
+	}
+	if got := lm.DiscoverySeq(slot); got != 2 {
+		t.Errorf("NOTICE discovered at #%d, want #2", got)
+	}
+	if got := lm.DiscoverySeq(2); got != 1 {
+		t.Errorf("INFO discovered at #%d, want #1", got)
+	}
+	if got := lm.DiscoverySeq(6); got != 0 {
+		t.Errorf("empty slot discovered at #%d, want 0", got)
+	}
+
+	// A rotating slot 9 points at the first line of the level it shows now
+	lm.SetOverflow(OverflowRotate, "")
+	appendLines("[L6] a", "[L7] b", "[L8] c", "[L9] d", "[L9] e")
+	if got := lm.DiscoverySeq(9); got != 8 {
+		t.Errorf("slot 9 (L9) discovered at #%d, want #8", got)
+	}
+	appendLines("[L9] f", "[L10] g", "[L9] h")
+	if names, _ := lm.GetSnapshot(); names[9] != "L10" {
+		t.Fatalf("slot 9 shows %q, want L10", names[9])
+	}
+	if got := lm.DiscoverySeq(9); got != 11 {
+		t.Errorf("rotated slot 9 discovered at #%d, want #11 (the first L10)", got)
+	}
+}
+
+func TestLogEvent_FileLineCountsBaseOnce(t *testing.T) {
+	calls := 0
+	base := NewLineBase(func() (int, error) {
+		calls++
+		return 40, nil
+	})
+	events := []LogEvent{{LineNo: 2, LineBase: base}, {LineNo: -1, LineBase: base}}
+	if calls != 0 {
+		t.Fatal("base counted before a line number was needed")
+	}
+	if got := events[0].FileLine(); got != 42 {
+		t.Errorf("FileLine() = %d, want 42", got)
+	}
+	if got := events[1].FileLine(); got != 39 {
+		t.Errorf("FileLine() = %d, want 39", got)
+	}
+	if calls != 1 {
+		t.Errorf("base counted %d times, want once", calls)
+	}
+
+	failed := NewLineBase(func() (int, error) { return 0, errors.New("gone") })
+	if got := (LogEvent{LineNo: 3, LineBase: failed}).FileLine(); got != 0 {
+		t.Errorf("FileLine() with a failed count = %d, want 0", got)
+	}
+	if got := (LogEvent{LineNo: 7}).FileLine(); got != 7 {
+		t.Errorf("FileLine() without a base = %d, want 7", got)
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	templateUUIDRe  = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	templateHexRe   = regexp.MustCompile(`\b(?:0[xX][0-9a-fA-F]+|[0-9a-fA-F]{6,})\b`)
+	templateDigitRe = regexp.MustCompile(`\d+`)
+)
+
+// MessageTemplate normalizes a log message so lines that differ only in
+// their ids, counters or hashes share a template: UUIDs become <uuid>, hex
+// runs with a digit (0x1f, 6+ hex characters) become <hex> and the
+// remaining digit runs become <n>.
+func MessageTemplate(line string) string {
+	s := templateUUIDRe.ReplaceAllString(line, "<uuid>")
+	s = templateHexRe.ReplaceAllStringFunc(s, func(run string) string {
+		if strings.ContainsAny(run, "0123456789") {
+			return "<hex>"
+		}
+		return run // a word like "decade"
+	})
+	return templateDigitRe.ReplaceAllString(s, "<n>")
+}
+
+// TemplateCount is how many lines share a message template
+type TemplateCount struct {
+	Template string
+	Count    int
+	Example  string // the most recent line with this template
+}
+
+// TopTemplates counts the message templates of events, most frequent
+// first (ties by template), keeping at most n when n > 0.
+func TopTemplates(events []LogEvent, n int) []TemplateCount {
+	index := make(map[string]int)
+	var counts []TemplateCount
+	for _, e := range events {
+		t := MessageTemplate(e.Line)
+		if i, ok := index[t]; ok {
+			counts[i].Count++
+			counts[i].Example = e.Line
+			continue
+		}
+		index[t] = len(counts)
+		counts = append(counts, TemplateCount{Template: t, Count: 1, Example: e.Line})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Template < counts[j].Template
+	})
+	if n > 0 && len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
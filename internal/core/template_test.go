@@ -0,0 +1,51 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMessageTemplate(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"user 42 logged in", "user <n> logged in"},
+		{"request 7c9e6679-7425-40de-944b-e07fc1f90ae7 took 15ms", "request <uuid> took <n>ms"},
+		{"commit 3f2a9b1c pushed", "commit <hex> pushed"},
+		{"fault at 0x7fff5fbff8c8", "fault at <hex>"},
+		{"a decade of cafe logs", "a decade of cafe logs"},
+		{"no numbers here", "no numbers here"},
+	}
+	for _, tt := range tests {
+		if got := MessageTemplate(tt.in); got != tt.want {
+			t.Errorf("MessageTemplate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTopTemplates_CollapsesVaryingIDs(t *testing.T) {
+	var events []LogEvent
+	for i := 0; i < 5; i++ {
+		events = append(events, LogEvent{Line: fmt.Sprintf("GET /users/%d 200 in %dms", 1000+i, i*3)})
+	}
+	for i := 0; i < 3; i++ {
+		events = append(events, LogEvent{Line: fmt.Sprintf("cache miss key=%08x", 0xabc000+i)})
+	}
+	events = append(events, LogEvent{Line: "server started"})
+
+	top := TopTemplates(events, 0)
+	want := []TemplateCount{
+		{Template: "GET /users/<n> <n> in <n>ms", Count: 5, Example: "GET /users/1004 200 in 12ms"},
+		{Template: "cache miss key=<hex>", Count: 3, Example: "cache miss key=00abc002"},
+		{Template: "server started", Count: 1, Example: "server started"},
+	}
+	if len(top) != len(want) {
+		t.Fatalf("got %d templates, want %d: %+v", len(top), len(want), top)
+	}
+	for i := range want {
+		if top[i] != want[i] {
+			t.Errorf("top[%d] = %+v, want %+v", i, top[i], want[i])
+		}
+	}
+	if got := TopTemplates(events, 2); len(got) != 2 || got[1].Count != 3 {
+		t.Errorf("TopTemplates(n=2) = %+v", got)
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// leadingTimeRe matches a date-time at the start of a line, optionally
+	// in brackets: 2024-05-01T12:00:00Z, [2024-05-01 12:00:00,123 +0200]
+	leadingTimeRe = regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?: ?(?:Z|[+-]\d{2}:?\d{2}))?)`)
+	// jsonTimeRe matches the usual timestamp fields of a JSON line
+	jsonTimeRe = regexp.MustCompile(`"(?:@timestamp|timestamp|time|ts)"\s*:\s*"([^"]+)"`)
+)
+
+// lineTimeLayouts are tried in order on the normalized timestamp text
+var lineTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// LineTime extracts the timestamp a log line starts with, or the one in its
+// JSON time field. Timestamps without a zone are taken as local time.
+func LineTime(line string) (time.Time, bool) {
+	var s string
+	if m := leadingTimeRe.FindStringSubmatch(line); m != nil {
+		s = m[1]
+	} else if m := jsonTimeRe.FindStringSubmatch(line); m != nil {
+		s = m[1]
+	} else {
+		return time.Time{}, false
+	}
+
+	s = strings.Replace(s, " ", "T", 1)
+	s = strings.Replace(s, ",", ".", 1)
+	s = strings.Replace(s, " ", "", 1) // "12:00:00 +0200"
+	for _, layout := range lineTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
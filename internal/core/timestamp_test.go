@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLineTime(t *testing.T) {
+	utc := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	local := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		line string
+		want time.Time
+		ok   bool
+	}{
+		{"2024-05-01T12:00:00Z started", utc, true},
+		{"2024-05-01T14:00:00+02:00 started", utc, true},
+		{"[2024-05-01 12:00:00.250] INFO ready", local.Add(250 * time.Millisecond), true},
+		{"2024-05-01 12:00:00,500 +0000 WARN slow", utc.Add(500 * time.Millisecond), true},
+		{`{"level":"info","time":"2024-05-01T12:00:00Z","msg":"ok"}`, utc, true},
+		{`{"ts":"not a time"}`, time.Time{}, false},
+		{"  at com.example.Main(Main.java:10)", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := LineTime(tt.line)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("LineTime(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -5,12 +5,39 @@ type VisiblePlan struct {
 	Include       *Filters        // Include/exclude filters from Filters
 	LevelMap      *LevelMap       // Severity level mapping and enabled state
 	DockerVisible map[string]bool // Container visibility by name or id (empty means all visible)
+	Hits          *FilterHits     // If set, ComputeVisible fills in per-filter match counts
+	SinceSeq      uint64          // If set, events before this sequence are hidden (leading cut)
+	UntilSeq      uint64          // If set, events after this sequence are hidden (trailing cut)
+	BeforeSeq     uint64          // If set, events from this sequence on are hidden (pause)
+	Chronological bool            // If set, the result is ordered by line timestamp instead of sequence
+}
+
+// FilterHits counts how many lines each include/exclude filter matched,
+// indexed like Filters.Include and Filters.Exclude. Only lines that pass the
+// level and container checks are counted.
+type FilterHits struct {
+	Include []int
+	Exclude []int
 }
 
 // ComputeVisible returns a filtered slice of events that should be visible
 // based on the visibility plan. The returned slice contains references to
-// the original events (no copying of event data).
+// the original events (no copying of event data). When plan.Hits is set its
+// counts are reset and recounted over events. With plan.Chronological the
+// result is reordered by SortChronological.
 func ComputeVisible(events []LogEvent, plan VisiblePlan) []LogEvent {
+	result := computeVisible(events, plan)
+	if plan.Chronological {
+		SortChronological(result, nil)
+	}
+	return result
+}
+
+// computeVisible filters events in sequence order
+func computeVisible(events []LogEvent, plan VisiblePlan) []LogEvent {
+	if plan.Hits != nil {
+		return computeVisibleCounting(events, plan)
+	}
 	if len(events) == 0 {
 		return nil
 	}
@@ -26,10 +53,61 @@ func ComputeVisible(events []LogEvent, plan VisiblePlan) []LogEvent {
 	return result
 }
 
+// computeVisibleCounting is ComputeVisible with per-filter counting. Every
+// filter is tried on every line, since the usual short-circuit would hide
+// which filters overlap.
+func computeVisibleCounting(events []LogEvent, plan VisiblePlan) []LogEvent {
+	var include, exclude []TextMatcher
+	if plan.Include != nil {
+		include, exclude = plan.Include.Include, plan.Include.Exclude
+	}
+	*plan.Hits = FilterHits{Include: make([]int, len(include)), Exclude: make([]int, len(exclude))}
+
+	textPlan := plan
+	textPlan.Include = nil
+	var result []LogEvent
+	for _, event := range events {
+		if !ShouldShowEvent(event, textPlan) {
+			continue
+		}
+		folded := &foldedLine{line: event.Line}
+		excluded := false
+		for i, m := range exclude {
+			if m.matchFolded(folded) {
+				plan.Hits.Exclude[i]++
+				excluded = true
+			}
+		}
+		requiredOK, hasOptional, matchedOptional := true, false, false
+		for i, m := range include {
+			matched := m.matchFolded(folded)
+			if matched {
+				plan.Hits.Include[i]++
+			}
+			if m.Required() {
+				requiredOK = requiredOK && matched
+			} else {
+				hasOptional = true
+				matchedOptional = matchedOptional || matched
+			}
+		}
+		if requiredOK && (!hasOptional || matchedOptional) && !excluded {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
 // ShouldShowEvent determines if a single event should be visible based on the plan
 func ShouldShowEvent(event LogEvent, plan VisiblePlan) bool {
+	// 0. Check the sequence window
+	if (plan.SinceSeq != 0 && event.Seq < plan.SinceSeq) || (plan.UntilSeq != 0 && event.Seq > plan.UntilSeq) ||
+		(plan.BeforeSeq != 0 && event.Seq >= plan.BeforeSeq) {
+		return false
+	}
+
 	// 1. Check severity level enabled
-	if plan.LevelMap != nil && !plan.LevelMap.IsEnabled(event.Level) {
+	if plan.LevelMap != nil && !plan.LevelMap.IsEventEnabled(event) {
 		return false
 	}
 
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -394,3 +395,138 @@ func TestShouldShowEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeVisible_CountsFilterHits(t *testing.T) {
+	levels := NewLevelMap()
+	filters := NewFilters()
+	for _, p := range []string{"error", "/time(out)?/", "unused"} {
+		m, _ := NewMatcher(p)
+		filters.AddInclude(m)
+	}
+	healthz, _ := NewMatcher("healthz")
+	filters.AddExclude(healthz)
+
+	events := []LogEvent{
+		{Seq: 1, Line: "error: timeout talking to db", Level: SevError},
+		{Seq: 2, Line: "error on /healthz", Level: SevError},
+		{Seq: 3, Line: "request time 20ms", Level: SevInfo},
+		{Seq: 4, Line: "GET /healthz 200", Level: SevInfo},
+		{Seq: 5, Line: "debug error details", Level: SevDebug},
+	}
+	levels.Toggle(1) // DEBUG hidden: its lines don't count
+
+	hits := FilterHits{Include: []int{99}}
+	visible := ComputeVisible(events, VisiblePlan{Include: filters, LevelMap: levels, Hits: &hits})
+
+	if want := (FilterHits{Include: []int{2, 2, 0}, Exclude: []int{2}}); !reflect.DeepEqual(hits, want) {
+		t.Errorf("hits = %+v, want %+v", hits, want)
+	}
+	var seqs []uint64
+	for _, e := range visible {
+		seqs = append(seqs, e.Seq)
+	}
+	if want := []uint64{1, 3}; !reflect.DeepEqual(seqs, want) {
+		t.Errorf("visible = %v, want %v", seqs, want)
+	}
+
+	// Counting must not change what is visible
+	plain := ComputeVisible(events, VisiblePlan{Include: filters, LevelMap: levels})
+	if !reflect.DeepEqual(plain, visible) {
+		t.Errorf("counting changed the visible set: %v vs %v", visible, plain)
+	}
+}
+
+func TestComputeVisible_RequiredIncludesWithHits(t *testing.T) {
+	filters := NewFilters()
+	for _, p := range []string{"+timeout", "error"} {
+		m, _ := NewIncludeMatcher(p)
+		filters.AddInclude(m)
+	}
+	events := []LogEvent{
+		{Seq: 1, Line: "error: timeout"},
+		{Seq: 2, Line: "error: disk"},
+		{Seq: 3, Line: "warn: timeout"},
+	}
+	var hits FilterHits
+	counted := ComputeVisible(events, VisiblePlan{Include: filters, Hits: &hits})
+	plain := ComputeVisible(events, VisiblePlan{Include: filters})
+	if len(counted) != 1 || counted[0].Seq != 1 || !reflect.DeepEqual(counted, plain) {
+		t.Errorf("visible = %v (plain %v), want only seq 1", counted, plain)
+	}
+	if want := []int{2, 2}; !reflect.DeepEqual(hits.Include, want) {
+		t.Errorf("include hits = %v, want %v", hits.Include, want)
+	}
+}
+
+func TestComputeVisible_SequenceWindow(t *testing.T) {
+	var events []LogEvent
+	for seq := uint64(1); seq <= 10; seq++ {
+		events = append(events, LogEvent{Seq: seq, Line: "line", Level: SevInfo})
+	}
+	seqs := func(visible []LogEvent) []uint64 {
+		var out []uint64
+		for _, e := range visible {
+			out = append(out, e.Seq)
+		}
+		return out
+	}
+
+	// Trailing cut mid-buffer: later events stay hidden
+	plan := VisiblePlan{LevelMap: NewLevelMap(), UntilSeq: 6}
+	if got := seqs(ComputeVisible(events, plan)); !reflect.DeepEqual(got, []uint64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("trailing cut: got %v", got)
+	}
+	events = append(events, LogEvent{Seq: 11, Line: "arrived later", Level: SevInfo})
+	if got := seqs(ComputeVisible(events, plan)); len(got) != 6 {
+		t.Errorf("expected new events hidden past the cut, got %v", got)
+	}
+
+	// With a leading cut the window is bounded on both sides
+	plan.SinceSeq = 4
+	if got := seqs(ComputeVisible(events, plan)); !reflect.DeepEqual(got, []uint64{4, 5, 6}) {
+		t.Errorf("bounded window: got %v", got)
+	}
+
+	// BeforeSeq hides from its sequence on, also everything when it is 1
+	plan = VisiblePlan{LevelMap: NewLevelMap(), BeforeSeq: 3}
+	if got := seqs(ComputeVisible(events, plan)); !reflect.DeepEqual(got, []uint64{1, 2}) {
+		t.Errorf("before cut: got %v", got)
+	}
+	plan.BeforeSeq = 1
+	if got := seqs(ComputeVisible(events, plan)); len(got) != 0 {
+		t.Errorf("expected nothing before #1, got %v", got)
+	}
+}
+
+func TestShouldShowEvent_NumericThreshold(t *testing.T) {
+	events := []LogEvent{
+		{Seq: 1, Line: "GET /api latency=1234ms", Level: SevInfo},
+		{Seq: 2, Line: "GET /api latency=87ms", Level: SevInfo},
+		{Seq: 3, Line: "GET /health", Level: SevInfo},
+		{Seq: 4, Line: "POST /api latency=1000.5ms", Level: SevInfo},
+	}
+	seqs := func(pattern string) []uint64 {
+		t.Helper()
+		m, err := NewIncludeMatcher(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := NewFilters()
+		f.AddInclude(m)
+		var out []uint64
+		for _, e := range ComputeVisible(events, VisiblePlan{Include: f, LevelMap: NewLevelMap()}) {
+			out = append(out, e.Seq)
+		}
+		return out
+	}
+
+	if got := seqs("field:latency > 1000"); !reflect.DeepEqual(got, []uint64{1, 4}) {
+		t.Errorf("latency > 1000: got %v", got)
+	}
+	if got := seqs("field:latency? < 100"); !reflect.DeepEqual(got, []uint64{2, 3}) {
+		t.Errorf("optional latency < 100: got %v", got)
+	}
+	if got := seqs(`field:/latency=([\d.]+)/ <= 1000`); !reflect.DeepEqual(got, []uint64{2}) {
+		t.Errorf("regex capture <= 1000: got %v", got)
+	}
+}
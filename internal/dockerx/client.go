@@ -10,8 +10,23 @@ type Client interface {
 	ListContainers(ctx context.Context) ([]Container, error)
 	StreamLogs(ctx context.Context, id string, since string) (io.ReadCloser, error)
 	ContainerName(ctx context.Context, id string) (string, error) // convenience
+	// Events reports containers starting and dying until ctx ends. A
+	// failure is sent on the error channel, after which no events follow.
+	Events(ctx context.Context) (<-chan ContainerEvent, <-chan error)
 }
 
+// ContainerEvent is a container lifecycle change
+type ContainerEvent struct {
+	ID     string
+	Action string // ActionStart or ActionDie
+}
+
+// Container lifecycle actions reported by Events
+const (
+	ActionStart = "start"
+	ActionDie   = "die"
+)
+
 // Container represents a Docker container
 type Container struct {
 	ID    string
@@ -5,14 +5,21 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 )
 
 // FakeClient implements Client for testing
 type FakeClient struct {
+	mu         sync.Mutex
+	listCalls  int
 	containers []Container
-	logStreams map[string][]string // containerID -> log lines
-	errors     map[string]error    // method -> error to return
+	logStreams map[string][]string      // containerID -> log lines
+	errors     map[string]error         // method -> error to return
+	events     chan ContainerEvent      // emitted with EmitEvent
+	since      map[string]string        // containerID -> since of the last StreamLogs
+	stops      map[string]chan struct{} // containerID -> closed by StopContainer
+	follow     bool                     // streams stay open after their lines
 }
 
 // NewFakeClient creates a new fake Docker client for testing
@@ -21,11 +28,16 @@ func NewFakeClient() *FakeClient {
 		containers: []Container{},
 		logStreams: make(map[string][]string),
 		errors:     make(map[string]error),
+		events:     make(chan ContainerEvent, 16),
+		since:      make(map[string]string),
+		stops:      make(map[string]chan struct{}),
 	}
 }
 
 // AddContainer adds a container to the fake client
 func (f *FakeClient) AddContainer(id, name, state string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.containers = append(f.containers, Container{
 		ID:    id,
 		Name:  name,
@@ -35,16 +47,23 @@ func (f *FakeClient) AddContainer(id, name, state string) {
 
 // AddLogLines adds log lines for a container
 func (f *FakeClient) AddLogLines(containerID string, lines []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.logStreams[containerID] = append(f.logStreams[containerID], lines...)
 }
 
 // SetError sets an error to return for a specific method
 func (f *FakeClient) SetError(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.errors[method] = err
 }
 
 // ListContainers returns the fake containers
 func (f *FakeClient) ListContainers(ctx context.Context) ([]Container, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listCalls++
 	if err, exists := f.errors["ListContainers"]; exists {
 		return nil, err
 	}
@@ -54,13 +73,72 @@ func (f *FakeClient) ListContainers(ctx context.Context) ([]Container, error) {
 	return result, nil
 }
 
-// StreamLogs returns a fake log stream
-func (f *FakeClient) StreamLogs(ctx context.Context, id string, since string) (io.ReadCloser, error) {
-	if err, exists := f.errors["StreamLogs"]; exists {
-		return nil, err
+// ListCalls returns how many times ListContainers was called
+func (f *FakeClient) ListCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.listCalls
+}
+
+// StreamSince returns the since argument of the last StreamLogs call for a
+// container
+func (f *FakeClient) StreamSince(id string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.since[id]
+}
+
+// SetFollow keeps log streams open after their lines, as followed logs of a
+// running container are, until their context ends or StopContainer
+func (f *FakeClient) SetFollow(follow bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.follow = follow
+}
+
+// StopContainer marks a container exited and ends its log streams once their
+// lines are written, like a followed log of a container that stops
+func (f *FakeClient) StopContainer(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setState(id, "exited")
+	if stop, ok := f.stops[id]; ok {
+		close(stop)
+		delete(f.stops, id)
+	}
+}
+
+// StartContainer marks a stopped container running again
+func (f *FakeClient) StartContainer(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setState(id, "running")
+}
+
+func (f *FakeClient) setState(id, state string) {
+	for i := range f.containers {
+		if f.containers[i].ID == id {
+			f.containers[i].State = state
+		}
 	}
+}
 
+// StreamLogs returns a fake log stream of the container's lines, see SetFollow
+func (f *FakeClient) StreamLogs(ctx context.Context, id string, since string) (io.ReadCloser, error) {
+	f.mu.Lock()
+	err, failing := f.errors["StreamLogs"]
 	lines, exists := f.logStreams[id]
+	f.since[id] = since
+	stop, ok := f.stops[id]
+	if !ok {
+		stop = make(chan struct{})
+		f.stops[id] = stop
+	}
+	follow := f.follow
+	f.mu.Unlock()
+	if failing {
+		return nil, err
+	}
 	if !exists {
 		return nil, fmt.Errorf("container not found: %s", id)
 	}
@@ -75,8 +153,9 @@ func (f *FakeClient) StreamLogs(ctx context.Context, id string, since string) (i
 			case <-ctx.Done():
 				return
 			default:
-				// Add timestamp prefix to simulate Docker log format
-				timestamp := time.Now().UTC().Format(time.RFC3339Nano)
+				// Add timestamp prefix to simulate Docker log format, which
+				// keeps trailing zeros of the nanoseconds
+				timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000000000Z07:00")
 				formatted := fmt.Sprintf("%s %s\n", timestamp, line)
 				if _, err := pw.Write([]byte(formatted)); err != nil {
 					return
@@ -86,13 +165,40 @@ func (f *FakeClient) StreamLogs(ctx context.Context, id string, since string) (i
 				time.Sleep(10 * time.Millisecond)
 			}
 		}
+		if follow {
+			select {
+			case <-ctx.Done():
+			case <-stop:
+			}
+		}
 	}()
 
 	return pr, nil
 }
 
+// EmitEvent reports a container lifecycle change to the Events subscriber
+func (f *FakeClient) EmitEvent(ev ContainerEvent) {
+	f.events <- ev
+}
+
+// Events returns the events passed to EmitEvent, or the error set for
+// "Events"
+func (f *FakeClient) Events(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	f.mu.Lock()
+	err, failing := f.errors["Events"]
+	f.mu.Unlock()
+	if failing {
+		errs := make(chan error, 1)
+		errs <- err
+		return nil, errs
+	}
+	return f.events, nil
+}
+
 // ContainerName returns the container name by ID
 func (f *FakeClient) ContainerName(ctx context.Context, id string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	if err, exists := f.errors["ContainerName"]; exists {
 		return "", err
 	}
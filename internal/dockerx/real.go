@@ -7,8 +7,9 @@ import (
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/stdcopy"
 )
 
 // RealClient implements Client using the actual Docker SDK
@@ -58,12 +59,6 @@ func (c *RealClient) ListContainers(ctx context.Context) ([]Container, error) {
 
 // StreamLogs returns a log stream for the given container
 func (c *RealClient) StreamLogs(ctx context.Context, id string, since string) (io.ReadCloser, error) {
-	// Inspect to determine if the container has TTY enabled
-	inspect, err := c.client.ContainerInspect(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to inspect container: %w", err)
-	}
-
 	options := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -75,35 +70,41 @@ func (c *RealClient) StreamLogs(ctx context.Context, id string, since string) (i
 		options.Since = since
 	}
 
+	// Returned as is: multiplexed stdout/stderr frames unless the container
+	// has a TTY. The reader demultiplexes them.
 	logs, err := c.client.ContainerLogs(ctx, id, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container logs: %w", err)
 	}
+	return logs, nil
+}
 
-	// Create a pipe to demultiplex stdout/stderr
-	pr, pw := io.Pipe()
-
+// Events subscribes to the daemon's container start and die events
+func (c *RealClient) Events(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	messages, errs := c.client.Events(ctx, events.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("event", ActionStart),
+			filters.Arg("event", ActionDie),
+		),
+	})
+	out := make(chan ContainerEvent)
 	go func() {
-		defer pw.Close()
-		defer logs.Close()
-		if inspect.Config != nil && inspect.Config.Tty {
-			// With TTY enabled, the stream is not multiplexed; copy directly
-			if _, err := io.Copy(pw, logs); err != nil && err != context.Canceled {
-				pw.CloseWithError(fmt.Errorf("tty log copy error: %w", err))
-			}
-			return
-		}
-
-		// Without TTY, use stdcopy to demultiplex the Docker log stream
-		if _, err := stdcopy.StdCopy(pw, pw, logs); err != nil {
-			// Fallback copy when headers are unrecognized (non-multiplexed stream)
-			if _, copyErr := io.Copy(pw, logs); copyErr != nil && copyErr != context.Canceled {
-				pw.CloseWithError(fmt.Errorf("log demux error: %v; raw copy error: %v", err, copyErr))
+		defer close(out)
+		for {
+			select {
+			case msg := <-messages:
+				select {
+				case out <- ContainerEvent{ID: msg.Actor.ID, Action: string(msg.Action)}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
-
-	return pr, nil
+	return out, errs
 }
 
 // ContainerName returns the name of the container by ID
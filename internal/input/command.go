@@ -0,0 +1,168 @@
+package input
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// RecordParser groups command output lines into events, for tools that print
+// one record across several lines. Events it returns only need Line, and
+// optionally Time and the level fields; the reader fills in the rest.
+type RecordParser interface {
+	// Feed consumes one output line (without the newline) and returns the
+	// previous record once this line starts a new one.
+	Feed(line string) (core.LogEvent, bool)
+	// Flush returns the record still being built when output ends.
+	Flush() (core.LogEvent, bool)
+}
+
+// CommandReader runs a command and reads its standard output: one event per
+// line, or per record when a RecordParser is set. Useful for ssh, watch or
+// platform log tools.
+type CommandReader struct {
+	argv     []string
+	parser   RecordParser
+	seq      uint64
+	keepCR   bool
+	detector core.SeverityDetector
+}
+
+// NewCommandReader creates a reader for argv; parser may be nil for
+// line-per-event output.
+func NewCommandReader(argv []string, parser RecordParser) *CommandReader {
+	return &CommandReader{argv: argv, parser: parser}
+}
+
+// SetKeepCR keeps the \r of CRLF line endings instead of stripping it.
+// Record parsers always get lines without it.
+func (c *CommandReader) SetKeepCR(keep bool) {
+	c.keepCR = keep
+}
+
+// SetDetector detects the level of each event the parser left without one;
+// without a detector, such levels are left unknown.
+func (c *CommandReader) SetDetector(d core.SeverityDetector) {
+	c.detector = d
+}
+
+// Seekable implements the Reader interface; command output is a stream
+func (c *CommandReader) Seekable() bool {
+	return false
+}
+
+// Start implements the Reader interface. The command is killed when ctx is
+// cancelled; a failing exit is reported with the tail of its stderr.
+func (c *CommandReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
+	eventCh := make(chan core.LogEvent, 50)
+	errCh := make(chan error, 5)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		sendErr := func(err error) {
+			select {
+			case errCh <- err:
+			case <-ctx.Done():
+			}
+		}
+		send := func(e core.LogEvent) bool {
+			select {
+			case eventCh <- c.stamp(e):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if len(c.argv) == 0 {
+			sendErr(fatal(errors.New("no command given")))
+			return
+		}
+		cmd := exec.CommandContext(ctx, c.argv[0], c.argv[1:]...)
+		stderr := &tailBuffer{max: 4096}
+		cmd.Stderr = stderr
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			sendErr(err)
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			sendErr(fatal(fmt.Errorf("failed to start %s: %w", c.argv[0], err)))
+			return
+		}
+
+		bufReader := bufio.NewReader(stdout)
+		for {
+			lineBytes, rerr := bufReader.ReadBytes('\n')
+			if len(lineBytes) > 0 {
+				if c.parser == nil {
+					line := TrimLineEnd(string(lineBytes), c.keepCR)
+					if !send(core.LogEvent{Line: core.SanitizeLine(line)}) {
+						break
+					}
+				} else if e, ok := c.parser.Feed(TrimLineEnd(string(lineBytes), false)); ok && !send(e) {
+					break
+				}
+			}
+			if rerr != nil {
+				break
+			}
+		}
+		if c.parser != nil {
+			if e, ok := c.parser.Flush(); ok {
+				send(e)
+			}
+		}
+
+		// Drain so Wait doesn't block on a full pipe after an early exit
+		_, _ = io.Copy(io.Discard, stdout)
+		if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			sendErr(fmt.Errorf("%s: %w", c.argv[0], err))
+		}
+	}()
+
+	return eventCh, errCh
+}
+
+// stamp fills in the fields the parser leaves to the reader
+func (c *CommandReader) stamp(e core.LogEvent) core.LogEvent {
+	e.Seq = atomic.AddUint64(&c.seq, 1)
+	e.Source = core.SourceCommand
+	e.Line = core.SanitizeLine(e.Line)
+	if e.LevelStr == "" && c.detector != nil {
+		e.LevelStr, e.Level, _ = c.detector.Detect(e.Line)
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	return e
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+	max int
+	buf []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string { return string(t.buf) }
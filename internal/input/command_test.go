@@ -0,0 +1,179 @@
+package input
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// winEventOutput mimics `wevtutil qe System /f:text`, CRLF line endings included
+const winEventOutput = "Event[0]:\r\n" +
+	"  Log Name: System\r\n" +
+	"  Source: Service Control Manager\r\n" +
+	"  Date: 2025-03-04T10:20:30.1230000Z\r\n" +
+	"  Event ID: 7036\r\n" +
+	"  Level: Information\r\n" +
+	"  Computer: host\r\n" +
+	"  Description: \r\n" +
+	"The Windows Update service entered the running state.\r\n" +
+	"\r\n" +
+	"Event[1]:\r\n" +
+	"  Log Name: System\r\n" +
+	"  Source: disk\r\n" +
+	"  Date: 2025-03-04T10:21:00.0000000Z\r\n" +
+	"  Event ID: 7\r\n" +
+	"  Level: Error\r\n" +
+	"  Description: \r\n" +
+	"The device has a bad block.\r\n" +
+	"Check the disk for errors.\r\n" +
+	"Event[2]:\r\n" +
+	"  Source: Kernel-Power\r\n" +
+	"  Event ID: 41\r\n" +
+	"  Level: Critical\r\n" +
+	"  Description: The system has rebooted without cleanly shutting down first.\r\n"
+
+// TestCommandReaderHelper is not a real test: it is the fake command run by
+// the command reader tests, printing the output named in the environment.
+func TestCommandReaderHelper(t *testing.T) {
+	switch os.Getenv("SIFTAIL_FAKE_COMMAND") {
+	case "winevent":
+		fmt.Print(winEventOutput)
+	case "lines":
+		fmt.Print("first\nsecond\n")
+	case "levels":
+		fmt.Print("ERROR disk full\nall good\n")
+	case "fail":
+		fmt.Fprint(os.Stderr, "access denied")
+		os.Exit(3)
+	default:
+		return
+	}
+	os.Exit(0)
+}
+
+// runFakeCommand starts a command reader on the fake command and collects
+// everything it sends
+func runFakeCommand(t *testing.T, output string, parser RecordParser) ([]core.LogEvent, []error) {
+	t.Helper()
+	return runFakeCommandReader(t, output, NewCommandReader(fakeCommandArgv(), parser))
+}
+
+// fakeCommandArgv runs TestCommandReaderHelper as the command
+func fakeCommandArgv() []string {
+	return []string{os.Args[0], "-test.run=^TestCommandReaderHelper$"}
+}
+
+// runFakeCommandReader runs reader, set up on fakeCommandArgv, with the fake
+// command printing output
+func runFakeCommandReader(t *testing.T, output string, reader *CommandReader) ([]core.LogEvent, []error) {
+	t.Helper()
+	t.Setenv("SIFTAIL_FAKE_COMMAND", output)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events, errs := reader.Start(ctx)
+
+	var gotEvents []core.LogEvent
+	var gotErrs []error
+	for events != nil || errs != nil {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			gotEvents = append(gotEvents, e)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			gotErrs = append(gotErrs, err)
+		case <-ctx.Done():
+			t.Fatal("command reader did not finish")
+		}
+	}
+	return gotEvents, gotErrs
+}
+
+func TestCommandReader_WinEventBlocks(t *testing.T) {
+	events, errs := runFakeCommand(t, "winevent", NewWinEventParser())
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	want := []struct {
+		line     string
+		levelStr string
+		level    core.Severity
+	}{
+		{"Service Control Manager (7036): The Windows Update service entered the running state.", "INFO", core.SevInfo},
+		{"disk (7): The device has a bad block. Check the disk for errors.", "ERROR", core.SevError},
+		{"Kernel-Power (41): The system has rebooted without cleanly shutting down first.", "CRITICAL", core.SevError},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Line != w.line || e.LevelStr != w.levelStr || e.Level != w.level {
+			t.Errorf("event %d = %q %s/%d, want %q %s/%d", i, e.Line, e.LevelStr, e.Level, w.line, w.levelStr, w.level)
+		}
+		if e.Seq != uint64(i+1) || e.Source != core.SourceCommand {
+			t.Errorf("event %d: seq %d source %d", i, e.Seq, e.Source)
+		}
+	}
+	if got := events[0].Time; !got.Equal(time.Date(2025, 3, 4, 10, 20, 30, 123000000, time.UTC)) {
+		t.Errorf("event time = %v", got)
+	}
+	if events[2].Time.IsZero() {
+		t.Error("events without a date should be stamped with the read time")
+	}
+}
+
+func TestCommandReader_LinesAndFailure(t *testing.T) {
+	events, errs := runFakeCommand(t, "lines", nil)
+	if len(errs) > 0 || len(events) != 2 || events[0].Line != "first" || events[1].Line != "second" {
+		t.Errorf("got events %+v, errors %v", events, errs)
+	}
+
+	_, errs = runFakeCommand(t, "fail", nil)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "access denied") {
+		t.Errorf("expected exit error with stderr, got %v", errs)
+	} else if IsFatal(errs[0]) {
+		t.Error("a command exiting with an error should keep its output on screen")
+	}
+}
+
+func TestCommandReader_DetectsLevels(t *testing.T) {
+	reader := NewCommandReader(fakeCommandArgv(), nil)
+	reader.SetDetector(core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	events, errs := runFakeCommandReader(t, "levels", reader)
+	if len(errs) > 0 || len(events) != 2 {
+		t.Fatalf("got events %+v, errors %v", events, errs)
+	}
+	if events[0].LevelStr != "ERROR" || events[0].Level != core.SevError {
+		t.Errorf("first event level = %s/%d, want ERROR", events[0].LevelStr, events[0].Level)
+	}
+	if events[1].LevelStr != "" || events[1].Level != core.SevUnknown {
+		t.Errorf("second event level = %s/%d, want unknown", events[1].LevelStr, events[1].Level)
+	}
+}
+
+func TestCommandReader_StartFailureIsFatal(t *testing.T) {
+	reader := NewCommandReader([]string{"/nonexistent/siftail-command"}, nil)
+	events, errs := reader.Start(context.Background())
+	for range events {
+	}
+	var got []error
+	for err := range errs {
+		got = append(got, err)
+	}
+	if len(got) != 1 || !IsFatal(got[0]) {
+		t.Errorf("expected one fatal error, got %v", got)
+	}
+}
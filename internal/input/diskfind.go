@@ -0,0 +1,107 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// FileMatch is a line of a file on disk that matched a find
+type FileMatch struct {
+	Line   int   // 1-based line number
+	Offset int64 // byte offset of the start of the line
+	Text   string
+}
+
+// aroundWindow caps how far back ReadAround looks for preceding lines
+const aroundWindow = 64 * 1024
+
+// GrepFile scans the whole file for lines matching m, including lines no
+// longer in the ring. It stops after limit matches and reports whether it
+// did. Lines are sanitized as the readers do.
+func GrepFile(ctx context.Context, path string, m core.TextMatcher, limit int) ([]FileMatch, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	var (
+		matches []FileMatch
+		offset  int64
+		lineNo  int
+	)
+	reader := bufio.NewReader(f)
+	for {
+		lineBytes, rerr := reader.ReadBytes('\n')
+		if len(lineBytes) > 0 {
+			lineNo++
+			line := core.SanitizeLine(TrimLineEnd(string(lineBytes), false))
+			if m.Match(line) {
+				if len(matches) == limit {
+					return matches, true, nil
+				}
+				matches = append(matches, FileMatch{Line: lineNo, Offset: offset, Text: line})
+			}
+			offset += int64(len(lineBytes))
+			if lineNo%4096 == 0 && ctx.Err() != nil {
+				return matches, false, ctx.Err()
+			}
+		}
+		if rerr == io.EOF {
+			return matches, false, nil
+		}
+		if rerr != nil {
+			return matches, false, rerr
+		}
+	}
+}
+
+// ReadAround returns up to before lines preceding the line starting at
+// offset, that line, and up to after lines following it, along with the
+// index of the line itself in the result.
+func ReadAround(path string, offset int64, before, after int) ([]string, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	start := max(offset-aroundWindow, 0)
+	head := make([]byte, offset-start)
+	if _, err := f.ReadAt(head, start); err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	var lines []string
+	if len(head) > 0 {
+		prev := strings.Split(string(bytes.TrimSuffix(head, []byte("\n"))), "\n")
+		if start > 0 {
+			prev = prev[1:] // the window starts mid-line
+		}
+		lines = prev[max(len(prev)-before, 0):]
+	}
+	at := len(lines)
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	reader := bufio.NewReader(f)
+	for i := 0; i <= after; i++ {
+		lineBytes, rerr := reader.ReadBytes('\n')
+		if len(lineBytes) > 0 {
+			lines = append(lines, string(lineBytes))
+		}
+		if rerr != nil {
+			break
+		}
+	}
+	for i, line := range lines {
+		lines[i] = core.SanitizeLine(TrimLineEnd(line, false))
+	}
+	return lines, at, nil
+}
@@ -0,0 +1,70 @@
+package input
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestGrepFile_FindsMatchesBeyondTheRing(t *testing.T) {
+	var b strings.Builder
+	for i := 1; i <= 1000; i++ {
+		if i == 10 || i == 500 || i == 990 {
+			fmt.Fprintf(&b, "line %d request timeout\r\n", i)
+		} else {
+			fmt.Fprintf(&b, "line %d ok\n", i)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "big.log")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ring only keeps the last 100 lines; line 10 and 500 are gone
+	ring := core.NewRing(100)
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		ring.Append(core.LogEvent{Line: line})
+	}
+	if got := ring.Snapshot()[0].Line; got != "line 901 ok" {
+		t.Fatalf("setup: oldest buffered line %q", got)
+	}
+
+	matcher, _ := core.NewMatcher("TIMEOUT")
+	matches, truncated, err := GrepFile(context.Background(), path, matcher, 100)
+	if err != nil || truncated {
+		t.Fatalf("GrepFile: %v (truncated %v)", err, truncated)
+	}
+	var lines []int
+	for _, m := range matches {
+		lines = append(lines, m.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{10, 500, 990}) || matches[0].Text != "line 10 request timeout" {
+		t.Fatalf("unexpected matches %+v", matches)
+	}
+
+	around, at, err := ReadAround(path, matches[1].Offset, 2, 2)
+	if err != nil {
+		t.Fatalf("ReadAround: %v", err)
+	}
+	want := []string{"line 498 ok", "line 499 ok", "line 500 request timeout", "line 501 ok", "line 502 ok"}
+	if !reflect.DeepEqual(around, want) || at != 2 {
+		t.Errorf("ReadAround = %q at %d, want %q at 2", around, at, want)
+	}
+
+	// At the start of the file there is nothing before the match
+	first, _ := core.NewMatcher("line 1 ok")
+	matches, _, _ = GrepFile(context.Background(), path, first, 1)
+	if around, at, _ := ReadAround(path, matches[0].Offset, 3, 1); at != 0 || len(around) != 2 {
+		t.Errorf("ReadAround at line 1 = %q at %d", around, at)
+	}
+
+	if _, truncated, _ := GrepFile(context.Background(), path, matcher, 2); !truncated {
+		t.Error("expected the limit to truncate the matches")
+	}
+}
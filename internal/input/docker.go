@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/germanoeich/siftail/internal/core"
 	"github.com/germanoeich/siftail/internal/dockerx"
 )
@@ -92,16 +93,24 @@ func (vs *VisibleSet) GetSnapshot() map[string]bool {
 	return snapshot
 }
 
+// DefaultDockerRefresh is how often the reader re-lists containers to
+// start streams for new ones. Container start events usually get there
+// first; the refresh is the fallback when events are unavailable.
+const DefaultDockerRefresh = 30 * time.Second
+
 // DockerReader reads logs from all running Docker containers
 type DockerReader struct {
 	client      dockerx.Client
 	levelDetect core.SeverityDetector
 	visible     *VisibleSet
+	refresh     time.Duration
+	since       time.Duration // backlog read from the containers found at start
 
 	// Internal state
 	mu            sync.RWMutex
 	containers    []dockerx.Container
 	activeStreams map[string]context.CancelFunc // containerID -> cancel func
+	died          map[string]bool               // containers that died while their stream drains
 	streamWG      sync.WaitGroup                // tracks active processStream goroutines
 }
 
@@ -111,10 +120,35 @@ func NewDockerReader(client dockerx.Client, levelDetect core.SeverityDetector) *
 		client:        client,
 		levelDetect:   levelDetect,
 		visible:       NewVisibleSet(),
+		refresh:       DefaultDockerRefresh,
 		activeStreams: make(map[string]context.CancelFunc),
+		died:          make(map[string]bool),
+	}
+}
+
+// SetRefreshInterval sets how often containers are re-listed; call it
+// before Start.
+func (dr *DockerReader) SetRefreshInterval(d time.Duration) {
+	if d > 0 {
+		dr.refresh = d
 	}
 }
 
+// SetSince makes the containers running at start include their logs from
+// the last d; call it before Start. Containers attached later start from
+// the time they are attached.
+func (dr *DockerReader) SetSince(d time.Duration) {
+	if d > 0 {
+		dr.since = d
+	}
+}
+
+// SinceTime formats the since-time for a log stream reaching back lookback
+// from now
+func SinceTime(now time.Time, lookback time.Duration) string {
+	return now.Add(-lookback).UTC().Format(time.RFC3339)
+}
+
 // GetVisibleSet returns the visibility control for container toggles
 func (dr *DockerReader) GetVisibleSet() *VisibleSet {
 	return dr.visible
@@ -130,6 +164,12 @@ func (dr *DockerReader) GetContainers() []dockerx.Container {
 	return result
 }
 
+// Seekable implements the Reader interface; live container streams are not
+// replayed from the start
+func (dr *DockerReader) Seekable() bool {
+	return false
+}
+
 // Start implements the Reader interface
 // Enumerates running containers and starts streaming logs from each
 func (dr *DockerReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
@@ -151,10 +191,13 @@ func (dr *DockerReader) run(ctx context.Context, eventCh chan<- core.LogEvent, e
 		close(errCh)
 	}()
 
+	// Subscribe before listing so containers starting in between are seen
+	events, eventErrs := dr.client.Events(ctx)
+
 	// Initial container discovery
 	if err := dr.refreshContainers(ctx); err != nil {
 		select {
-		case errCh <- fmt.Errorf("failed to list containers: %w", err):
+		case errCh <- fatal(fmt.Errorf("failed to list containers: %w", err)):
 		case <-ctx.Done():
 			return
 		}
@@ -162,10 +205,10 @@ func (dr *DockerReader) run(ctx context.Context, eventCh chan<- core.LogEvent, e
 	}
 
 	// Start streaming from all running containers
-	dr.startAllStreams(ctx, eventCh, errCh)
+	dr.startAllStreams(ctx, dr.since, eventCh, errCh)
 
-	// Set up periodic container refresh (every 30 seconds)
-	ticker := time.NewTicker(30 * time.Second)
+	// Set up periodic container refresh
+	ticker := time.NewTicker(dr.refresh)
 	defer ticker.Stop()
 
 	for {
@@ -181,7 +224,35 @@ func (dr *DockerReader) run(ctx context.Context, eventCh chan<- core.LogEvent, e
 					return
 				}
 			}
-			dr.startAllStreams(ctx, eventCh, errCh)
+			dr.startAllStreams(ctx, 0, eventCh, errCh)
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			// A dead container's stream drains to EOF by itself
+			switch ev.Action {
+			case dockerx.ActionDie:
+				dr.markDied(ev.ID)
+			case dockerx.ActionStart:
+				dr.replaceDiedStream(ev.ID)
+			}
+			if err := dr.refreshContainers(ctx); err != nil {
+				select {
+				case errCh <- fmt.Errorf("failed to refresh containers: %w", err):
+				case <-ctx.Done():
+					return
+				}
+			}
+			dr.startAllStreams(ctx, 0, eventCh, errCh)
+		case err := <-eventErrs:
+			// Keep going on the periodic refresh alone
+			events, eventErrs = nil, nil
+			select {
+			case errCh <- fmt.Errorf("container events unavailable, refreshing every %s: %w", dr.refresh, err):
+			case <-ctx.Done():
+				return
+			}
 		}
 	}
 }
@@ -208,11 +279,14 @@ func (dr *DockerReader) refreshContainers(ctx context.Context) error {
 	return nil
 }
 
-// startAllStreams starts log streams for any containers that don't have active streams
-func (dr *DockerReader) startAllStreams(ctx context.Context, eventCh chan<- core.LogEvent, errCh chan<- error) {
+// startAllStreams starts log streams for any containers that don't have
+// active streams, reaching back lookback for their earlier logs
+func (dr *DockerReader) startAllStreams(ctx context.Context, lookback time.Duration, eventCh chan<- core.LogEvent, errCh chan<- error) {
 	dr.mu.Lock()
 	defer dr.mu.Unlock()
 
+	since := SinceTime(time.Now(), lookback)
+
 	for _, container := range dr.containers {
 		// Check if we already have a stream for this container
 		if _, exists := dr.activeStreams[container.ID]; exists {
@@ -223,8 +297,32 @@ func (dr *DockerReader) startAllStreams(ctx context.Context, eventCh chan<- core
 		streamCtx, cancel := context.WithCancel(ctx)
 		dr.activeStreams[container.ID] = cancel
 
-		go dr.streamContainer(streamCtx, container, eventCh, errCh)
+		go dr.streamContainer(streamCtx, container, since, eventCh, errCh)
+	}
+}
+
+// markDied notes that a container with a stream died; the stream keeps
+// reading until its logs end
+func (dr *DockerReader) markDied(containerID string) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	if _, ok := dr.activeStreams[containerID]; ok {
+		dr.died[containerID] = true
+	}
+}
+
+// replaceDiedStream cancels the stream of a container that started again
+// before the stream from its previous run ended, so a new one is attached
+func (dr *DockerReader) replaceDiedStream(containerID string) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	if cancel, ok := dr.activeStreams[containerID]; ok && dr.died[containerID] {
+		cancel()
+		delete(dr.activeStreams, containerID)
 	}
+	delete(dr.died, containerID)
 }
 
 // stopAllStreams cancels all active container log streams
@@ -236,19 +334,24 @@ func (dr *DockerReader) stopAllStreams() {
 		cancel()
 	}
 	dr.activeStreams = make(map[string]context.CancelFunc)
+	dr.died = make(map[string]bool)
 }
 
 // streamContainer streams logs from a single container
-func (dr *DockerReader) streamContainer(ctx context.Context, container dockerx.Container, eventCh chan<- core.LogEvent, errCh chan<- error) {
+func (dr *DockerReader) streamContainer(ctx context.Context, container dockerx.Container, since string, eventCh chan<- core.LogEvent, errCh chan<- error) {
 	defer func() {
-		// Clean up this stream from activeStreams when it exits
+		// A cancelled stream was already removed by replaceDiedStream or
+		// stopAllStreams, and its ID may belong to a newer stream by now
 		dr.mu.Lock()
-		delete(dr.activeStreams, container.ID)
+		if cancel, ok := dr.activeStreams[container.ID]; ok && ctx.Err() == nil {
+			cancel()
+			delete(dr.activeStreams, container.ID)
+			delete(dr.died, container.ID)
+		}
 		dr.mu.Unlock()
 	}()
 
-	// Start streaming logs from "now" to avoid old logs
-	stream, err := dr.client.StreamLogs(ctx, container.ID, time.Now().UTC().Format(time.RFC3339))
+	stream, err := dr.client.StreamLogs(ctx, container.ID, since)
 	if err != nil {
 		select {
 		case errCh <- fmt.Errorf("failed to stream logs for container %s (%s): %w", container.Name, container.ID, err):
@@ -257,25 +360,46 @@ func (dr *DockerReader) streamContainer(ctx context.Context, container dockerx.C
 		return
 	}
 	defer stream.Close()
+	// Unblock the format check below on a silent container
+	defer context.AfterFunc(ctx, func() { stream.Close() })()
+
+	// Containers without a TTY multiplex stdout and stderr into frames;
+	// TTY containers (and the fake client) send plain text, all stdout.
+	var streams sync.WaitGroup
+	process := func(r io.ReadCloser, streamType string) {
+		dr.streamWG.Add(1)
+		streams.Add(1)
+		go func() {
+			defer streams.Done()
+			dr.processStream(ctx, r, container, streamType, eventCh, errCh)
+		}()
+	}
+	buffered := bufio.NewReader(stream)
+	if isMultiplexed(buffered) {
+		stdoutReader, stdoutWriter := io.Pipe()
+		stderrReader, stderrWriter := io.Pipe()
+		go func() {
+			_, err := stdcopy.StdCopy(stdoutWriter, stderrWriter, buffered)
+			stdoutWriter.CloseWithError(err)
+			stderrWriter.CloseWithError(err)
+		}()
+		process(stdoutReader, "stdout")
+		process(stderrReader, "stderr")
+	} else {
+		process(io.NopCloser(buffered), "stdout")
+	}
+
+	// Wait until the logs end, e.g. after the container died, or the stream
+	// is cancelled
+	streams.Wait()
+}
 
-	// For simplicity, treat the stream as a single output (stdout)
-	// TODO: In a real implementation with real Docker daemon, use stdcopy.StdCopy to demultiplex
-	// Docker's multiplexed stream format. The fake client outputs plain text for testing.
-	// Real implementation would look like:
-	//   stdoutReader, stdoutWriter := io.Pipe()
-	//   stderrReader, stderrWriter := io.Pipe()
-	//   go func() {
-	//     defer stdoutWriter.Close()
-	//     defer stderrWriter.Close()
-	//     stdcopy.StdCopy(stdoutWriter, stderrWriter, stream)
-	//   }()
-	//   go dr.processStream(ctx, stdoutReader, container, "stdout", eventCh, errCh)
-	//   go dr.processStream(ctx, stderrReader, container, "stderr", eventCh, errCh)
-	dr.streamWG.Add(1)
-	go dr.processStream(ctx, stream, container, "stdout", eventCh, errCh)
-
-	// Wait for context cancellation
-	<-ctx.Done()
+// isMultiplexed reports whether a log stream starts with a stdcopy frame
+// header, whose first byte is the stream type (0-3) where text has a
+// printable character.
+func isMultiplexed(r *bufio.Reader) bool {
+	head, err := r.Peek(1)
+	return err == nil && head[0] <= byte(stdcopy.Systemerr)
 }
 
 // processStream processes a single stream (stdout or stderr) from a container
@@ -320,6 +444,7 @@ func (dr *DockerReader) processStream(ctx context.Context, reader io.ReadCloser,
 			Time:      timestamp,
 			Source:    core.SourceDocker,
 			Container: container.Name,
+			Stream:    streamType,
 			Line:      message,
 			LevelStr:  levelStr,
 			Level:     level,
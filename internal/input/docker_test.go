@@ -1,11 +1,16 @@
 package input
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/germanoeich/siftail/internal/core"
 	"github.com/germanoeich/siftail/internal/dockerx"
 )
@@ -334,3 +339,255 @@ func TestDockerReader_ContextCancellation(t *testing.T) {
 		t.Error("Error channel should have closed after context cancellation")
 	}
 }
+
+func TestDockerReader_RefreshInterval_PicksUpNewContainers(t *testing.T) {
+	fakeClient := dockerx.NewFakeClient()
+	fakeClient.AddContainer("container1", "app1", "running")
+	fakeClient.AddLogLines("container1", []string{"2023-01-01T12:00:00.000000000Z first"})
+
+	reader := NewDockerReader(fakeClient, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	reader.SetRefreshInterval(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	eventCh, _ := reader.Start(ctx)
+
+	for fakeClient.ListCalls() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	// A container started after the initial listing is only found by a refresh
+	fakeClient.AddLogLines("container2", []string{"2023-01-01T12:00:01.000000000Z late"})
+	fakeClient.AddContainer("container2", "app2", "running")
+
+	for {
+		select {
+		case e := <-eventCh:
+			if e.Container == "app2" {
+				if calls := fakeClient.ListCalls(); calls < 2 {
+					t.Errorf("expected repeated listing, got %d calls", calls)
+				}
+				return
+			}
+		case <-ctx.Done():
+			t.Fatalf("new container not picked up with a 50ms refresh (%d list calls)", fakeClient.ListCalls())
+		}
+	}
+}
+
+func TestDockerReader_StartEvent_AttachesPromptly(t *testing.T) {
+	fakeClient := dockerx.NewFakeClient()
+	fakeClient.AddContainer("container1", "app1", "running")
+	fakeClient.AddLogLines("container1", []string{"2023-01-01T12:00:00.000000000Z first"})
+
+	reader := NewDockerReader(fakeClient, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	reader.SetRefreshInterval(time.Hour) // only the event can find the new container
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	eventCh, errCh := reader.Start(ctx)
+
+	for fakeClient.ListCalls() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	fakeClient.AddLogLines("container2", []string{"2023-01-01T12:00:01.000000000Z fresh"})
+	fakeClient.AddContainer("container2", "app2", "running")
+	started := time.Now()
+	fakeClient.EmitEvent(dockerx.ContainerEvent{ID: "container2", Action: dockerx.ActionStart})
+
+	for {
+		select {
+		case e := <-eventCh:
+			if e.Container == "app2" {
+				if waited := time.Since(started); waited > time.Second {
+					t.Errorf("new container attached after %s", waited)
+				}
+				return
+			}
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-ctx.Done():
+			t.Fatal("start event did not attach the new container")
+		}
+	}
+}
+
+func TestDockerReader_DieDrainsStreamAndRestartReattaches(t *testing.T) {
+	fakeClient := dockerx.NewFakeClient()
+	fakeClient.SetFollow(true)
+	fakeClient.AddContainer("container1", "app1", "running")
+	fakeClient.AddLogLines("container1", []string{"first", "last words"})
+
+	reader := NewDockerReader(fakeClient, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	reader.SetRefreshInterval(time.Hour) // only events attach streams
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	eventCh, errCh := reader.Start(ctx)
+
+	next := func(want string) {
+		t.Helper()
+		select {
+		case e := <-eventCh:
+			if e.Line != want {
+				t.Fatalf("got %q, want %q", e.Line, want)
+			}
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-ctx.Done():
+			t.Fatalf("no %q event", want)
+		}
+	}
+
+	next("first")
+	// The die event can arrive before the last lines are read
+	fakeClient.EmitEvent(dockerx.ContainerEvent{ID: "container1", Action: dockerx.ActionDie})
+	next("last words")
+
+	fakeClient.StopContainer("container1")
+	fakeClient.StartContainer("container1")
+	fakeClient.EmitEvent(dockerx.ContainerEvent{ID: "container1", Action: dockerx.ActionStart})
+	next("first")
+}
+
+func TestSinceTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 14, 30, 15, 500, time.FixedZone("CEST", 2*60*60))
+	if got, want := SinceTime(now, 10*time.Minute), "2024-05-01T12:20:15Z"; got != want {
+		t.Errorf("SinceTime(10m) = %q, want %q", got, want)
+	}
+	if got, want := SinceTime(now, 0), "2024-05-01T12:30:15Z"; got != want {
+		t.Errorf("SinceTime(0) = %q, want %q", got, want)
+	}
+}
+
+func TestDockerReader_SinceOnlyReachesBackAtStart(t *testing.T) {
+	fakeClient := dockerx.NewFakeClient()
+	fakeClient.AddContainer("container1", "app1", "running")
+	fakeClient.AddLogLines("container1", []string{"2023-01-01T12:00:00.000000000Z first"})
+
+	reader := NewDockerReader(fakeClient, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	reader.SetRefreshInterval(time.Hour)
+	reader.SetSince(10 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	eventCh, _ := reader.Start(ctx)
+
+	<-eventCh
+	fakeClient.AddLogLines("container2", []string{"2023-01-01T12:00:01.000000000Z fresh"})
+	fakeClient.AddContainer("container2", "app2", "running")
+	fakeClient.EmitEvent(dockerx.ContainerEvent{ID: "container2", Action: dockerx.ActionStart})
+	for e := range eventCh {
+		if e.Container == "app2" {
+			break
+		}
+	}
+
+	sinceOf := func(id string) time.Duration {
+		t.Helper()
+		since, err := time.Parse(time.RFC3339, fakeClient.StreamSince(id))
+		if err != nil {
+			t.Fatalf("since for %s: %v", id, err)
+		}
+		return time.Since(since)
+	}
+	if back := sinceOf("container1"); back < 9*time.Minute || back > 11*time.Minute {
+		t.Errorf("container found at start reached back %s, want about 10m", back)
+	}
+	if back := sinceOf("container2"); back > time.Minute {
+		t.Errorf("container attached later reached back %s, want about now", back)
+	}
+}
+
+func TestDockerReader_EventsUnavailable_FallsBackToRefresh(t *testing.T) {
+	fakeClient := dockerx.NewFakeClient()
+	fakeClient.SetError("Events", fmt.Errorf("events not supported"))
+	fakeClient.AddContainer("container1", "app1", "running")
+	fakeClient.AddLogLines("container1", []string{"2023-01-01T12:00:00.000000000Z first"})
+
+	reader := NewDockerReader(fakeClient, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	eventCh, errCh := reader.Start(ctx)
+
+	var gotErr, gotEvent bool
+	for !gotErr || !gotEvent {
+		select {
+		case err := <-errCh:
+			if IsFatal(err) || !strings.Contains(err.Error(), "events unavailable") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			gotErr = true
+		case <-eventCh:
+			gotEvent = true
+		case <-ctx.Done():
+			t.Fatalf("error reported: %v, events streamed: %v", gotErr, gotEvent)
+		}
+	}
+}
+
+// framedClient serves the given bytes as every container's log stream, like
+// the Docker daemon does for containers without a TTY
+type framedClient struct {
+	*dockerx.FakeClient
+	data []byte
+}
+
+func (c framedClient) StreamLogs(ctx context.Context, id, since string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(c.data)), nil
+}
+
+func TestDockerReader_DemultiplexesFramedStream(t *testing.T) {
+	var framed bytes.Buffer
+	stdout := stdcopy.NewStdWriter(&framed, stdcopy.Stdout)
+	stderr := stdcopy.NewStdWriter(&framed, stdcopy.Stderr)
+	fmt.Fprint(stdout, "2023-01-01T12:00:00.000000000Z server listening\n")
+	fmt.Fprint(stderr, "2023-01-01T12:00:01.000000000Z panic: nil map\n")
+	fmt.Fprint(stdout, "2023-01-01T12:00:02.000000000Z request done\n")
+
+	fake := dockerx.NewFakeClient()
+	fake.AddContainer("c1", "api", "running")
+	reader := NewDockerReader(framedClient{FakeClient: fake, data: framed.Bytes()}, core.NewDefaultSeverityDetector(core.NewLevelMap()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	eventCh, errCh := reader.Start(ctx)
+
+	got := map[string]string{}
+	for len(got) < 3 {
+		select {
+		case e := <-eventCh:
+			got[e.Line] = e.Stream
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-ctx.Done():
+			t.Fatalf("timed out; got %v", got)
+		}
+	}
+	want := map[string]string{
+		"server listening": "stdout",
+		"panic: nil map":   "stderr",
+		"request done":     "stdout",
+	}
+	for line, stream := range want {
+		if got[line] != stream {
+			t.Errorf("%q: stream %q, want %q (all: %q)", line, got[line], stream, got)
+		}
+	}
+}
+
+func TestIsMultiplexed(t *testing.T) {
+	var framed bytes.Buffer
+	fmt.Fprint(stdcopy.NewStdWriter(&framed, stdcopy.Stderr), "oops\n")
+	for _, tt := range []struct {
+		in   []byte
+		want bool
+	}{
+		{framed.Bytes(), true},
+		{[]byte("2023-01-01T12:00:00Z plain tty line\n"), false},
+		{nil, false},
+	} {
+		if got := isMultiplexed(bufio.NewReader(bytes.NewReader(tt.in))); got != tt.want {
+			t.Errorf("isMultiplexed(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -2,10 +2,16 @@ package input
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -20,10 +26,38 @@ type FileReader struct {
 	seq       uint64
 	file      *os.File
 	watcher   *fsnotify.Watcher
+	target    string            // real path of the file, path with symlinks resolved
+	linkWatch *fsnotify.Watcher // set when path is a symlink: watches its directory
 	lastStat  os.FileInfo
+	keepCR    bool
+	origin    string
+	gz        *gzip.Reader // set for gzip-compressed files, which are read once
+	detector  core.SeverityDetector
+	lineNo    int            // line number of the last line read, relative to lineBase if set
+	lineBase  *core.LineBase // lines before the tailed ones, counted on demand; nil from the start
+	group     *traceGrouper  // set when grouping stack traces
+	poll      bool           // stat the file periodically instead of watching it
 }
 
-// NewFileReader creates a new file tailer
+// Polling, used when file events are unavailable (network filesystems, no
+// inotify watches left) or asked for with SetPoll
+const (
+	// pollInterval is how often a polled file is checked for changes
+	pollInterval = 500 * time.Millisecond
+	// eventStallTimeout is how long the file may have unread data without a
+	// single watcher event before the reader gives up on events and polls
+	eventStallTimeout = 2 * time.Second
+)
+
+// watchFile adds path to w; a variable so tests can simulate a filesystem
+// whose changes raise no events
+var watchFile = func(w *fsnotify.Watcher, path string) error {
+	return w.Add(path)
+}
+
+// NewFileReader creates a new file tailer. Gzip-compressed files (rotated
+// logs such as app.log.1.gz) don't grow: they are decompressed, read to the
+// end when fromStart is set, and not watched.
 func NewFileReader(path string, fromStart bool) *FileReader {
 	return &FileReader{
 		path:      path,
@@ -31,6 +65,38 @@ func NewFileReader(path string, fromStart bool) *FileReader {
 	}
 }
 
+// SetKeepCR keeps the \r of CRLF line endings instead of stripping it.
+func (f *FileReader) SetKeepCR(keep bool) {
+	f.keepCR = keep
+}
+
+// SetDetector detects the level of each line with d; without one, levels
+// are left unknown.
+func (f *FileReader) SetDetector(d core.SeverityDetector) {
+	f.detector = d
+}
+
+// SetGroupStackTraces joins stack trace continuation lines onto the line
+// before them, making each trace one multi-line event.
+func (f *FileReader) SetGroupStackTraces(group bool) {
+	f.group = nil
+	if group {
+		f.group = &traceGrouper{}
+	}
+}
+
+// SetPoll checks the file for changes every pollInterval instead of waiting
+// for file events, for filesystems that don't raise them (e.g. NFS).
+func (f *FileReader) SetPoll(poll bool) {
+	f.poll = poll
+}
+
+// SetOrigin tags every event with name, to tell files apart when several
+// are tailed together.
+func (f *FileReader) SetOrigin(name string) {
+	f.origin = name
+}
+
 // Start implements the Reader interface
 func (f *FileReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
 	eventCh := make(chan core.LogEvent, 50)
@@ -43,7 +109,7 @@ func (f *FileReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan er
 
 		if err := f.initialize(); err != nil {
 			select {
-			case errCh <- fmt.Errorf("failed to initialize file reader: %w", err):
+			case errCh <- fatal(fmt.Errorf("failed to initialize file reader: %w", err)):
 			case <-ctx.Done():
 			}
 			return
@@ -55,16 +121,28 @@ func (f *FileReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan er
 	return eventCh, errCh
 }
 
+// Seekable implements the Reader interface; files can be re-read from the start
+func (f *FileReader) Seekable() bool {
+	return true
+}
+
 // initialize sets up the file handle and watcher
 func (f *FileReader) initialize() error {
 	var err error
 
 	// Open the file
-	f.file, err = os.Open(f.path)
-	if err != nil {
+	if err := f.openTarget(); err != nil {
 		return fmt.Errorf("failed to open file %s: %w", f.path, err)
 	}
 
+	f.gz, err = newGzipReader(f.file)
+	if err != nil {
+		return fmt.Errorf("failed to read gzip file %s: %w", f.path, err)
+	}
+	if f.gz != nil {
+		return nil
+	}
+
 	// Get initial file stats
 	f.lastStat, err = f.file.Stat()
 	if err != nil {
@@ -73,28 +151,85 @@ func (f *FileReader) initialize() error {
 
 	// Position cursor
 	if !f.fromStart {
+		// Number lines past the existing ones, counted only when a number
+		// is needed; a trailing partial line is finished by the next write
+		target, size := f.target, f.lastStat.Size()
+		f.lineBase = core.NewLineBase(func() (int, error) {
+			n, partial, err := CountLinesBefore(target, size)
+			if partial {
+				n--
+			}
+			return n, err
+		})
 		// Seek to end unless fromStart is requested
 		if _, err := f.file.Seek(0, io.SeekEnd); err != nil {
 			return fmt.Errorf("failed to seek to end of file: %w", err)
 		}
 	}
 
-	// Set up fsnotify watcher
+	if f.poll {
+		return nil
+	}
+
+	// Set up fsnotify watcher; without one, the file is polled
 	f.watcher, err = fsnotify.NewWatcher()
 	if err != nil {
-		return fmt.Errorf("failed to create watcher: %w", err)
+		f.watcher = nil
+		return nil
 	}
 
 	// Watch the file
-	if err := f.watcher.Add(f.path); err != nil {
-		return fmt.Errorf("failed to watch file %s: %w", f.path, err)
+	if err := watchFile(f.watcher, f.target); err != nil {
+		f.watcher.Close()
+		f.watcher = nil
+		return nil
 	}
 
+	// A symlink can be pointed at another file (a "current" log swapped on
+	// rotation): watch its directory to notice
+	if info, err := os.Lstat(f.path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		f.linkWatch, err = fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to create watcher: %w", err)
+		}
+		if err := f.linkWatch.Add(filepath.Dir(f.path)); err != nil {
+			return fmt.Errorf("failed to watch directory of %s: %w", f.path, err)
+		}
+	}
+
+	return nil
+}
+
+// openTarget opens the file at path, following symlinks, and records its
+// real path
+func (f *FileReader) openTarget() error {
+	target, err := filepath.EvalSymlinks(f.path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(target)
+	if err != nil {
+		return err
+	}
+	f.file, f.target = file, target
 	return nil
 }
 
+// retargeted reports whether the symlinked path now leads to another file
+func (f *FileReader) retargeted() bool {
+	target, err := filepath.EvalSymlinks(f.path)
+	return err == nil && target != f.target
+}
+
 // run is the main event loop
 func (f *FileReader) run(ctx context.Context, eventCh chan<- core.LogEvent, errCh chan<- error) {
+	if f.gz != nil {
+		if f.fromStart {
+			f.readAvailableLines(bufio.NewReader(f.gz), eventCh, errCh, ctx)
+		}
+		return
+	}
+
 	reader := bufio.NewReader(f.file)
 	backoffTimer := time.NewTimer(0)
 	backoffTimer.Stop()
@@ -104,6 +239,21 @@ func (f *FileReader) run(ctx context.Context, eventCh chan<- core.LogEvent, errC
 		f.readAvailableLines(reader, eventCh, errCh, ctx)
 	}
 
+	if f.watcher == nil {
+		f.pollLoop(ctx, reader, eventCh, errCh, backoffTimer)
+		return
+	}
+
+	var linkEvents <-chan fsnotify.Event
+	if f.linkWatch != nil {
+		linkEvents = f.linkWatch.Events
+	}
+
+	// Data the watcher stays silent about means events don't work here
+	stallTicker := time.NewTicker(pollInterval)
+	defer stallTicker.Stop()
+	var unreadSince time.Time
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -113,10 +263,23 @@ func (f *FileReader) run(ctx context.Context, eventCh chan<- core.LogEvent, errC
 			// Retry after backoff
 			f.readAvailableLines(reader, eventCh, errCh, ctx)
 
+		case <-stallTicker.C:
+			if !f.hasUnread() {
+				unreadSince = time.Time{}
+			} else if unreadSince.IsZero() {
+				unreadSince = time.Now()
+			} else if time.Since(unreadSince) >= eventStallTimeout {
+				f.stopWatching()
+				f.readAvailableLines(reader, eventCh, errCh, ctx)
+				f.pollLoop(ctx, reader, eventCh, errCh, backoffTimer)
+				return
+			}
+
 		case event, ok := <-f.watcher.Events:
 			if !ok {
 				return // watcher closed
 			}
+			unreadSince = time.Time{}
 
 			switch {
 			case event.Has(fsnotify.Write):
@@ -141,17 +304,8 @@ func (f *FileReader) run(ctx context.Context, eventCh chan<- core.LogEvent, errC
 
 			case event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove):
 				// File was rotated/removed - handle rotation
-				if err := f.handleRotation(reader, eventCh, errCh); err != nil {
-					select {
-					case errCh <- fmt.Errorf("rotation handling failed: %w", err):
-					case <-ctx.Done():
-						return
-					}
-					// Start backoff on rotation error
-					backoffTimer.Reset(100 * time.Millisecond)
-				} else {
-					// After successful rotation, read new content
-					f.readAvailableLines(reader, eventCh, errCh, ctx)
+				if !f.rotate(ctx, reader, eventCh, errCh, backoffTimer) {
+					return
 				}
 
 			case event.Has(fsnotify.Create):
@@ -159,6 +313,19 @@ func (f *FileReader) run(ctx context.Context, eventCh chan<- core.LogEvent, errC
 				f.readAvailableLines(reader, eventCh, errCh, ctx)
 			}
 
+		case event, ok := <-linkEvents:
+			if !ok {
+				linkEvents = nil
+				continue
+			}
+			// The symlink was replaced: follow it to its new target
+			if filepath.Clean(event.Name) != filepath.Clean(f.path) || !f.retargeted() {
+				continue
+			}
+			if !f.rotate(ctx, reader, eventCh, errCh, backoffTimer) {
+				return
+			}
+
 		case err, ok := <-f.watcher.Errors:
 			if !ok {
 				return // watcher closed
@@ -172,6 +339,92 @@ func (f *FileReader) run(ctx context.Context, eventCh chan<- core.LogEvent, errC
 	}
 }
 
+// pollLoop follows the file without watcher events: every pollInterval it
+// reads what was appended, and rotates when the file was truncated or
+// replaced (including a symlink pointed elsewhere).
+func (f *FileReader) pollLoop(ctx context.Context, reader *bufio.Reader, eventCh chan<- core.LogEvent, errCh chan<- error, backoffTimer *time.Timer) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-backoffTimer.C:
+			f.readAvailableLines(reader, eventCh, errCh, ctx)
+
+		case <-ticker.C:
+			// A missing file may be mid-rotation; wait for it to come back
+			info, err := os.Stat(f.path)
+			if err != nil {
+				continue
+			}
+			var pos int64
+			if f.file != nil {
+				pos, _ = f.file.Seek(0, io.SeekCurrent)
+			}
+			switch {
+			case f.file == nil || !os.SameFile(info, f.lastStat) || info.Size() < pos:
+				if !f.rotate(ctx, reader, eventCh, errCh, backoffTimer) {
+					return
+				}
+			case info.Size() > pos:
+				f.readAvailableLines(reader, eventCh, errCh, ctx)
+			}
+		}
+	}
+}
+
+// hasUnread reports whether the file holds data past what was read
+func (f *FileReader) hasUnread() bool {
+	if f.file == nil {
+		return false
+	}
+	info, err := f.file.Stat()
+	if err != nil {
+		return false
+	}
+	pos, err := f.file.Seek(0, io.SeekCurrent)
+	return err == nil && info.Size() > pos
+}
+
+// stopWatching drops the watchers, leaving the file to be polled
+func (f *FileReader) stopWatching() {
+	f.watcher.Close()
+	f.watcher = nil
+	if f.linkWatch != nil {
+		f.linkWatch.Close()
+		f.linkWatch = nil
+	}
+}
+
+// rotate switches to the file now at path and reads it from the start; on
+// failure it reports the error and arms backoffTimer to retry reading. It
+// returns false when the reader must stop.
+func (f *FileReader) rotate(ctx context.Context, reader *bufio.Reader, eventCh chan<- core.LogEvent, errCh chan<- error, backoffTimer *time.Timer) bool {
+	err := f.handleRotation(reader, eventCh, errCh)
+	if err == nil {
+		// After successful rotation, read new content
+		f.readAvailableLines(reader, eventCh, errCh, ctx)
+		return true
+	}
+	// A file that is back but unreadable won't recover
+	if errors.Is(err, fs.ErrPermission) {
+		err = fatal(err)
+	}
+	select {
+	case errCh <- fmt.Errorf("rotation handling failed: %w", err):
+	case <-ctx.Done():
+		return false
+	}
+	if IsFatal(err) {
+		return false
+	}
+	// Start backoff on rotation error
+	backoffTimer.Reset(100 * time.Millisecond)
+	return true
+}
+
 // readAvailableLines reads all available lines from the current position
 func (f *FileReader) readAvailableLines(reader *bufio.Reader, eventCh chan<- core.LogEvent, errCh chan<- error, ctx context.Context) {
 	for {
@@ -186,16 +439,14 @@ func (f *FileReader) readAvailableLines(reader *bufio.Reader, eventCh chan<- cor
 			if err == io.EOF {
 				// Process any remaining data without newline
 				if len(lineBytes) > 0 {
-					line := string(lineBytes)
+					line := TrimLineEnd(string(lineBytes), f.keepCR)
 					line = core.SanitizeLine(line)
-					event := f.createLogEvent(line)
-					select {
-					case eventCh <- event:
-					case <-ctx.Done():
+					if !f.send(ctx, eventCh, line) {
 						return
 					}
 				}
 				// EOF means no more data currently available
+				f.flushGroup(ctx, eventCh)
 				return
 			}
 			// Other read errors
@@ -207,24 +458,48 @@ func (f *FileReader) readAvailableLines(reader *bufio.Reader, eventCh chan<- cor
 			return
 		}
 
-		// Convert bytes to string and trim newline
-		line := string(lineBytes)
-		if len(line) > 0 && line[len(line)-1] == '\n' {
-			line = line[:len(line)-1]
-		}
+		// Convert bytes to string and trim the line ending
+		line := TrimLineEnd(string(lineBytes), f.keepCR)
 
 		// Sanitize destructive ANSI/control sequences
 		line = core.SanitizeLine(line)
 
-		event := f.createLogEvent(line)
+		if !f.send(ctx, eventCh, line) {
+			return
+		}
+	}
+}
+
+// flushGroup emits the stack trace being grouped, if any
+func (f *FileReader) flushGroup(ctx context.Context, eventCh chan<- core.LogEvent) {
+	if f.group == nil {
+		return
+	}
+	if line, ok := f.group.flush(); ok {
 		select {
-		case eventCh <- event:
+		case eventCh <- f.createLogEvent(line):
 		case <-ctx.Done():
-			return
 		}
 	}
 }
 
+// send emits line as an event, through the stack trace grouper when
+// grouping; it returns false once ctx is done.
+func (f *FileReader) send(ctx context.Context, eventCh chan<- core.LogEvent, line string) bool {
+	if f.group != nil {
+		var ok bool
+		if line, ok = f.group.add(line); !ok {
+			return true
+		}
+	}
+	select {
+	case eventCh <- f.createLogEvent(line):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // handleRotation handles file rotation scenarios
 func (f *FileReader) handleRotation(reader *bufio.Reader, eventCh chan<- core.LogEvent, errCh chan<- error) error {
 	// Try to read any remaining data from the current file handle
@@ -237,7 +512,9 @@ func (f *FileReader) handleRotation(reader *bufio.Reader, eventCh chan<- core.Lo
 	}
 
 	// Remove the old watch to avoid conflicts
-	f.watcher.Remove(f.path)
+	if f.watcher != nil {
+		f.watcher.Remove(f.target)
+	}
 
 	// Attempt to reopen the file (it might have been recreated)
 	var err error
@@ -245,7 +522,8 @@ func (f *FileReader) handleRotation(reader *bufio.Reader, eventCh chan<- core.Lo
 	maxRetries := 20
 
 	for retries < maxRetries {
-		f.file, err = os.Open(f.path)
+		// Resolve symlinks again: the link may lead to a new file now
+		err = f.openTarget()
 		if err == nil {
 			break
 		}
@@ -270,10 +548,13 @@ func (f *FileReader) handleRotation(reader *bufio.Reader, eventCh chan<- core.Lo
 	f.lastStat = newStat
 	f.file.Seek(0, io.SeekStart)
 	reader.Reset(f.file)
+	f.lineNo, f.lineBase = 0, nil
 
 	// Re-add to watcher
-	if err := f.watcher.Add(f.path); err != nil {
-		return fmt.Errorf("failed to re-watch file: %w", err)
+	if f.watcher != nil {
+		if err := watchFile(f.watcher, f.target); err != nil {
+			return fmt.Errorf("failed to re-watch file: %w", err)
+		}
 	}
 
 	return nil
@@ -302,23 +583,75 @@ func (f *FileReader) checkForTruncation() bool {
 // createLogEvent creates a LogEvent from a line of input
 func (f *FileReader) createLogEvent(line string) core.LogEvent {
 	seq := atomic.AddUint64(&f.seq, 1)
+	// A grouped stack trace spans several lines; LineNo is its first
+	extra := strings.Count(line, "\n")
+	f.lineNo += 1 + extra
 
-	return core.LogEvent{
+	event := core.LogEvent{
 		Seq:       seq,
 		Time:      time.Now(),
 		Source:    core.SourceFile,
 		Container: "",
+		Origin:    f.origin,
 		Line:      line,
-		LevelStr:  "", // TODO: Add severity detection in future
+		LevelStr:  "",
 		Level:     core.SevUnknown,
+		LineNo:    f.lineNo - extra,
+		LineBase:  f.lineBase,
 	}
+	if f.detector != nil {
+		event.LevelStr, event.Level, _ = f.detector.Detect(line)
+	}
+	return event
+}
+
+// countLines counts the lines in r: its newlines, plus one for a last line
+// without a newline, which partial reports.
+func countLines(r io.Reader) (n int, partial bool, err error) {
+	buf := make([]byte, 64*1024)
+	var last byte = '\n'
+	for {
+		k, err := r.Read(buf)
+		if k > 0 {
+			n += bytes.Count(buf[:k], []byte{'\n'})
+			last = buf[k-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+	if last != '\n' {
+		return n + 1, true, nil
+	}
+	return n, false, nil
+}
+
+// CountLinesBefore counts the lines in the first size bytes of the file at
+// path, decompressed for gzip files; partial reports a last line without a
+// newline.
+func CountLinesBefore(path string, size int64) (n int, partial bool, err error) {
+	f, err := OpenDecompressed(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+	return countLines(io.LimitReader(f, size))
 }
 
 // cleanup closes file handles and watcher
 func (f *FileReader) cleanup() {
+	if f.gz != nil {
+		f.gz.Close()
+	}
 	if f.watcher != nil {
 		f.watcher.Close()
 	}
+	if f.linkWatch != nil {
+		f.linkWatch.Close()
+	}
 	if f.file != nil {
 		f.file.Close()
 	}
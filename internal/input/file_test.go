@@ -1,12 +1,15 @@
 package input
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/germanoeich/siftail/internal/core"
 )
 
@@ -288,6 +291,64 @@ func TestTailer_RenameCreateRotation(t *testing.T) {
 	os.Remove(backupPath)
 }
 
+// TestTailer_SymlinkRetarget tests that tailing a symlinked "current" log
+// follows the link when it is swapped to a new target
+func TestTailer_SymlinkRetarget(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "app-1.log")
+	second := filepath.Join(dir, "app-2.log")
+	link := filepath.Join(dir, "current.log")
+	if err := os.WriteFile(first, []byte("first 1\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Symlink(first, link); err != nil {
+		t.Skipf("Symlinks unavailable: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventCh, errCh := NewFileReader(link, true).Start(ctx)
+	collectEvents(t, eventCh, 1, 2*time.Second)
+
+	// Swap the link atomically, as log rotators do, then write to the new target
+	if err := os.WriteFile(second, nil, 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Symlink(second, link+".tmp"); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := os.Rename(link+".tmp", link); err != nil {
+		t.Fatalf("Failed to swap symlink: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	out, err := os.OpenFile(second, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer out.Close()
+	out.WriteString("second 1\nsecond 2\n")
+
+	events := collectEvents(t, eventCh, 2, 3*time.Second)
+	if events[0].Line != "second 1" || events[1].Line != "second 2" {
+		t.Errorf("Expected the lines of the new target, got %q and %q", events[0].Line, events[1].Line)
+	}
+	if events[0].FileLine() != 1 {
+		t.Errorf("Expected numbering to restart on the new target, got line %d", events[0].FileLine())
+	}
+
+	// Writes to the old target are no longer followed
+	os.WriteFile(first, []byte("first 1\nstale\n"), 0o644)
+	out.WriteString("second 3\n")
+	if e := collectEvents(t, eventCh, 1, 2*time.Second)[0]; e.Line != "second 3" {
+		t.Errorf("Expected 'second 3', got %q", e.Line)
+	}
+	select {
+	case err := <-errCh:
+		t.Fatalf("Unexpected error: %v", err)
+	default:
+	}
+}
+
 // TestTailer_FromEndBehavior tests that tailer starts from end when fromStart is false
 func TestTailer_FromEndBehavior(t *testing.T) {
 	helper := newTestHelper(t)
@@ -323,6 +384,9 @@ func TestTailer_FromEndBehavior(t *testing.T) {
 	if events[0].Line != "new line after start" {
 		t.Errorf("Expected 'new line after start', got '%s'", events[0].Line)
 	}
+	if events[0].FileLine() != 3 {
+		t.Errorf("Expected line number 3 after two existing lines, got %d", events[0].FileLine())
+	}
 
 	// Check for errors
 	select {
@@ -375,3 +439,170 @@ func TestTailer_ContextCancellation(t *testing.T) {
 		t.Error("Error channel should close within reasonable time")
 	}
 }
+
+func TestTailer_GzipFileReadToEnd(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("rotated 1\nrotated 2\r\nrotated 3"))
+	zw.Close()
+	path := filepath.Join(t.TempDir(), "app.log.1.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write gzip file: %v", err)
+	}
+	if !IsGzip(path) {
+		t.Fatal("Expected the file to be detected as gzip")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventCh, errCh := NewFileReader(path, true).Start(ctx)
+
+	events := collectEvents(t, eventCh, 3, 2*time.Second)
+	for i, want := range []string{"rotated 1", "rotated 2", "rotated 3"} {
+		if events[i].Line != want || events[i].Source != core.SourceFile {
+			t.Errorf("Event %d: got %q (source %v), want %q from a file", i, events[i].Line, events[i].Source, want)
+		}
+	}
+
+	// A compressed file doesn't grow: the reader stops at the end
+	select {
+	case _, ok := <-eventCh:
+		if ok {
+			t.Error("Expected no events after the end of the gzip file")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Expected the reader to stop at the end of the gzip file")
+	}
+	if err, ok := <-errCh; ok {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestTailer_DetectsSeverity(t *testing.T) {
+	helper := newTestHelper(t)
+	defer helper.cleanup()
+	helper.writeLines("[ERROR] disk full", "plain line", "[TRACE] tick")
+
+	levels := core.NewLevelMap()
+	tailer := NewFileReader(helper.filePath(), true)
+	tailer.SetDetector(core.NewDefaultSeverityDetector(levels))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventCh, _ := tailer.Start(ctx)
+
+	events := collectEvents(t, eventCh, 3, 2*time.Second)
+	if events[0].Level != core.SevError || events[0].LevelStr == "" {
+		t.Errorf("Expected an ERROR event, got level %v %q", events[0].Level, events[0].LevelStr)
+	}
+	if events[1].Level != core.SevUnknown || events[1].LevelStr != "" {
+		t.Errorf("Expected no level for a plain line, got %v %q", events[1].Level, events[1].LevelStr)
+	}
+
+	// Custom levels still get a dynamic slot, so the toolbar shows them
+	if names, _ := levels.GetSnapshot(); names[5] != "TRACE" {
+		t.Errorf("Expected TRACE in slot 5, got %q", names[5])
+	}
+
+	helper.writeLines("[ERROR] appended")
+	if appended := collectEvents(t, eventCh, 1, 2*time.Second); appended[0].Level != core.SevError {
+		t.Errorf("Expected appended ERROR line detected, got %v", appended[0].Level)
+	}
+}
+
+const javaTrace = "Exception in thread \"main\" java.lang.IllegalStateException: boom\n" +
+	"\tat com.example.App.run(App.java:42)\n" +
+	"\tat com.example.App.main(App.java:7)\n" +
+	"Caused by: java.io.IOException: disk full\n" +
+	"\t... 2 more"
+
+func TestTailer_GroupsStackTraces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	content := "start\n" + javaTrace + "\nnext\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader := NewFileReader(path, true)
+	reader.SetGroupStackTraces(true)
+	eventCh, _ := reader.Start(ctx)
+
+	events := collectEvents(t, eventCh, 3, 2*time.Second)
+	for i, want := range []struct {
+		line   string
+		lineNo int
+	}{{"start", 1}, {javaTrace, 2}, {"next", 7}} {
+		if events[i].Line != want.line || events[i].FileLine() != want.lineNo {
+			t.Errorf("Event %d: got %q (line %d), want %q (line %d)", i, events[i].Line, events[i].FileLine(), want.line, want.lineNo)
+		}
+	}
+}
+
+// TestTailer_PollsWhenEventsStall tests that a file whose writes raise no
+// watcher events, as on network filesystems, is still tailed by polling
+func TestTailer_PollsWhenEventsStall(t *testing.T) {
+	watch := watchFile
+	watchFile = func(*fsnotify.Watcher, string) error { return nil } // silent watcher
+	defer func() { watchFile = watch }()
+
+	helper := newTestHelper(t)
+	defer helper.cleanup()
+	helper.writeLines("existing")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventCh, errCh := NewFileReader(helper.filePath(), false).Start(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	helper.writeLines("appended 1", "appended 2")
+	events := collectEvents(t, eventCh, 2, eventStallTimeout+3*time.Second)
+	if events[0].Line != "appended 1" || events[1].Line != "appended 2" {
+		t.Errorf("Expected the appended lines, got %q and %q", events[0].Line, events[1].Line)
+	}
+
+	// Now polling: later writes arrive without waiting for a stall again
+	helper.writeLines("appended 3")
+	if e := collectEvents(t, eventCh, 1, 3*pollInterval)[0]; e.Line != "appended 3" {
+		t.Errorf("Expected 'appended 3', got %q", e.Line)
+	}
+	select {
+	case err := <-errCh:
+		t.Fatalf("Unexpected error: %v", err)
+	default:
+	}
+}
+
+// TestTailer_PollModeRotation tests tailing with SetPoll across a
+// rename+create rotation
+func TestTailer_PollModeRotation(t *testing.T) {
+	helper := newTestHelper(t)
+	defer helper.cleanup()
+	helper.writeLines("old 1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader := NewFileReader(helper.filePath(), true)
+	reader.SetPoll(true)
+	eventCh, _ := reader.Start(ctx)
+	collectEvents(t, eventCh, 1, 2*time.Second)
+
+	helper.writeLines("old 2")
+	if e := collectEvents(t, eventCh, 1, 3*pollInterval)[0]; e.Line != "old 2" {
+		t.Errorf("Expected 'old 2', got %q", e.Line)
+	}
+
+	path := helper.filePath()
+	helper.file.Close()
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("Failed to rename file: %v", err)
+	}
+	var err error
+	if helper.file, err = os.Create(path); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	helper.writeLines("new 1")
+	if e := collectEvents(t, eventCh, 1, 3*time.Second)[0]; e.Line != "new 1" || e.FileLine() != 1 {
+		t.Errorf("Expected 'new 1' as line 1, got %q (line %d)", e.Line, e.FileLine())
+	}
+}
@@ -0,0 +1,67 @@
+package input
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// gzipMagic starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// IsGzip reports whether the file at path is gzip-compressed, judged by its
+// magic bytes rather than its name.
+func IsGzip(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := make([]byte, len(gzipMagic))
+	_, err = io.ReadFull(f, head)
+	return err == nil && bytes.Equal(head, gzipMagic)
+}
+
+// gzipFile is a decompressing reader that also closes the underlying file
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// OpenDecompressed opens path for reading, decompressing it when it is
+// gzip-compressed (e.g. a rotated app.log.1.gz).
+func OpenDecompressed(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := newGzipReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if gz == nil {
+		return f, nil
+	}
+	return gzipFile{Reader: gz, file: f}, nil
+}
+
+// newGzipReader returns a gzip reader over f when it starts with the gzip
+// magic bytes, or nil for a plain file. f is left at its start.
+func newGzipReader(f *os.File) (*gzip.Reader, error) {
+	head := make([]byte, len(gzipMagic))
+	n, err := f.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if n < len(head) || !bytes.Equal(head, gzipMagic) {
+		return nil, nil
+	}
+	return gzip.NewReader(f)
+}
@@ -0,0 +1,225 @@
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// errNoMatchingFile is returned when a directory has no file matching the glob.
+var errNoMatchingFile = errors.New("no matching file")
+
+// LatestFileReader tails the most recently modified file in a directory,
+// switching to a newer file whenever one appears.
+type LatestFileReader struct {
+	dir       string
+	pattern   string // glob matched against base names; empty matches all
+	fromStart bool   // applies to the first file; newer files are read from the start
+	keepCR    bool
+	group     bool
+	detector  core.SeverityDetector
+
+	mu      sync.Mutex
+	current string
+}
+
+// NewLatestFileReader creates a reader that follows the newest file in dir
+// whose base name matches pattern.
+func NewLatestFileReader(dir, pattern string, fromStart bool) *LatestFileReader {
+	if pattern == "" {
+		pattern = "*"
+	}
+	return &LatestFileReader{
+		dir:       dir,
+		pattern:   pattern,
+		fromStart: fromStart,
+	}
+}
+
+// SetKeepCR keeps the \r of CRLF line endings instead of stripping it.
+func (l *LatestFileReader) SetKeepCR(keep bool) {
+	l.keepCR = keep
+}
+
+// SetGroupStackTraces joins stack trace continuation lines onto the line
+// before them, making each trace one multi-line event.
+func (l *LatestFileReader) SetGroupStackTraces(group bool) {
+	l.group = group
+}
+
+// SetDetector detects the level of each line with d; without one, levels
+// are left unknown.
+func (l *LatestFileReader) SetDetector(d core.SeverityDetector) {
+	l.detector = d
+}
+
+// Current returns the path of the file currently being tailed.
+func (l *LatestFileReader) Current() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.current
+}
+
+// Seekable implements the Reader interface; the newest file can be re-read
+func (l *LatestFileReader) Seekable() bool {
+	return true
+}
+
+// Start implements the Reader interface
+func (l *LatestFileReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
+	eventCh := make(chan core.LogEvent, 50)
+	errCh := make(chan error, 5)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+
+		sendErr := func(err error) bool {
+			select {
+			case errCh <- err:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			sendErr(fmt.Errorf("failed to create watcher: %w", err))
+			return
+		}
+		defer watcher.Close()
+		if err := watcher.Add(l.dir); err != nil {
+			sendErr(fmt.Errorf("failed to watch directory %s: %w", l.dir, err))
+			return
+		}
+
+		var (
+			childCancel context.CancelFunc
+			childEvents <-chan core.LogEvent
+			childErrs   <-chan error
+		)
+		defer func() {
+			if childCancel != nil {
+				childCancel()
+			}
+		}()
+
+		follow := func(path string, fromStart bool) {
+			if childCancel != nil {
+				childCancel()
+			}
+			var childCtx context.Context
+			childCtx, childCancel = context.WithCancel(ctx)
+			child := NewFileReader(path, fromStart)
+			child.SetKeepCR(l.keepCR)
+			child.SetGroupStackTraces(l.group)
+			child.SetDetector(l.detector)
+			childEvents, childErrs = child.Start(childCtx)
+			l.mu.Lock()
+			l.current = path
+			l.mu.Unlock()
+		}
+
+		// Pick the initial file; if none exists yet, wait for one to appear
+		if path, err := NewestMatchingFile(l.dir, l.pattern); err == nil {
+			follow(path, l.fromStart)
+		} else if !errors.Is(err, errNoMatchingFile) {
+			if !sendErr(err) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) && !event.Has(fsnotify.Rename) {
+					continue
+				}
+				// Writes to the followed file can't make another file newer
+				if event.Has(fsnotify.Write) && event.Name == l.Current() {
+					continue
+				}
+				path, err := NewestMatchingFile(l.dir, l.pattern)
+				if err != nil || path == l.Current() {
+					continue
+				}
+				follow(path, true)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				if !sendErr(fmt.Errorf("watcher error: %w", err)) {
+					return
+				}
+
+			case e, ok := <-childEvents:
+				if !ok {
+					childEvents = nil
+					continue
+				}
+				select {
+				case eventCh <- e:
+				case <-ctx.Done():
+					return
+				}
+
+			case err, ok := <-childErrs:
+				if !ok {
+					childErrs = nil
+					continue
+				}
+				if !sendErr(err) {
+					return
+				}
+			}
+		}
+	}()
+
+	return eventCh, errCh
+}
+
+// NewestMatchingFile returns the most recently modified regular file in dir
+// whose base name matches pattern. Ties are broken by name for stability.
+func NewestMatchingFile(dir, pattern string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var (
+		best     string
+		bestInfo os.FileInfo
+	)
+	for _, entry := range entries {
+		if ok, _ := filepath.Match(pattern, entry.Name()); !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if bestInfo == nil || info.ModTime().After(bestInfo.ModTime()) ||
+			(info.ModTime().Equal(bestInfo.ModTime()) && entry.Name() > filepath.Base(best)) {
+			best = filepath.Join(dir, entry.Name())
+			bestInfo = info
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("%w in %s matching %q", errNoMatchingFile, dir, pattern)
+	}
+	return best, nil
+}
@@ -0,0 +1,81 @@
+package input
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFileWithMtime(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("Failed to set mtime on %s: %v", path, err)
+	}
+}
+
+func TestNewestMatchingFile(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	writeFileWithMtime(t, filepath.Join(dir, "app-1.log"), "", base)
+	writeFileWithMtime(t, filepath.Join(dir, "app-2.log"), "", base.Add(time.Minute))
+	writeFileWithMtime(t, filepath.Join(dir, "notes.txt"), "", base.Add(2*time.Minute))
+
+	got, err := NewestMatchingFile(dir, "*.log")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "app-2.log"); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	got, err = NewestMatchingFile(dir, "*")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "notes.txt"); got != want {
+		t.Errorf("Expected %s without glob, got %s", want, got)
+	}
+
+	if _, err := NewestMatchingFile(dir, "*.json"); err == nil {
+		t.Error("Expected error when nothing matches")
+	}
+}
+
+func TestLatestFileReader_FollowsNewestAndSwitches(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+	writeFileWithMtime(t, filepath.Join(dir, "app-1.log"), "old line\n", base)
+	writeFileWithMtime(t, filepath.Join(dir, "app-2.log"), "newest line\n", base.Add(time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := NewLatestFileReader(dir, "*.log", true)
+	eventCh, _ := reader.Start(ctx)
+
+	events := collectEvents(t, eventCh, 1, 2*time.Second)
+	if events[0].Line != "newest line" {
+		t.Errorf("Expected line from newest file, got %q", events[0].Line)
+	}
+	if got := reader.Current(); got != filepath.Join(dir, "app-2.log") {
+		t.Errorf("Expected to follow app-2.log, got %s", got)
+	}
+
+	// A newer file takes over and is read from the start
+	if err := os.WriteFile(filepath.Join(dir, "app-3.log"), []byte("rolled line\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write newer file: %v", err)
+	}
+
+	events = collectEvents(t, eventCh, 1, 2*time.Second)
+	if events[0].Line != "rolled line" {
+		t.Errorf("Expected line from newer file, got %q", events[0].Line)
+	}
+	if got := reader.Current(); got != filepath.Join(dir, "app-3.log") {
+		t.Errorf("Expected to follow app-3.log, got %s", got)
+	}
+}
@@ -2,6 +2,8 @@ package input
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
 
 	"github.com/germanoeich/siftail/internal/core"
@@ -11,6 +13,97 @@ import (
 type Reader interface {
 	// Start returns immediately; goroutine pumps events until ctx done.
 	Start(ctx context.Context) (<-chan core.LogEvent, <-chan error)
+	// Seekable reports whether a fresh reader can re-read the source from the
+	// start (files can; stdin and live streams cannot).
+	Seekable() bool
+}
+
+// FatalError is sent on a reader's error channel when the reader cannot go
+// on; it stops after sending it. Other errors are recoverable.
+type FatalError struct {
+	Err error
+}
+
+func (e *FatalError) Error() string { return e.Err.Error() }
+
+func (e *FatalError) Unwrap() error { return e.Err }
+
+// fatal marks err as non-recoverable
+func fatal(err error) error {
+	return &FatalError{Err: err}
+}
+
+// IsFatal reports whether err is a FatalError
+func IsFatal(err error) bool {
+	var f *FatalError
+	return errors.As(err, &f)
+}
+
+// TrimLineEnd drops the trailing \n and, unless keepCR is set, the \r of a
+// CRLF line ending.
+func TrimLineEnd(line string, keepCR bool) string {
+	line = strings.TrimSuffix(line, "\n")
+	if !keepCR {
+		line = strings.TrimSuffix(line, "\r")
+	}
+	return line
+}
+
+// traceGrouper joins stack trace continuation lines onto the line before
+// them, so a trace becomes one multi-line event (--group-stack-traces).
+type traceGrouper struct {
+	pending []string
+}
+
+// isContinuation reports whether line continues the event before it: it is
+// indented (Java "\tat ...", Python "  File ...") or starts with "Caused by".
+func isContinuation(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	return line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(line, "Caused by")
+}
+
+// add buffers line and returns the event completed by it, if any
+func (g *traceGrouper) add(line string) (string, bool) {
+	if len(g.pending) > 0 && isContinuation(line) {
+		g.pending = append(g.pending, line)
+		return "", false
+	}
+	done, ok := g.flush()
+	g.pending = append(g.pending, line)
+	return done, ok
+}
+
+// flush returns the buffered event, if any; readers call it when no more
+// input is available, so a trace isn't held back until the next line
+func (g *traceGrouper) flush() (string, bool) {
+	if len(g.pending) == 0 {
+		return "", false
+	}
+	line := strings.Join(g.pending, "\n")
+	g.pending = g.pending[:0]
+	return line, true
+}
+
+// GroupTraces wraps fn so stack trace continuation lines reach it joined
+// onto the line before them, as the readers group them, for callers that
+// read lines themselves. flush passes on the last event after the last line.
+func GroupTraces(fn func(line string) error) (add func(line string) error, flush func() error) {
+	g := &traceGrouper{}
+	add = func(line string) error {
+		if done, ok := g.add(line); ok {
+			return fn(done)
+		}
+		return nil
+	}
+	flush = func() error {
+		if done, ok := g.flush(); ok {
+			return fn(done)
+		}
+		return nil
+	}
+	return add, flush
 }
 
 // FanIn multiplexes multiple readers into a single stream
@@ -23,6 +116,16 @@ func NewFanIn(readers ...Reader) *FanIn {
 	return &FanIn{readers: readers}
 }
 
+// Seekable reports whether every underlying reader is seekable
+func (f *FanIn) Seekable() bool {
+	for _, r := range f.readers {
+		if !r.Seekable() {
+			return false
+		}
+	}
+	return len(f.readers) > 0
+}
+
 // Start starts all readers and multiplexes their output into single channels
 // Cancelling ctx cleanly stops all readers
 func (f *FanIn) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
@@ -16,6 +16,8 @@ type mockReader struct {
 	delay  time.Duration
 }
 
+func (m *mockReader) Seekable() bool { return false }
+
 func (m *mockReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
 	eventCh := make(chan core.LogEvent, len(m.events)+1) // extra buffer
 	errCh := make(chan error, len(m.errors)+1)           // extra buffer
@@ -13,8 +13,11 @@ import (
 
 // StdinReader reads from standard input using bufio.Reader to handle arbitrarily long lines
 type StdinReader struct {
-	reader io.Reader
-	seq    uint64
+	reader   io.Reader
+	seq      uint64
+	keepCR   bool
+	detector core.SeverityDetector
+	group    *traceGrouper // set when grouping stack traces
 }
 
 // NewStdinReader creates a new STDIN reader
@@ -31,6 +34,31 @@ func NewStdinReaderFromReader(reader io.Reader) *StdinReader {
 	}
 }
 
+// SetKeepCR keeps the \r of CRLF line endings instead of stripping it.
+func (s *StdinReader) SetKeepCR(keep bool) {
+	s.keepCR = keep
+}
+
+// SetGroupStackTraces joins stack trace continuation lines onto the line
+// before them, making each trace one multi-line event.
+func (s *StdinReader) SetGroupStackTraces(group bool) {
+	s.group = nil
+	if group {
+		s.group = &traceGrouper{}
+	}
+}
+
+// SetDetector detects the level of each line with d; without one, levels
+// are left unknown.
+func (s *StdinReader) SetDetector(d core.SeverityDetector) {
+	s.detector = d
+}
+
+// Seekable implements the Reader interface; a pipe can't be re-read
+func (s *StdinReader) Seekable() bool {
+	return false
+}
+
 // Start implements the Reader interface
 // Uses bufio.Reader.ReadBytes to handle arbitrarily long lines without Scanner's 64KB limit
 func (s *StdinReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan error) {
@@ -48,51 +76,44 @@ func (s *StdinReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan e
 			case <-ctx.Done():
 				return
 			default:
+				// Nothing buffered means the next read may block: don't hold
+				// back a grouped stack trace meanwhile
+				if s.group != nil && bufReader.Buffered() == 0 && !s.flushGroup(ctx, eventCh) {
+					return
+				}
 				// ReadBytes handles arbitrarily long lines without the default Scanner limit
 				lineBytes, err := bufReader.ReadBytes('\n')
 				if err != nil {
 					if err == io.EOF {
 						// Process any remaining data before exiting
 						if len(lineBytes) > 0 {
-							line := string(lineBytes)
-							// Don't trim trailing newline since EOF doesn't guarantee one
+							line := TrimLineEnd(string(lineBytes), s.keepCR)
 							line = core.SanitizeLine(line)
-							event := s.createLogEvent(line)
-							select {
-							case eventCh <- event:
-							case <-ctx.Done():
+							if !s.send(ctx, eventCh, line) {
 								return
 							}
 						}
 						// EOF stops gracefully - exit if stdin mode
+						s.flushGroup(ctx, eventCh)
 						return
 					}
 
 					// Other errors
 					select {
-					case errCh <- err:
+					case errCh <- fatal(err):
 					case <-ctx.Done():
 						return
 					}
 					return
 				}
 
-				// Convert bytes to string and process
-				line := string(lineBytes)
-
-				// Trim trailing \n but keep \r if present
-				if len(line) > 0 && line[len(line)-1] == '\n' {
-					line = line[:len(line)-1]
-				}
+				// Trim the line ending (\r too unless keepCR)
+				line := TrimLineEnd(string(lineBytes), s.keepCR)
 
 				// Sanitize destructive ANSI/control sequences
 				line = core.SanitizeLine(line)
 
-				event := s.createLogEvent(line)
-
-				select {
-				case eventCh <- event:
-				case <-ctx.Done():
+				if !s.send(ctx, eventCh, line) {
 					return
 				}
 			}
@@ -102,17 +123,56 @@ func (s *StdinReader) Start(ctx context.Context) (<-chan core.LogEvent, <-chan e
 	return eventCh, errCh
 }
 
+// send emits line as an event, through the stack trace grouper when
+// grouping; it returns false once ctx is done.
+func (s *StdinReader) send(ctx context.Context, eventCh chan<- core.LogEvent, line string) bool {
+	if s.group != nil {
+		var ok bool
+		if line, ok = s.group.add(line); !ok {
+			return true
+		}
+	}
+	select {
+	case eventCh <- s.createLogEvent(line):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// flushGroup emits the stack trace being grouped, if any; it returns false
+// once ctx is done.
+func (s *StdinReader) flushGroup(ctx context.Context, eventCh chan<- core.LogEvent) bool {
+	if s.group == nil {
+		return true
+	}
+	line, ok := s.group.flush()
+	if !ok {
+		return true
+	}
+	select {
+	case eventCh <- s.createLogEvent(line):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // createLogEvent creates a LogEvent from a line of input
 func (s *StdinReader) createLogEvent(line string) core.LogEvent {
 	seq := atomic.AddUint64(&s.seq, 1)
 
-	return core.LogEvent{
+	event := core.LogEvent{
 		Seq:       seq,
 		Time:      time.Now(), // Stamp Time: time.Now() if no timestamp parsing
 		Source:    core.SourceStdin,
 		Container: "", // empty for stdin
 		Line:      line,
-		LevelStr:  "", // TODO: Add severity detection in future
+		LevelStr:  "",
 		Level:     core.SevUnknown,
 	}
+	if s.detector != nil {
+		event.LevelStr, event.Level, _ = s.detector.Detect(line)
+	}
+	return event
 }
@@ -184,6 +184,7 @@ func TestStdinReader_NewlineHandling(t *testing.T) {
 	testCases := []struct {
 		name     string
 		input    string
+		keepCR   bool
 		expected []string
 	}{
 		{
@@ -194,12 +195,23 @@ func TestStdinReader_NewlineHandling(t *testing.T) {
 		{
 			name:     "windows newlines",
 			input:    "line1\r\nline2\r\nline3\r\n",
+			expected: []string{"line1", "line2", "line3"},
+		},
+		{
+			name:     "windows newlines with keep-cr",
+			input:    "line1\r\nline2\r\nline3\r\n",
+			keepCR:   true,
 			expected: []string{"line1\r", "line2\r", "line3\r"},
 		},
 		{
 			name:     "mixed newlines",
 			input:    "line1\nline2\r\nline3\n",
-			expected: []string{"line1", "line2\r", "line3"},
+			expected: []string{"line1", "line2", "line3"},
+		},
+		{
+			name:     "crlf without trailing newline",
+			input:    "line1\r\nline2\r",
+			expected: []string{"line1", "line2"},
 		},
 		{
 			name:     "no trailing newline",
@@ -226,6 +238,7 @@ func TestStdinReader_NewlineHandling(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			reader := NewStdinReaderFromReader(strings.NewReader(tc.input))
+			reader.SetKeepCR(tc.keepCR)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 			defer cancel()
@@ -402,3 +415,46 @@ func TestStdinReader_SequenceNumbers(t *testing.T) {
 		}
 	}
 }
+
+func TestStdinReader_DetectsSeverity(t *testing.T) {
+	reader := NewStdinReaderFromReader(strings.NewReader("[ERROR] boom\n{\"level\":\"warn\",\"msg\":\"slow\"}\nplain\n"))
+	reader.SetDetector(core.NewDefaultSeverityDetector(core.NewLevelMap()))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	eventCh, _ := reader.Start(ctx)
+
+	var levels []core.Severity
+	for e := range eventCh {
+		levels = append(levels, e.Level)
+	}
+	want := []core.Severity{core.SevError, core.SevWarn, core.SevUnknown}
+	if len(levels) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(levels))
+	}
+	for i := range want {
+		if levels[i] != want[i] {
+			t.Errorf("Event %d: expected level %v, got %v", i, want[i], levels[i])
+		}
+	}
+}
+
+func TestStdinReader_GroupsStackTraces(t *testing.T) {
+	trace := "java.lang.NullPointerException\n" +
+		"    at com.example.Handler.handle(Handler.java:12)\n" +
+		"Caused by: java.lang.IllegalArgumentException\n" +
+		"    at com.example.Parser.parse(Parser.java:3)"
+	reader := NewStdinReaderFromReader(strings.NewReader("before\n" + trace + "\n\nafter"))
+	reader.SetGroupStackTraces(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	eventCh, _ := reader.Start(ctx)
+
+	var lines []string
+	for e := range eventCh {
+		lines = append(lines, e.Line)
+	}
+	want := []string{"before", trace, "", "after"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, lines)
+	}
+}
@@ -0,0 +1,121 @@
+package input
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// WinEventCommand returns the command that prints a Windows event log (e.g.
+// "System", "Application") oldest first, in the text format WinEventParser
+// understands.
+func WinEventCommand(logName string) []string {
+	return []string{"wevtutil", "qe", logName, "/f:text"}
+}
+
+var winEventStartRe = regexp.MustCompile(`^Event\[\d+\]:?\s*$`)
+
+// WinEventParser parses `wevtutil qe /f:text` output. Each block starts with
+// "Event[N]:", continues with "  Key: value" fields, and ends with a
+// possibly multi-line description. A block becomes one event:
+// "Source (EventID): description", with the Level field mapped to Severity.
+type WinEventParser struct {
+	fields map[string]string
+	desc   []string
+	inDesc bool
+}
+
+// NewWinEventParser creates a parser for wevtutil text output
+func NewWinEventParser() *WinEventParser {
+	return &WinEventParser{}
+}
+
+// Feed implements RecordParser
+func (p *WinEventParser) Feed(line string) (core.LogEvent, bool) {
+	if winEventStartRe.MatchString(strings.TrimSpace(line)) {
+		e, ok := p.Flush()
+		p.fields = make(map[string]string)
+		return e, ok
+	}
+	if p.fields == nil {
+		return core.LogEvent{}, false // preamble before the first block
+	}
+	trimmed := strings.TrimSpace(line)
+	if p.inDesc {
+		if trimmed != "" {
+			p.desc = append(p.desc, trimmed)
+		}
+		return core.LogEvent{}, false
+	}
+	key, value, ok := strings.Cut(trimmed, ":")
+	if !ok {
+		return core.LogEvent{}, false
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if key == "Description" {
+		p.inDesc = true
+		if value != "" {
+			p.desc = append(p.desc, value)
+		}
+		return core.LogEvent{}, false
+	}
+	p.fields[key] = value
+	return core.LogEvent{}, false
+}
+
+// Flush implements RecordParser
+func (p *WinEventParser) Flush() (core.LogEvent, bool) {
+	if p.fields == nil {
+		return core.LogEvent{}, false
+	}
+	fields, desc := p.fields, strings.Join(p.desc, " ")
+	p.fields, p.desc, p.inDesc = nil, nil, false
+
+	line := fields["Source"]
+	if id := fields["Event ID"]; id != "" {
+		line += " (" + id + ")"
+	}
+	if desc != "" {
+		line += ": " + desc
+	}
+	levelStr, level := winEventLevel(fields["Level"])
+	return core.LogEvent{
+		Time:     parseWinEventDate(fields["Date"]),
+		Line:     strings.TrimSpace(line),
+		LevelStr: levelStr,
+		Level:    level,
+	}, true
+}
+
+// winEventLevel maps a Windows event level name to a badge and Severity.
+// Critical keeps its name but toggles with ERROR.
+func winEventLevel(name string) (string, core.Severity) {
+	switch strings.ToLower(name) {
+	case "critical":
+		return "CRITICAL", core.SevError
+	case "error":
+		return "ERROR", core.SevError
+	case "warning":
+		return "WARN", core.SevWarn
+	case "information":
+		return "INFO", core.SevInfo
+	case "verbose":
+		return "DEBUG", core.SevDebug
+	default:
+		return "", core.SevUnknown
+	}
+}
+
+// parseWinEventDate parses wevtutil's timestamp, which is UTC with a "Z" on
+// newer systems and local time without one on older ones.
+func parseWinEventDate(s string) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05.999999999", s, time.Local); err == nil {
+		return t
+	}
+	return time.Time{}
+}
@@ -2,15 +2,30 @@ package persist
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+
+	"github.com/germanoeich/siftail/internal/core"
 )
 
-// Preset represents a named configuration of container visibility settings
+// Preset represents a named configuration of container visibility settings,
+// or, outside Docker mode, a named set of filters and highlights
 type Preset struct {
 	Name    string          `json:"name"`
 	Visible map[string]bool `json:"visible"` // container name -> visible
+
+	// Raw matcher strings of a filter preset
+	Includes   []string `json:"includes,omitempty"`
+	Excludes   []string `json:"excludes,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// IsFilterPreset reports whether the preset holds filters rather than
+// container visibility
+func (p Preset) IsFilterPreset() bool {
+	return p.Visible == nil
 }
 
 // PresetsFile represents the structure of the presets configuration file
@@ -192,3 +207,63 @@ func CreatePresetFromCurrent(name string, currentContainers map[string]bool) Pre
 		Visible: visible,
 	}
 }
+
+// CreateFilterPreset creates a new preset from the current filters and
+// highlights, keeping each matcher's raw text
+func CreateFilterPreset(name string, filters *core.Filters) Preset {
+	raw := func(matchers []core.TextMatcher) []string {
+		var out []string
+		for _, m := range matchers {
+			out = append(out, m.Raw())
+		}
+		return out
+	}
+	return Preset{
+		Name:       name,
+		Includes:   raw(filters.Include),
+		Excludes:   raw(filters.Exclude),
+		Highlights: raw(filters.Highlights),
+	}
+}
+
+// ApplyFilterPreset replaces the filters and highlights with the preset's.
+// Nothing changes when one of its patterns no longer compiles.
+func ApplyFilterPreset(preset Preset, filters *core.Filters) error {
+	build := func(patterns []string, parse func(string) (core.TextMatcher, error)) ([]core.TextMatcher, error) {
+		var out []core.TextMatcher
+		for _, p := range patterns {
+			m, err := parse(p)
+			if err != nil {
+				return nil, fmt.Errorf("pattern %q: %w", p, err)
+			}
+			out = append(out, m)
+		}
+		return out, nil
+	}
+	includes, err := build(preset.Includes, core.NewIncludeMatcher) // keeps "+pattern" required
+	if err != nil {
+		return err
+	}
+	excludes, err := build(preset.Excludes, core.NewMatcher)
+	if err != nil {
+		return err
+	}
+	highlights, err := build(preset.Highlights, core.NewMatcher)
+	if err != nil {
+		return err
+	}
+
+	filters.ClearIncludes()
+	filters.ClearExcludes()
+	filters.ClearHighlights()
+	for _, m := range includes {
+		filters.AddInclude(m)
+	}
+	for _, m := range excludes {
+		filters.AddExclude(m)
+	}
+	for _, m := range highlights {
+		filters.AddHighlight(m)
+	}
+	return nil
+}
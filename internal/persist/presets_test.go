@@ -3,8 +3,11 @@ package persist
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"testing"
+
+	"github.com/germanoeich/siftail/internal/core"
 )
 
 func TestPresets_SaveAndLoad(t *testing.T) {
@@ -418,3 +421,62 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFilterPreset_CreateAndApply(t *testing.T) {
+	filters := core.NewFilters()
+	for _, p := range []string{"+error", "/timeout \\d+/"} {
+		m, _ := core.NewIncludeMatcher(p)
+		filters.AddInclude(m)
+	}
+	healthz, _ := core.NewMatcher("healthz")
+	filters.AddExclude(healthz)
+	hl, _ := core.NewMatcher("panic")
+	filters.AddHighlight(hl)
+
+	preset := CreateFilterPreset("errors", filters)
+	if !preset.IsFilterPreset() || !reflect.DeepEqual(preset.Includes, []string{"+error", "/timeout \\d+/"}) ||
+		!reflect.DeepEqual(preset.Excludes, []string{"healthz"}) || !reflect.DeepEqual(preset.Highlights, []string{"panic"}) {
+		t.Fatalf("unexpected preset %+v", preset)
+	}
+
+	other := core.NewFilters()
+	stale, _ := core.NewMatcher("stale")
+	other.AddHighlight(stale)
+	if err := ApplyFilterPreset(preset, other); err != nil {
+		t.Fatalf("ApplyFilterPreset: %v", err)
+	}
+	if len(other.Include) != 2 || !other.Include[0].Required() || len(other.Exclude) != 1 || len(other.Highlights) != 1 || other.Highlights[0].Raw() != "panic" {
+		t.Errorf("filters not replaced: %+v", other)
+	}
+	if other.ShouldShowLine("timeout 5") || !other.ShouldShowLine("error: timeout 5") {
+		t.Error("expected the required include to be rebuilt")
+	}
+
+	bad := Preset{Name: "bad", Includes: []string{"/[/"}}
+	if err := ApplyFilterPreset(bad, other); err == nil || len(other.Include) != 2 {
+		t.Errorf("expected an invalid pattern to leave the filters alone, got %v", err)
+	}
+}
+
+func TestPresets_LoadsOldContainerPresets(t *testing.T) {
+	manager := &PresetsManager{configPath: filepath.Join(t.TempDir(), "presets.json")}
+	old := `{"presets": [{"name": "web", "visible": {"nginx": true, "db": false}}]}`
+	if err := os.WriteFile(manager.configPath, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	presets, err := manager.LoadPresets()
+	if err != nil || len(presets) != 1 {
+		t.Fatalf("LoadPresets = %v, %v", presets, err)
+	}
+	if p := presets[0]; p.IsFilterPreset() || !p.Visible["nginx"] || p.Includes != nil {
+		t.Errorf("unexpected preset %+v", p)
+	}
+
+	if err := manager.SavePreset(Preset{Name: "errors", Includes: []string{"error"}}); err != nil {
+		t.Fatal(err)
+	}
+	presets, _ = manager.LoadPresets()
+	if len(presets) != 2 || !presets[1].IsFilterPreset() || presets[1].Includes[0] != "error" {
+		t.Errorf("filter preset did not round-trip: %+v", presets)
+	}
+}
@@ -11,6 +11,38 @@ import (
 type Settings struct {
 	ShowTimestamps bool   `json:"showTimestamps"`
 	Theme          string `json:"theme"`
+	Links          bool   `json:"links,omitempty"` // emphasize URLs/paths, OSC 8 links
+	// CompactTimestamps shows a timestamp only when the second changes.
+	CompactTimestamps bool `json:"compactTimestamps,omitempty"`
+	// ThemeOverrides replaces individual styles of the base theme, keyed by
+	// style (e.g. "error", "highlight") with a theme name or color as value.
+	ThemeOverrides map[string]string `json:"themeOverrides,omitempty"`
+	// OpsKeywords replaces the keywords highlighted by the ops profile.
+	OpsKeywords []string `json:"opsKeywords,omitempty"`
+	// ContainerAliases maps real container names (or IDs) to display names.
+	ContainerAliases map[string]string `json:"containerAliases,omitempty"`
+	// LevelOverflow is what happens to levels beyond slot 8: "collapse"
+	// (default), "rotate" or "warn". OverflowLabel renames slot 9 (OTHER).
+	LevelOverflow string `json:"levelOverflow,omitempty"`
+	OverflowLabel string `json:"overflowLabel,omitempty"`
+	// LevelSlots reserves slots 5-8 for level names, e.g. {"TRACE": 5}.
+	LevelSlots map[string]int `json:"levelSlots,omitempty"`
+	// SavePromptHistory keeps the recent prompt entries (find, filters, ...)
+	// in PromptHistory across restarts, keyed by prompt.
+	SavePromptHistory bool                `json:"savePromptHistory,omitempty"`
+	PromptHistory     map[string][]string `json:"promptHistory,omitempty"`
+	// PrefixSeparator goes between the prefix columns (timestamp, container,
+	// level) and before the message; default a single space. PrefixWidth
+	// pads the prefix so messages start in the same column.
+	PrefixSeparator string `json:"prefixSeparator,omitempty"`
+	PrefixWidth     int    `json:"prefixWidth,omitempty"`
+	// DecodeCopies pretty-prints JSON and decodes URL-encoded or base64
+	// text in single-line mouse selections before copying them.
+	DecodeCopies bool `json:"decodeCopies,omitempty"`
+	// SeverityDisplay is "bar" or "none"; empty shows the level badge
+	SeverityDisplay string `json:"severityDisplay,omitempty"`
+	// PinnedContainers sort to the top of the Docker container list
+	PinnedContainers []string `json:"pinnedContainers,omitempty"`
 }
 
 // SettingsManager handles persistence of settings.
@@ -3,6 +3,7 @@ package persist
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -34,7 +35,7 @@ func TestSettingsRoundTrip(t *testing.T) {
 		t.Fatalf("unexpected defaults: %+v", s)
 	}
 
-	want := Settings{ShowTimestamps: false, Theme: "nord"}
+	want := Settings{ShowTimestamps: false, Theme: "nord", ThemeOverrides: map[string]string{"error": "196"}}
 	if err := sm.Save(want); err != nil {
 		t.Fatalf("Save: %v", err)
 	}
@@ -43,7 +44,62 @@ func TestSettingsRoundTrip(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Load(2): %v", err)
 	}
-	if got != want {
+	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("round-trip mismatch: got %+v want %+v", got, want)
 	}
 }
+
+func TestSettings_ThemeSurvivesRestart(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	sm, err := NewSettingsManager()
+	if err != nil {
+		t.Fatalf("NewSettingsManager: %v", err)
+	}
+	s, _ := sm.Load()
+	s.Theme = "dracula"
+	if err := sm.Save(s); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// A new manager, as on the next run, reads the same file
+	next, err := NewSettingsManager()
+	if err != nil {
+		t.Fatalf("NewSettingsManager: %v", err)
+	}
+	got, err := next.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Theme != "dracula" || !got.ShowTimestamps {
+		t.Errorf("expected dracula with defaults kept, got %+v", got)
+	}
+}
+
+func TestSettings_LoadsLevelSlots(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	sm, err := NewSettingsManager()
+	if err != nil {
+		t.Fatalf("NewSettingsManager: %v", err)
+	}
+	if err := os.WriteFile(sm.path, []byte(`{"levelSlots": {"TRACE": 5, "audit": 8}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := sm.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := map[string]int{"TRACE": 5, "audit": 8}; !reflect.DeepEqual(s.LevelSlots, want) {
+		t.Errorf("LevelSlots = %v, want %v", s.LevelSlots, want)
+	}
+
+	if err := os.WriteFile(sm.path, []byte(`{"levelSlots": {"TRACE": "five"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sm.Load(); err == nil {
+		t.Error("expected a parse error for a non-numeric slot")
+	}
+}
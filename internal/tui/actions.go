@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paletteAction is a named action of the command palette. Running it replays
+// its key, so the palette does exactly what the key binding does.
+type paletteAction struct {
+	name string
+	key  tea.KeyMsg
+}
+
+// keyAction is one line of the help overlay: keys and what they do, plus the
+// palette actions that run them. Help and palette both skip unavailable ones.
+type keyAction struct {
+	section string
+	keys    string
+	help    string
+	avail   func(Model) bool // nil: always available
+	palette []paletteAction  // none for keys the palette can't replay usefully
+}
+
+func runeKey(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+func keyOf(t tea.KeyType) tea.KeyMsg { return tea.KeyMsg{Type: t} }
+
+// act is a palette action list of one
+func act(name string, key tea.KeyMsg) []paletteAction {
+	return []paletteAction{{name: name, key: key}}
+}
+
+// levelActions returns an action per slot 1..9, named by format and run by
+// the slot's rune in keys
+func levelActions(format, keys string) []paletteAction {
+	var actions []paletteAction
+	for i, r := range keys {
+		actions = append(actions, paletteAction{name: fmt.Sprintf(format, i+1), key: runeKey(r)})
+	}
+	return actions
+}
+
+func inDocker(m Model) bool { return m.mode == ModeDocker }
+
+func withMouse(m Model) bool { return m.mouseCapture }
+
+// keyActions lists the key bindings of the main view, in help order
+var keyActions = []keyAction{
+	{section: "Navigation", keys: "PgUp/PgDn", help: "scroll by page"},
+	{section: "Navigation", keys: "Home/End", help: "jump to top/bottom", palette: []paletteAction{
+		{name: "Jump to top", key: keyOf(tea.KeyHome)},
+		{name: "Jump to bottom and follow", key: keyOf(tea.KeyEnd)},
+	}},
+	{section: "Navigation", keys: "g", help: "Go to line # (sequence number, as in Window: #a–#b)", palette: act("Go to line #", runeKey('g'))},
+	{section: "Navigation", keys: "g g", help: "Jump to the top (g twice quickly)"},
+	{section: "Navigation", keys: "m", help: "Bookmark the find hit / top line (again: remove)", palette: act("Toggle bookmark", runeKey('m'))},
+	{section: "Navigation", keys: "' / \"", help: "Next/previous bookmark", palette: []paletteAction{
+		{name: "Next bookmark", key: runeKey('\'')},
+		{name: "Previous bookmark", key: runeKey('"')},
+	}},
+	{section: "Navigation", keys: "Wheel", help: "scroll"},
+	{section: "Navigation", keys: "] / [", help: "Next/previous error line", avail: func(m Model) bool { return m.errorNav }, palette: []paletteAction{
+		{name: "Next error line", key: runeKey(']')},
+		{name: "Previous error line", key: runeKey('[')},
+	}},
+
+	{section: "Find/Highlight", keys: "Ctrl+F", help: "Find; Up/Down jump matches", palette: act("Find", keyOf(tea.KeyCtrlF))},
+	{section: "Find/Highlight", keys: "A", help: "Toggle find case sensitivity (Aa/aa)", palette: act("Toggle find case sensitivity", runeKey('A'))},
+	{section: "Find/Highlight", keys: "l", help: "List all find matches; Enter jumps to one", palette: act("List find matches", runeKey('l'))},
+	{section: "Find/Highlight", keys: "G", help: "Find follow: while following, jump to each new match (like tail -f | grep)", palette: act("Toggle find follow", runeKey('G'))},
+	{section: "Find/Highlight", keys: "Ctrl+G", help: "Find in the whole file on disk (incl. evicted lines)", avail: func(m Model) bool { return m.diskPath != "" }, palette: act("Find in the whole file on disk", keyOf(tea.KeyCtrlG))},
+	{section: "Find/Highlight", keys: "h", help: "Highlight (no jump)", palette: act("Highlight", runeKey('h'))},
+	{section: "Find/Highlight", keys: "Esc", help: "Clear active Find"},
+	{section: "Find/Highlight", keys: "Up/Down", help: "In a prompt: recall recent entries"},
+
+	{section: "Filters", keys: "I", help: "Filter In (+pattern: required, !pattern: negated)", palette: act("Filter in", runeKey('I'))},
+	{section: "Filters", keys: "O", help: "Filter Out", palette: act("Filter out", runeKey('O'))},
+	{section: "Filters", keys: "F", help: "Filter In by mouse selection", palette: act("Filter in by mouse selection", runeKey('F'))},
+	{section: "Filters", keys: "c / C", help: "Clear filters (menu / all)", palette: []paletteAction{
+		{name: "Clear filters (menu)", key: runeKey('c')},
+		{name: "Clear all filters", key: runeKey('C')},
+	}},
+	{section: "Filters", keys: "i", help: "List filters with per-filter match counts", palette: act("List filters with match counts", runeKey('i'))},
+	{section: "Filters", keys: "T", help: "Top messages: visible lines grouped by template (ids as <n>)", palette: act("Top messages", runeKey('T'))},
+	{section: "Filters", keys: "p", help: "Presets: save/apply named filter and highlight sets", avail: func(m Model) bool { return m.mode != ModeDocker }, palette: act("Presets", runeKey('p'))},
+	{section: "Filters", keys: "P", help: "Peek: show all lines, filters kept (again: restore)", palette: act("Peek: show all lines", runeKey('P'))},
+	{section: "Filters", keys: "{ / }", help: "Hide lines before/after the centered one (again: undo)", palette: []paletteAction{
+		{name: "Hide lines before the centered one", key: runeKey('{')},
+		{name: "Hide lines after the centered one", key: runeKey('}')},
+	}},
+
+	{section: "Severity", keys: "1..9", help: "Toggle buckets", palette: levelActions("Toggle level %d", "123456789")},
+	{section: "Severity", keys: "Shift+1..9", help: "Focus a bucket; press again to enable all", palette: levelActions("Focus level %d", "!@#$%^&*(")},
+	{section: "Severity", keys: "0", help: "Enable all", palette: act("Enable all levels", runeKey('0'))},
+	{section: "Severity", keys: "K", help: "Jump to the first line seen at a level (slot or name)", palette: act("Jump to the first line of a level", runeKey('K'))},
+	{section: "Severity", keys: "o", help: "Ops view: hide DEBUG/TRACE, highlight panic/exception/fatal", palette: act("Ops view", runeKey('o'))},
+
+	{section: "Docker", keys: "Ctrl+D", help: "Containers list (p: pin to top, s: log-rate sparklines)", avail: inDocker, palette: act("Containers list", keyOf(tea.KeyCtrlD))},
+	{section: "Docker", keys: "p", help: "Presets of container visibility", avail: inDocker, palette: act("Presets", runeKey('p'))},
+	{section: "Docker", keys: "Tab", help: "Show only the next visible container (Shift+Tab: previous; Esc: restore)", avail: inDocker, palette: []paletteAction{
+		{name: "Focus next visible container", key: keyOf(tea.KeyTab)},
+		{name: "Focus previous visible container", key: keyOf(tea.KeyShiftTab)},
+	}},
+
+	{section: "Misc", keys: ": / Ctrl+P", help: "Command palette: run any action by name"},
+	{section: "Misc", keys: "? / F1", help: "Help: this list", palette: act("Help", runeKey('?'))},
+	{section: "Misc", keys: "Ctrl+O", help: "Settings (timestamps on/compact/off, theme, links, decode copies, severity badge/bar/none)", palette: act("Settings", keyOf(tea.KeyCtrlO))},
+	{section: "Misc", keys: "t", help: "Cycle theme", palette: act("Cycle theme", runeKey('t'))},
+	{section: "Misc", keys: "Ctrl+T", help: "Theme picker: preview a sample line in each theme", palette: act("Choose theme", keyOf(tea.KeyCtrlT))},
+	{section: "Misc", keys: "d", help: "Toggle timestamps", palette: act("Toggle timestamps", runeKey('d'))},
+	{section: "Misc", keys: "D", help: "Duplicate session with current filters (tmux split, or copy command)", palette: act("Duplicate session", runeKey('D'))},
+	{section: "Misc", keys: "Space", help: "Pause: freeze the view while lines keep buffering", palette: act("Pause", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})},
+	{section: "Misc", keys: "w", help: "Toggle wrap; unwrapped, Left/Right scroll the message", palette: act("Toggle wrap", runeKey('w'))},
+	{section: "Misc", keys: "n", help: "Toggle the [container] / [file] name column", palette: act("Toggle name column", runeKey('n'))},
+	{section: "Misc", keys: "j", help: "Expand/collapse the centered JSON line (indented)", palette: act("Expand/collapse JSON line", runeKey('j'))},
+	{section: "Misc", keys: "V", help: "Toggle caret notation for control characters (^A, \\xNN)", palette: act("Toggle control characters", runeKey('V'))},
+	{section: "Misc", keys: "Click", help: "Unwrapped: show a clipped line in full", avail: withMouse},
+	{section: "Misc", keys: "y", help: "Copy the line of the current find match", palette: act("Copy find match line", runeKey('y'))},
+	{section: "Misc", keys: "Y", help: "Copy find pattern", palette: act("Copy find pattern", runeKey('Y'))},
+	{section: "Misc", keys: "Ctrl+Y", help: "Copy filter expression", palette: act("Copy filter expression", keyOf(tea.KeyCtrlY))},
+	{section: "Misc", keys: "M", help: "Copy visible rows as Markdown", palette: act("Copy visible rows as Markdown", runeKey('M'))},
+	{section: "Misc", keys: "e", help: "Export all lines passing the filters to a file", palette: act("Export lines to a file", runeKey('e'))},
+	{section: "Misc", keys: "J", help: "Copy the centered event as JSON", palette: act("Copy event as JSON", runeKey('J'))},
+	{section: "Misc", keys: "f", help: "Copy file:line of the centered line", avail: func(m Model) bool { return m.mode == ModeFile }, palette: act("Copy file:line", runeKey('f'))},
+	{section: "Misc", keys: "L", help: "Copy level legend (slot, name, enabled)", palette: act("Copy level legend", runeKey('L'))},
+	{section: "Misc", keys: "Ctrl+R", help: "Reload from start (stdin/docker: clear)", palette: act("Reload from start", keyOf(tea.KeyCtrlR))},
+	{section: "Misc", keys: "R", help: "Replay from oldest line", palette: act("Replay from oldest line", runeKey('R'))},
+	{section: "Misc", keys: "B", help: "Resize the line buffer (keeps the newest lines)", palette: act("Resize the line buffer", runeKey('B'))},
+	{section: "Misc", keys: "Mouse drag", help: "Select and copy", avail: withMouse},
+	{section: "Misc", keys: "Ctrl+S", help: "Selection mode (native terminal select)", palette: act("Selection mode", keyOf(tea.KeyCtrlS))},
+	{section: "Misc", keys: "^Q", help: "Quit", palette: act("Quit", keyOf(tea.KeyCtrlQ))},
+}
+
+// available reports whether a is available in m
+func (a keyAction) available(m Model) bool {
+	return a.avail == nil || a.avail(m)
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// alertMinInterval is the minimum gap between webhook posts; matches in
+// between are counted and reported with the next post.
+const alertMinInterval = time.Second
+
+// alertPayload is the JSON body posted to the alert webhook
+type alertPayload struct {
+	Pattern    string    `json:"pattern"`
+	Line       string    `json:"line"`
+	Level      string    `json:"level,omitempty"`
+	Container  string    `json:"container,omitempty"`
+	Time       time.Time `json:"time"`
+	Suppressed int       `json:"suppressed"` // matches dropped by rate limiting since the last post
+}
+
+// alertWebhook posts alert matches to a URL, rate limited. It is shared
+// between model copies, hence the lock.
+type alertWebhook struct {
+	url    string
+	client *http.Client
+
+	mu         sync.Mutex
+	last       time.Time
+	suppressed int
+}
+
+// alertResultMsg reports a failed webhook post
+type alertResultMsg struct {
+	err error
+}
+
+func newAlertWebhook(url string) *alertWebhook {
+	return &alertWebhook{url: url, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// post returns a command that sends the payload, or nil when rate limited
+func (w *alertWebhook) post(p alertPayload, now time.Time) tea.Cmd {
+	w.mu.Lock()
+	if !w.last.IsZero() && now.Sub(w.last) < alertMinInterval {
+		w.suppressed++
+		w.mu.Unlock()
+		return nil
+	}
+	w.last = now
+	p.Suppressed, w.suppressed = w.suppressed, 0
+	w.mu.Unlock()
+
+	return func() tea.Msg {
+		body, err := json.Marshal(p)
+		if err != nil {
+			return alertResultMsg{err: err}
+		}
+		resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return alertResultMsg{err: err}
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return alertResultMsg{err: fmt.Errorf("webhook returned %s", resp.Status)}
+		}
+		return nil
+	}
+}
+
+// SetAlerts sets the patterns whose matches are posted to webhookURL.
+func (m *Model) SetAlerts(patterns []string, webhookURL string) error {
+	m.alerts = nil
+	for _, p := range patterns {
+		matcher, err := core.NewMatcher(p)
+		if err != nil {
+			return fmt.Errorf("invalid alert pattern %q: %w", p, err)
+		}
+		m.alerts = append(m.alerts, matcher)
+	}
+	m.webhook = nil
+	if webhookURL != "" {
+		m.webhook = newAlertWebhook(webhookURL)
+	}
+	return nil
+}
+
+// checkAlerts posts an event matching any alert pattern to the webhook
+func (m Model) checkAlerts(e core.LogEvent) tea.Cmd {
+	if m.webhook == nil {
+		return nil
+	}
+	for _, a := range m.alerts {
+		if a.Match(e.Line) {
+			return m.webhook.post(alertPayload{
+				Pattern:   a.Raw(),
+				Line:      e.Line,
+				Level:     e.LevelStr,
+				Container: e.Container,
+				Time:      e.Time,
+			}, time.Now())
+		}
+	}
+	return nil
+}
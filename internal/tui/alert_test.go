@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestAlertWebhook_PostsMatchingLine(t *testing.T) {
+	bodies := make(chan []byte, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		data, _ := io.ReadAll(r.Body)
+		bodies <- data
+	}))
+	defer srv.Close()
+
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	if err := m.SetAlerts([]string{"/pan+ic/"}, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	event := core.LogEvent{Line: "panic: boom", LevelStr: "ERROR", Container: "api", Time: ts}
+	if cmd := m.checkAlerts(core.LogEvent{Line: "all good"}); cmd != nil {
+		t.Fatal("non-matching line should not post")
+	}
+	cmd := m.checkAlerts(event)
+	if cmd == nil {
+		t.Fatal("matching line should post")
+	}
+	if msg := cmd(); msg != nil {
+		t.Fatalf("post failed: %v", msg)
+	}
+
+	var got alertPayload
+	if err := json.Unmarshal(<-bodies, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := alertPayload{Pattern: "/pan+ic/", Line: "panic: boom", Level: "ERROR", Container: "api", Time: ts}
+	if got != want {
+		t.Errorf("payload = %+v, want %+v", got, want)
+	}
+
+	// Within the rate limit: dropped and counted on the next post
+	if m.checkAlerts(event) != nil {
+		t.Error("second match within a second should be rate limited")
+	}
+	m.webhook.last = time.Now().Add(-2 * alertMinInterval)
+	if msg := m.checkAlerts(event)(); msg != nil {
+		t.Fatalf("post failed: %v", msg)
+	}
+	if err := json.Unmarshal(<-bodies, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Suppressed != 1 {
+		t.Errorf("suppressed = %d, want 1", got.Suppressed)
+	}
+}
+
+func TestAlertWebhook_FailureSurfacesInStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	if err := m.SetAlerts([]string{"boom"}, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	msg := m.checkAlerts(core.LogEvent{Line: "boom"})()
+	if msg == nil {
+		t.Fatal("expected a failure message")
+	}
+	updated, _ := m.Update(msg)
+	if got := updated.(Model).errMsg; got != "Alert webhook failed: webhook returned 500 Internal Server Error" {
+		t.Errorf("status = %q", got)
+	}
+}
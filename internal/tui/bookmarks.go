@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// bookmarkMark flags a bookmarked event's first row in the gutter
+const bookmarkMark = "◆"
+
+// bookmarkTarget is the event m bookmarks: the current find hit, else the
+// top visible line
+func (m Model) bookmarkTarget() (core.LogEvent, bool) {
+	if m.search.IsActive() {
+		if e, ok := m.ring.GetBySeq(m.search.Current()); ok {
+			return e, true
+		}
+	}
+	if len(m.contentLines) == 0 {
+		return core.LogEvent{}, false
+	}
+	e, ok := m.eventAtRow(m.vp.YOffset)
+	if !ok {
+		return core.LogEvent{}, false
+	}
+	return m.ring.GetBySeq(e.Seq)
+}
+
+// isBookmarked reports whether seq is bookmarked
+func (m Model) isBookmarked(seq uint64) bool {
+	_, ok := slices.BinarySearch(m.bookmarks, seq)
+	return ok
+}
+
+// toggleBookmark adds or removes a bookmark on the bookmark target
+func (m Model) toggleBookmark() Model {
+	e, ok := m.bookmarkTarget()
+	if !ok {
+		return m.setError("No line to bookmark")
+	}
+	i, found := slices.BinarySearch(m.bookmarks, e.Seq)
+	marks := slices.Clone(m.bookmarks)
+	if found {
+		m.bookmarks = slices.Delete(marks, i, i+1)
+	} else {
+		m.bookmarks = slices.Insert(marks, i, e.Seq)
+		m.bookmarkAt = e.Seq
+	}
+	if m.renderCache != nil {
+		delete(m.renderCache.rows, e.Seq)
+	}
+	m.dirty = true
+	if found {
+		return m.setError(fmt.Sprintf("Bookmark #%d removed", e.Seq))
+	}
+	return m.setError(fmt.Sprintf("Bookmarked #%d (%d total)", e.Seq, len(m.bookmarks)))
+}
+
+// cycleBookmark scrolls to the bookmark after (or before) the last one
+// visited, wrapping around
+func (m Model) cycleBookmark(forward bool) Model {
+	if len(m.bookmarks) == 0 {
+		return m.setError("No bookmarks (m adds one)")
+	}
+	i, found := slices.BinarySearch(m.bookmarks, m.bookmarkAt)
+	switch {
+	case forward && found:
+		i++
+	case !forward:
+		i--
+	}
+	seq := m.bookmarks[(i+len(m.bookmarks))%len(m.bookmarks)]
+	m.bookmarkAt = seq
+	if _, ok := m.seqIndex[seq]; !ok {
+		m = m.updateViewportContent()
+	}
+	if _, ok := m.seqIndex[seq]; !ok {
+		return m.setError(fmt.Sprintf("Bookmark #%d is hidden by filters", seq))
+	}
+	m.followTail = false
+	m = m.scrollToSequence(seq)
+	pos := slices.Index(m.bookmarks, seq) + 1
+	return m.setError(fmt.Sprintf("Bookmark %d/%d: #%d", pos, len(m.bookmarks), seq))
+}
+
+// pruneBookmarks drops bookmarks whose events were evicted from the ring
+func (m Model) pruneBookmarks() Model {
+	if len(m.bookmarks) == 0 {
+		return m
+	}
+	oldest := m.ring.OldestSeq()
+	if m.ring.Size() == 0 {
+		oldest = m.ring.CurrentSeq() + 1
+	}
+	i, _ := slices.BinarySearch(m.bookmarks, oldest)
+	if i > 0 {
+		m.bookmarks = m.bookmarks[i:]
+	}
+	return m
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestBookmarks_AddCycleEvict(t *testing.T) {
+	ring := core.NewRing(100)
+	search := core.NewSearchState()
+	m := *NewModel(ring, core.NewFilters(), search, core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 1; i <= 100; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %03d", i)})
+	}
+	m = m.updateViewportContent()
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model).updateViewportContent()
+	}
+	centered := func() uint64 {
+		e, _ := m.centeredEvent()
+		return e.Seq
+	}
+
+	// m marks the top visible line, or the current find hit
+	m.followTail = false
+	m = m.scrollToSequence(40)
+	top, _ := m.eventAtRow(m.vp.YOffset)
+	press("m")
+	matcher, _ := core.NewMatcher("line 077")
+	search.SetMatcher(matcher)
+	search.SetActive(true)
+	search.AddHit(77)
+	search.Next()
+	press("m")
+	search.Clear()
+	search.SetActive(false)
+	m.followTail = false
+	m = m.scrollToSequence(20)
+	top20, _ := m.eventAtRow(m.vp.YOffset)
+	press("m")
+	want := []uint64{top20.Seq, top.Seq, 77}
+	if !reflect.DeepEqual(m.bookmarks, want) {
+		t.Fatalf("bookmarks = %v, want %v", m.bookmarks, want)
+	}
+
+	// Bookmarked rows carry the mark in the gutter
+	row := m.seqIndex[top.Seq]
+	if got := m.contentPlainLines[row]; !strings.HasPrefix(got, bookmarkMark+fmt.Sprintf("line %03d", top.Seq)) {
+		t.Errorf("bookmarked row = %q", got)
+	}
+	if got := m.contentPlainLines[row+1]; !strings.HasPrefix(got, " line") {
+		t.Errorf("plain row = %q, want a blank gutter", got)
+	}
+
+	// ' cycles forward from the last one added, wrapping; " goes back
+	for _, step := range []struct {
+		key  string
+		want uint64
+	}{
+		{"'", top.Seq}, {"'", 77}, {"'", top20.Seq}, {"\"", 77},
+	} {
+		press(step.key)
+		if got := centered(); got != step.want || m.bookmarkAt != step.want {
+			t.Errorf("%s: centered #%d (at #%d), want #%d", step.key, got, m.bookmarkAt, step.want)
+		}
+	}
+
+	// m on a bookmarked line removes it
+	m = m.scrollToSequence(40)
+	press("m")
+	if m.isBookmarked(top.Seq) || len(m.bookmarks) != 2 {
+		t.Errorf("bookmarks after removing #%d = %v", top.Seq, m.bookmarks)
+	}
+
+	// Evicted sequences are dropped
+	for i := 0; i < 50; i++ {
+		ring.Append(core.LogEvent{Line: "later"})
+	}
+	m = m.updateViewportContent()
+	if !reflect.DeepEqual(m.bookmarks, []uint64{77}) {
+		t.Errorf("bookmarks after eviction = %v, want [77]", m.bookmarks)
+	}
+	for i := 0; i < 50; i++ {
+		ring.Append(core.LogEvent{Line: "later"})
+	}
+	m = m.updateViewportContent()
+	if len(m.bookmarks) != 0 || strings.HasPrefix(m.contentPlainLines[0], " ") {
+		t.Errorf("bookmarks = %v, row %q; want none and no gutter", m.bookmarks, m.contentPlainLines[0])
+	}
+	press("'")
+	if m.errMsg != "No bookmarks (m adds one)" {
+		t.Errorf("status = %q", m.errMsg)
+	}
+}
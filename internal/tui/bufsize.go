@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Buffer sizes accepted at the B prompt, the same bounds as --buffer-size
+const (
+	minBufferSize = 100
+	maxBufferSize = 1000000
+)
+
+// parseBufferSize reads the capacity typed at the buffer size prompt;
+// thousands separators are allowed (50,000 or 50_000).
+func parseBufferSize(text string) (int, error) {
+	n, err := strconv.Atoi(strings.NewReplacer(",", "", "_", "").Replace(strings.TrimSpace(text)))
+	if err != nil {
+		return 0, fmt.Errorf("not a number: %s", text)
+	}
+	if n < minBufferSize || n > maxBufferSize {
+		return 0, fmt.Errorf("buffer size must be between %d and %d", minBufferSize, maxBufferSize)
+	}
+	return n, nil
+}
+
+// resizeBuffer changes the ring capacity, keeping the newest lines; the view
+// stays on the centered line unless it was dropped.
+func (m Model) resizeBuffer(capacity int) Model {
+	before := m.ring.Size()
+	e, centered := m.centeredEvent()
+	m.ring.Resize(capacity)
+	m.dirty = true
+	m = m.updateViewportContent()
+	if centered && !m.followTail {
+		if _, ok := m.ring.GetBySeq(e.Seq); ok {
+			m = m.scrollToSequence(e.Seq)
+		}
+	}
+	if dropped := before - m.ring.Size(); dropped > 0 {
+		return m.setError(fmt.Sprintf("Buffer: %d lines (dropped the oldest %d)", capacity, dropped))
+	}
+	return m.setError(fmt.Sprintf("Buffer: %d lines", capacity))
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestBufferSizePrompt_ResizesRing(t *testing.T) {
+	ring := core.NewRing(500)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 1; i <= 400; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line-%03d", i)})
+	}
+	m = m.updateViewportContent()
+
+	resize := func(text string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+		m = updated.(Model)
+		if !m.inPrompt || m.promptKind != PromptBufferSize {
+			t.Fatal("B did not open the buffer size prompt")
+		}
+		m.input.SetValue(text)
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+	}
+
+	resize("50")
+	if !m.inPrompt || !strings.Contains(m.promptErr, "between") || ring.Capacity() != 500 {
+		t.Fatalf("expected an out-of-range size to be rejected in place, got %q", m.promptErr)
+	}
+	m = m.cancelPrompt()
+
+	resize("1,000")
+	if ring.Capacity() != 1000 || ring.Size() != 400 || m.errMsg != "Buffer: 1000 lines" {
+		t.Errorf("grow: capacity %d size %d status %q", ring.Capacity(), ring.Size(), m.errMsg)
+	}
+
+	resize("150")
+	if ring.Capacity() != 150 || ring.Size() != 150 || !strings.Contains(m.errMsg, "dropped the oldest 250") {
+		t.Errorf("shrink: capacity %d size %d status %q", ring.Capacity(), ring.Size(), m.errMsg)
+	}
+	if len(m.contentPlainLines) != 150 || m.contentPlainLines[0] != "line-251" {
+		t.Errorf("view not rebuilt after the shrink: %d rows, first %q", len(m.contentPlainLines), m.contentPlainLines[0])
+	}
+}
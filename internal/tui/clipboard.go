@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
 	"github.com/muesli/termenv"
 )
 
@@ -16,26 +18,51 @@ type clipboardResultMsg struct {
 	message string
 }
 
-// copySelectionCmd copies text to both OSC52 and the system clipboard (if available).
+var errClipboardUnsupported = errors.New("clipboard unsupported")
 
+// writeClipboard copies text to both OSC52 and the system clipboard (if
+// available). It is a variable so tests can capture copies.
+var writeClipboard = func(text string) error {
+	termenv.Copy(text)
+	if clipboard.Unsupported {
+		return errClipboardUnsupported
+	}
+	return clipboard.WriteAll(text)
+}
+
+// copySelectionCmd copies a mouse selection to the clipboard.
 func copySelectionCmd(text string) tea.Cmd {
+	return copyTextCmd(text, "Copied selection to clipboard")
+}
+
+// copyTextCmd copies text to the clipboard and reports okMsg on success.
+func copyTextCmd(text, okMsg string) tea.Cmd {
 	if strings.TrimSpace(text) == "" {
 		return nil
 	}
 
 	return func() tea.Msg {
-		termenv.Copy(text)
-
-		if clipboard.Unsupported {
-			return clipboardResultMsg{message: clipboardUnsupportedHint(detectClipboardEnv())}
-		}
-
-		if err := clipboard.WriteAll(text); err != nil {
+		if err := writeClipboard(text); err != nil {
+			if errors.Is(err, errClipboardUnsupported) {
+				return clipboardResultMsg{message: clipboardUnsupportedHint(detectClipboardEnv())}
+			}
 			return clipboardResultMsg{message: fmt.Sprintf("Copy failed: %v", err)}
 		}
+		return clipboardResultMsg{message: okMsg}
+	}
+}
 
-		return clipboardResultMsg{message: "Copied selection to clipboard"}
+// filterExpression renders the active include/exclude filters one per line
+// using their raw patterns, e.g. "include: /timeout \d+/".
+func filterExpression(f *core.Filters) string {
+	var lines []string
+	for _, m := range f.Include {
+		lines = append(lines, "include: "+m.Raw())
+	}
+	for _, m := range f.Exclude {
+		lines = append(lines, "exclude: "+m.Raw())
 	}
+	return strings.Join(lines, "\n")
 }
 
 // clipboardEnv captures environment details that influence clipboard hints.
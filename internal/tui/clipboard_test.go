@@ -3,8 +3,105 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
 )
 
+// captureClipboard replaces the clipboard writer for the duration of a test.
+func captureClipboard(t *testing.T) *string {
+	t.Helper()
+	var copied string
+	orig := writeClipboard
+	writeClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	t.Cleanup(func() { writeClipboard = orig })
+	return &copied
+}
+
+func TestCopyFindPattern(t *testing.T) {
+	copied := captureClipboard(t)
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+
+	raw := `/timeout after \d+ms/`
+	matcher, err := core.NewMatcher(raw)
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+	m.search.SetMatcher(matcher)
+	m.search.SetActive(true)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	msg := cmd()
+	if *copied != m.search.GetMatcher().Raw() {
+		t.Errorf("expected %q copied, got %q", m.search.GetMatcher().Raw(), *copied)
+	}
+	updated, _ = m.Update(msg)
+	if got := updated.(Model).errMsg; got != "Pattern copied" {
+		t.Errorf("expected status %q, got %q", "Pattern copied", got)
+	}
+}
+
+func TestCopyFindMatchLine(t *testing.T) {
+	copied := captureClipboard(t)
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	ring.Append(core.LogEvent{Line: "GET /health 200"})
+	ring.Append(core.LogEvent{Line: "GET /api 504 upstream timeout"})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd != nil {
+		t.Fatal("expected no copy without an active find")
+	}
+
+	matcher, _ := core.NewMatcher("timeout")
+	m.search.SetMatcher(matcher)
+	m.search.SetActive(true)
+	m.search.AddHit(2)
+	if m.search.JumpToFirst() != 2 {
+		t.Fatal("expected the second line to be the current hit")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	updated, _ = m.Update(cmd())
+	if *copied != "GET /api 504 upstream timeout" {
+		t.Errorf("copied %q", *copied)
+	}
+	if got := updated.(Model).errMsg; got != "Copied line" {
+		t.Errorf("expected status %q, got %q", "Copied line", got)
+	}
+}
+
+func TestCopyFilterExpression(t *testing.T) {
+	copied := captureClipboard(t)
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+
+	in, _ := core.NewMatcher("/err(or)?/")
+	out, _ := core.NewMatcher("healthcheck")
+	m.filters.AddInclude(in)
+	m.filters.AddExclude(out)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	cmd()
+	if want := "include: /err(or)?/\nexclude: healthcheck"; *copied != want {
+		t.Errorf("expected %q copied, got %q", want, *copied)
+	}
+}
+
 func TestCopySelectionCmdReturnsNilForEmptyText(t *testing.T) {
 	if cmd := copySelectionCmd(" \n\t"); cmd != nil {
 		t.Fatalf("expected nil command for whitespace selection")
@@ -48,3 +145,43 @@ func TestClipboardUnsupportedHintVariants(t *testing.T) {
 		})
 	}
 }
+
+func TestCopyEventJSON(t *testing.T) {
+	copied := captureClipboard(t)
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 5})
+	m = nm.(Model)
+
+	if _, cmd := m.copyEventJSON(); cmd != nil {
+		t.Fatal("expected no copy with an empty buffer")
+	}
+
+	ring.Append(core.LogEvent{Line: "before", Time: time.Unix(0, 0).UTC()})
+	ring.Append(core.LogEvent{
+		Time:      time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC),
+		Source:    core.SourceDocker,
+		Container: "api",
+		Line:      `db "timeout"`,
+		LevelStr:  "ERROR",
+		Level:     core.SevError,
+	})
+	m.dockerUI.Containers[""] = true
+	m.dockerUI.Containers["api"] = true
+	m = m.updateViewportContent()
+	m.vp.GotoTop()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	msg := cmd()
+	want := `{"seq":2,"time":"2025-03-04T10:20:30Z","source":"docker","container":"api","level":4,"levelStr":"ERROR","line":"db \"timeout\""}`
+	if *copied != want {
+		t.Errorf("copied\n %s\nwant\n %s", *copied, want)
+	}
+	updated, _ = updated.(Model).Update(msg)
+	if got := updated.(Model).errMsg; got != "Copied event #2 as JSON" {
+		t.Errorf("unexpected status %q", got)
+	}
+}
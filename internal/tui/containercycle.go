@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+)
+
+// containerCycleState remembers the containers that were visible when Tab
+// focus started, so each press can isolate the next one of them.
+type containerCycleState struct {
+	set []string
+	pos int
+}
+
+// focusedContainer returns the container isolated by Tab focus, while it is
+// still the only visible one
+func (m Model) focusedContainer() (string, bool) {
+	c := m.containerCycle
+	if len(c.set) == 0 {
+		return "", false
+	}
+	name := c.set[c.pos]
+	if !m.dockerUI.Containers[name] {
+		return "", false
+	}
+	for other, visible := range m.dockerUI.Containers {
+		if visible && other != name {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// cycleContainerFocus shows only the next (delta 1) or previous (delta -1)
+// of the containers visible when cycling started, wrapping around. Changing
+// visibility in between starts over from the containers then visible.
+func (m Model) cycleContainerFocus(delta int) Model {
+	c := &m.containerCycle
+	if _, ok := m.focusedContainer(); !ok {
+		var set []string
+		for _, name := range m.sortedContainers() {
+			if m.dockerUI.Containers[name] {
+				set = append(set, name)
+			}
+		}
+		if len(set) == 0 {
+			return m.setError("No visible containers to cycle through")
+		}
+		*c = containerCycleState{set: set, pos: len(set) - 1}
+		if delta < 0 {
+			c.pos = 0
+		}
+	}
+	// Skip containers that went away since cycling started
+	for range c.set {
+		c.pos = (c.pos + delta + len(c.set)) % len(c.set)
+		if _, ok := m.dockerUI.Containers[c.set[c.pos]]; ok {
+			break
+		}
+	}
+	for name := range m.dockerUI.Containers {
+		m.dockerUI.Containers[name] = name == c.set[c.pos]
+	}
+	m.dirty = true
+	return m
+}
+
+// endContainerFocus shows the containers visible before cycling started
+func (m Model) endContainerFocus() (Model, bool) {
+	if _, ok := m.focusedContainer(); !ok {
+		return m, false
+	}
+	for name := range m.dockerUI.Containers {
+		m.dockerUI.Containers[name] = slices.Contains(m.containerCycle.set, name)
+	}
+	m.containerCycle = containerCycleState{}
+	m.dirty = true
+	return m, true
+}
+
+// containerFocusStatus describes the focused container and its position in
+// the cycled set, e.g. "Focus: api 3/5"
+func (m Model) containerFocusStatus() (string, bool) {
+	name, ok := m.focusedContainer()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("Focus: %s %d/%d", m.containerLabel(name), m.containerCycle.pos+1, len(m.containerCycle.set)), true
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestContainerFocus_CyclesVisibleSubset(t *testing.T) {
+	m := *NewModel(core.NewRing(100), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 20})
+	m = nm.(Model)
+	m.dockerUI.Containers = map[string]bool{"api": true, "cache": false, "db": true, "queue": false, "web": true}
+
+	press := func(key tea.KeyType) {
+		t.Helper()
+		nm, _ := m.Update(tea.KeyMsg{Type: key})
+		m = nm.(Model)
+	}
+	visible := func() []string {
+		var names []string
+		for _, name := range m.sortedContainers() {
+			if m.dockerUI.Containers[name] {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	for i, want := range []struct {
+		key    tea.KeyType
+		name   string
+		status string
+	}{
+		{tea.KeyTab, "api", "Focus: api 1/3"},
+		{tea.KeyTab, "db", "Focus: db 2/3"},
+		{tea.KeyTab, "web", "Focus: web 3/3"},
+		{tea.KeyTab, "api", "Focus: api 1/3"}, // wraps around
+		{tea.KeyShiftTab, "web", "Focus: web 3/3"},
+	} {
+		press(want.key)
+		if got := visible(); !reflect.DeepEqual(got, []string{want.name}) {
+			t.Fatalf("press %d: visible %v, want only %s", i, got, want.name)
+		}
+		if status := m.renderStatusLine(); !strings.Contains(status, want.status) {
+			t.Errorf("press %d: status %q lacks %q", i, status, want.status)
+		}
+	}
+
+	// Esc restores the containers visible before cycling
+	press(tea.KeyEsc)
+	if got := visible(); !reflect.DeepEqual(got, []string{"api", "db", "web"}) {
+		t.Errorf("after Esc visible %v, want api, db and web", got)
+	}
+	if strings.Contains(m.renderStatusLine(), "Focus:") {
+		t.Error("expected no focus status after Esc")
+	}
+
+	// Changing visibility meanwhile starts over from the new visible set
+	press(tea.KeyTab)
+	m.dockerUI.Containers["queue"] = true
+	press(tea.KeyTab)
+	if got := visible(); !reflect.DeepEqual(got, []string{"api"}) || !strings.Contains(m.renderStatusLine(), "Focus: api 1/2") {
+		t.Errorf("visible %v (%q), want api of api and queue", got, m.renderStatusLine())
+	}
+}
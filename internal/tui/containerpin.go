@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"slices"
+	"sort"
+)
+
+// sortedContainers lists the container list's entries: pinned containers
+// first, each group alphabetical.
+func (m Model) sortedContainers() []string {
+	containers := make([]string, 0, len(m.dockerUI.Containers))
+	for name := range m.dockerUI.Containers {
+		containers = append(containers, name)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		pi, pj := m.dockerUI.Pinned[containers[i]], m.dockerUI.Pinned[containers[j]]
+		if pi != pj {
+			return pi
+		}
+		return containers[i] < containers[j]
+	})
+	return containers
+}
+
+// togglePinSelected pins or unpins the selected container, keeping the
+// cursor on it as it moves, and remembers the pinned set.
+func (m Model) togglePinSelected() Model {
+	containers := m.sortedContainers()
+	sel := m.dockerUI.SelectedContainer
+	if sel < 0 || sel >= len(containers) {
+		return m
+	}
+	name := containers[sel]
+	if m.dockerUI.Pinned[name] {
+		delete(m.dockerUI.Pinned, name)
+	} else {
+		m.dockerUI.Pinned[name] = true
+	}
+	m.dockerUI.SelectedContainer = slices.Index(m.sortedContainers(), name)
+	m.persistSettings()
+	return m
+}
+
+// pinnedContainers returns the pinned names in a stable order for saving
+func (m Model) pinnedContainers() []string {
+	var names []string
+	for name := range m.dockerUI.Pinned {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	percentEscapeRe = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+	base64Re        = regexp.MustCompile(`^[A-Za-z0-9+/_-]+={0,2}$`)
+)
+
+// minBase64Len keeps short words that happen to be valid base64 as they are
+const minBase64Len = 12
+
+// decodeForCopy recognizes a single-line selection that is JSON, URL-encoded
+// or base64-encoded text and returns its pretty-printed or decoded form with
+// a short name for the transform; how is empty when nothing applies.
+func decodeForCopy(text string) (decoded, how string) {
+	s := strings.TrimSpace(text)
+	if s == "" || strings.Contains(s, "\n") {
+		return "", ""
+	}
+
+	if (strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")) && json.Valid([]byte(s)) {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, []byte(s), "", "  "); err == nil {
+			return buf.String(), "JSON pretty-printed"
+		}
+	}
+
+	if percentEscapeRe.MatchString(s) {
+		if out, err := url.QueryUnescape(s); err == nil && out != s {
+			return out, "URL-decoded"
+		}
+	}
+
+	if len(s) >= minBase64Len && base64Re.MatchString(s) {
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+			if out, err := enc.DecodeString(s); err == nil && isPrintableText(out) {
+				return string(out), "base64-decoded"
+			}
+		}
+	}
+	return "", ""
+}
+
+// isPrintableText reports whether b is UTF-8 text without control bytes
+// other than whitespace
+func isPrintableText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectionCopyCmd copies a mouse selection, decoded when decoding copies is
+// on and raw (Alt held on release) is not set.
+func (m Model) selectionCopyCmd(text string, raw bool) tea.Cmd {
+	if m.decodeCopies && !raw {
+		if decoded, how := decodeForCopy(text); how != "" {
+			return copyTextCmd(decoded, "Copied selection ("+how+")")
+		}
+	}
+	return copySelectionCmd(text)
+}
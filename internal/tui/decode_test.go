@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestDecodeForCopy(t *testing.T) {
+	tests := []struct {
+		name, in, want, how string
+	}{
+		{"json object", ` {"user":"alice","ids":[1,2]} `, "{\n  \"user\": \"alice\",\n  \"ids\": [\n    1,\n    2\n  ]\n}", "JSON pretty-printed"},
+		{"json array", `[true,null]`, "[\n  true,\n  null\n]", "JSON pretty-printed"},
+		{"url encoded", "q=hello%20world%26more&next=%2Fhome", "q=hello world&more&next=/home", "URL-decoded"},
+		{"base64 text", base64.StdEncoding.EncodeToString([]byte("user=alice role=admin")), "user=alice role=admin", "base64-decoded"},
+		{"base64 url-safe unpadded", base64.RawURLEncoding.EncodeToString([]byte("token for bob?")), "token for bob?", "base64-decoded"},
+		{"base64 binary", base64.StdEncoding.EncodeToString([]byte{0xff, 0x00, 0x10, 0x80, 0x01, 0x02, 0x03, 0x04, 0x05}), "", ""},
+		{"short word", "abcd", "", ""},
+		{"plain text", "connection reset by peer", "", ""},
+		{"broken json", `{"user":`, "", ""},
+		{"multi-line", "{}\n{}", "", ""},
+	}
+	for _, tt := range tests {
+		got, how := decodeForCopy(tt.in)
+		if got != tt.want || how != tt.how {
+			t.Errorf("%s: got (%q, %q), want (%q, %q)", tt.name, got, how, tt.want, tt.how)
+		}
+	}
+}
+
+func TestSelectionCopy_DecodesUnlessRaw(t *testing.T) {
+	copied := captureClipboard(t)
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	blob := base64.StdEncoding.EncodeToString([]byte("hello from base64"))
+
+	m.decodeCopies = false
+	msg := m.selectionCopyCmd(blob, false)()
+	if *copied != blob {
+		t.Errorf("decoding is off; copied %q", *copied)
+	}
+
+	m.decodeCopies = true
+	msg = m.selectionCopyCmd(blob, false)()
+	if *copied != "hello from base64" || msg.(clipboardResultMsg).message != "Copied selection (base64-decoded)" {
+		t.Errorf("copied %q (%v)", *copied, msg)
+	}
+
+	m.selectionCopyCmd(blob, true)()
+	if *copied != blob {
+		t.Errorf("raw copy should bypass decoding; copied %q", *copied)
+	}
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/input"
+)
+
+// diskFindLimit caps the matches listed by a disk find
+const diskFindLimit = 10000
+
+// diskFindContext is how many lines are loaded around a selected match
+const diskFindContext = 5
+
+// diskFindRows is how many matches the overlay lists at once
+const diskFindRows = 15
+
+// diskFindState is the disk find overlay: matches from the file on disk,
+// with the lines around one of them loaded on demand.
+type diskFindState struct {
+	open      bool
+	pattern   string
+	matches   []input.FileMatch
+	truncated bool
+	sel       int
+	context   []string // lines around the selected match; nil until enter
+	contextAt int      // index of the match within context
+}
+
+// diskFindMsg carries the result of scanning the file
+type diskFindMsg struct {
+	pattern   string
+	matches   []input.FileMatch
+	truncated bool
+	err       error
+}
+
+// SetDiskPath sets the file searched by the disk find (Ctrl+G); empty
+// disables it.
+func (m *Model) SetDiskPath(path string) {
+	m.diskPath = path
+}
+
+// diskFindCmd scans the file for the pattern in the background
+func (m Model) diskFindCmd(pattern string) tea.Cmd {
+	path := m.diskPath
+	return func() tea.Msg {
+		matcher, err := core.NewMatcher(pattern)
+		if err != nil {
+			return diskFindMsg{pattern: pattern, err: err}
+		}
+		matches, truncated, err := input.GrepFile(context.Background(), path, matcher, diskFindLimit)
+		return diskFindMsg{pattern: pattern, matches: matches, truncated: truncated, err: err}
+	}
+}
+
+// handleDiskFindResult opens the overlay with the matches
+func (m Model) handleDiskFindResult(msg diskFindMsg) Model {
+	if msg.err != nil {
+		return m.setError("Disk find failed: " + msg.err.Error())
+	}
+	if len(msg.matches) == 0 {
+		return m.setError(fmt.Sprintf("No matches for %q in %s", msg.pattern, filepath.Base(m.diskPath)))
+	}
+	m.diskFind = diskFindState{open: true, pattern: msg.pattern, matches: msg.matches, truncated: msg.truncated}
+	return m
+}
+
+// handleDiskFindKey navigates the overlay; enter loads the lines around the
+// selected match, esc goes back to the list and then closes.
+func (m Model) handleDiskFindKey(key string) Model {
+	d := &m.diskFind
+	switch key {
+	case "up":
+		if d.sel > 0 {
+			d.sel--
+			d.context = nil
+		}
+	case "down":
+		if d.sel < len(d.matches)-1 {
+			d.sel++
+			d.context = nil
+		}
+	case "enter":
+		lines, at, err := input.ReadAround(m.diskPath, d.matches[d.sel].Offset, diskFindContext, diskFindContext)
+		if err != nil {
+			return m.setError("Disk find failed: " + err.Error())
+		}
+		d.context, d.contextAt = lines, at
+	case "esc", "q":
+		if d.context != nil {
+			d.context = nil
+		} else {
+			d.open = false
+		}
+	}
+	return m
+}
+
+// renderDiskFindOverlay lists the matches around the selection, or the
+// loaded lines around the selected match.
+func (m Model) renderDiskFindOverlay() string {
+	d := m.diskFind
+	width := min(100, m.width-4)
+	title := fmt.Sprintf("Disk find %q in %s — %d matches", d.pattern, filepath.Base(m.diskPath), len(d.matches))
+	if d.truncated {
+		title += " (first " + fmt.Sprint(diskFindLimit) + ")"
+	}
+	lines := []string{title, ""}
+
+	if d.context != nil {
+		first := d.matches[d.sel].Line - d.contextAt
+		for i, line := range d.context {
+			row := fmt.Sprintf("%6d  %s", first+i, line)
+			if i == d.contextAt {
+				row = m.theme.FindHitStyle.Render(row)
+			}
+			lines = append(lines, row)
+		}
+		lines = append(lines, "", "Esc: back to matches")
+	} else {
+		start := min(max(d.sel-diskFindRows/2, 0), max(len(d.matches)-diskFindRows, 0))
+		for i := start; i < min(start+diskFindRows, len(d.matches)); i++ {
+			cursor := "  "
+			if i == d.sel {
+				cursor = "> "
+			}
+			lines = append(lines, fmt.Sprintf("%s%6d  %s", cursor, d.matches[i].Line, d.matches[i].Text))
+		}
+		lines = append(lines, "", "Up/Down: select • Enter: show surrounding lines • Esc: close")
+	}
+
+	for i, line := range lines {
+		lines[i] = xansi.Truncate(line, width-2, "…")
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestDiskFind_ListsMatchesEvictedFromTheRing(t *testing.T) {
+	var sb strings.Builder
+	for i := 1; i <= 500; i++ {
+		if i == 10 || i == 20 {
+			fmt.Fprintf(&sb, "line %d needle\n", i)
+		} else {
+			fmt.Fprintf(&sb, "line %d\n", i)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ring := core.NewRing(50)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = nm.(Model)
+	for i := 451; i <= 500; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.inPrompt || !strings.Contains(m.errMsg, "single file") {
+		t.Fatalf("disk find without a path: inPrompt=%v status=%q", m.inPrompt, m.errMsg)
+	}
+
+	m.SetDiskPath(path)
+	press(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if !m.inPrompt || m.promptKind != PromptDiskFind {
+		t.Fatal("Ctrl+G did not open the disk find prompt")
+	}
+	m.input.SetValue("needle")
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("no search command after enter")
+	}
+	var found *diskFindMsg
+	msgs := []tea.Msg{cmd()}
+	for len(msgs) > 0 {
+		msg := msgs[0]
+		msgs = msgs[1:]
+		switch msg := msg.(type) {
+		case tea.BatchMsg:
+			for _, c := range msg {
+				if c != nil {
+					msgs = append(msgs, c())
+				}
+			}
+		case diskFindMsg:
+			found = &msg
+		}
+	}
+	if found == nil {
+		t.Fatal("search command did not produce a result")
+	}
+	nm, _ = m.Update(*found)
+	m = nm.(Model)
+
+	if !m.diskFind.open || len(m.diskFind.matches) != 2 {
+		t.Fatalf("overlay open=%v matches=%d, want the 2 evicted matches", m.diskFind.open, len(m.diskFind.matches))
+	}
+	view := m.View()
+	if !strings.Contains(view, "line 10 needle") || !strings.Contains(view, "line 20 needle") {
+		t.Errorf("overlay missing matches:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	d := m.diskFind
+	if len(d.context) != 2*diskFindContext+1 || d.context[d.contextAt] != "line 20 needle" || d.context[0] != "line 15" {
+		t.Errorf("context = %q (at %d)", d.context, d.contextAt)
+	}
+	if !strings.Contains(m.View(), "    25  line 25") {
+		t.Errorf("context not rendered with line numbers:\n%s", m.View())
+	}
+	if ring.Size() != 50 {
+		t.Errorf("ring changed: %d events", ring.Size())
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.diskFind.open || m.diskFind.context != nil {
+		t.Error("esc should go back to the match list first")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.diskFind.open {
+		t.Error("esc should close the overlay")
+	}
+}
@@ -0,0 +1,27 @@
+package tui
+
+import "time"
+
+// doubleTapWindow is how soon a key must be pressed again to count as a
+// double press
+const doubleTapWindow = 400 * time.Millisecond
+
+// doubleTapActions gives main-view keys a second meaning when pressed twice
+// in quick succession. The first press still does its usual thing, so an
+// action starts by undoing it where needed.
+var doubleTapActions = map[string]func(Model) Model{
+	// g g: close the goto prompt the first g opened and jump to the top
+	"g": func(m Model) Model {
+		m = m.cancelPrompt()
+		m.vp.GotoTop()
+		m.followTail = false
+		return m.setError("Top")
+	},
+}
+
+// isDoubleTap reports whether key repeats the last main-view key within
+// doubleTapWindow and has a double-press action
+func (m Model) isDoubleTap(key string, sincePrev time.Duration) bool {
+	_, ok := doubleTapActions[key]
+	return ok && key == m.tapKey && sincePrev <= doubleTapWindow
+}
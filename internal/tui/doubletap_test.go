@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestDoubleTap_GGJumpsToTop(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 0; i < 100; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+	m = m.updateViewportContent()
+	press := func(r rune) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+
+	press('g')
+	if !m.inPrompt || m.promptKind != PromptGotoSeq {
+		t.Fatal("a single g should open the goto prompt")
+	}
+	press('g')
+	if m.inPrompt || m.vp.YOffset != 0 || m.followTail {
+		t.Errorf("g g: prompt %v, offset %d, follow %v; want the top", m.inPrompt, m.vp.YOffset, m.followTail)
+	}
+
+	// A slow second press is just typed into the prompt
+	m.vp.GotoBottom()
+	press('g')
+	m.lastKeyTime = time.Now().Add(-time.Second)
+	press('g')
+	if !m.inPrompt || m.input.Value() != "g" {
+		t.Errorf("slow g g: prompt %v with %q, want the goto prompt holding g", m.inPrompt, m.input.Value())
+	}
+	m = m.cancelPrompt()
+
+	// Typing gg inside another prompt is plain text
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = updated.(Model)
+	press('g')
+	press('g')
+	if !m.inPrompt || m.input.Value() != "gg" {
+		t.Errorf("find prompt holds %q, want gg", m.input.Value())
+	}
+}
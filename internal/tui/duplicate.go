@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetLaunchArgs sets the arguments that select this session's input (e.g.
+// the file path, "docker", or "--cmd ..."), used to duplicate the session.
+// nil means the input can't be opened twice (piped stdin).
+func (m *Model) SetLaunchArgs(args []string) {
+	m.launchArgs = args
+}
+
+// sessionFlags serializes the current view as siftail flags: filters,
+// highlights, theme and the display options that have a flag.
+func (m Model) sessionFlags() []string {
+	var args []string
+	for _, f := range m.filters.Include {
+		args = append(args, "--filter-in", f.Raw())
+	}
+	for _, f := range m.filters.Exclude {
+		args = append(args, "--filter-out", f.Raw())
+	}
+	for _, f := range m.filters.Highlights {
+		args = append(args, "--highlight", f.Raw())
+	}
+	args = append(args, "--theme", m.theme.Name)
+	if m.linkify {
+		args = append(args, "--links")
+	}
+	if len(m.columns) > 0 {
+		args = append(args, "--columns", strings.Join(m.columns, ","))
+	}
+	return args
+}
+
+// duplicateLauncher returns the command that opens argv in a new tmux pane
+// beside the current one, started in dir, or nil outside tmux.
+func duplicateLauncher(argv []string, dir string, inTmux bool) []string {
+	if !inTmux {
+		return nil
+	}
+	return []string{"tmux", "split-window", "-h", "-c", dir, shellJoin(argv)}
+}
+
+var shellSafeRe = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// shellJoin quotes args for a POSIX shell
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if shellSafeRe.MatchString(a) {
+			quoted[i] = a
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// duplicateResultMsg reports a failed tmux split
+type duplicateResultMsg struct {
+	err error
+}
+
+// duplicateSession starts a second siftail on the same input with the
+// current filters: in a tmux split when inside tmux, otherwise by copying
+// the command to run.
+func (m Model) duplicateSession() (Model, tea.Cmd) {
+	if m.launchArgs == nil {
+		return m.setError("Can't duplicate: piped input can only be read once"), nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "siftail"
+	}
+	argv := append(append([]string{exe}, m.sessionFlags()...), m.launchArgs...)
+
+	dir, _ := os.Getwd()
+	if launch := duplicateLauncher(argv, dir, os.Getenv("TMUX") != ""); launch != nil {
+		return m.setError("Opened a duplicate in a tmux pane"), func() tea.Msg {
+			if out, err := exec.Command(launch[0], launch[1:]...).CombinedOutput(); err != nil {
+				return duplicateResultMsg{err: fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))}
+			}
+			return nil
+		}
+	}
+	cmdline := shellJoin(argv)
+	return m.setError("Run in another terminal: " + cmdline), copyTextCmd(cmdline, "Duplicate command copied; run it in another terminal")
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestDuplicate_LaunchCommand(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m.SetTheme("nord")
+	m.linkify = false
+	m.columns = nil
+	for _, add := range []struct {
+		fn      func(core.TextMatcher)
+		pattern string
+	}{
+		{m.filters.AddInclude, "/timeout \\d+/"},
+		{m.filters.AddExclude, "healthz"},
+		{m.filters.AddHighlight, "it's"},
+	} {
+		matcher, err := core.NewMatcher(add.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		add.fn(matcher)
+	}
+	m.SetLaunchArgs([]string{"/var/log/my app.log"})
+
+	argv := append(append([]string{"/usr/bin/siftail"}, m.sessionFlags()...), m.launchArgs...)
+	want := []string{"/usr/bin/siftail",
+		"--filter-in", "/timeout \\d+/", "--filter-out", "healthz", "--highlight", "it's",
+		"--theme", "nord", "/var/log/my app.log"}
+	if !reflect.DeepEqual(argv, want) {
+		t.Fatalf("argv = %q\nwant   %q", argv, want)
+	}
+
+	if got := duplicateLauncher(argv, "/srv", false); got != nil {
+		t.Errorf("expected no launcher outside tmux, got %q", got)
+	}
+	wantTmux := []string{"tmux", "split-window", "-h", "-c", "/srv",
+		`/usr/bin/siftail --filter-in '/timeout \d+/' --filter-out healthz --highlight 'it'\''s' --theme nord '/var/log/my app.log'`}
+	if got := duplicateLauncher(argv, "/srv", true); !reflect.DeepEqual(got, wantTmux) {
+		t.Errorf("tmux launcher = %q\nwant %q", got, wantTmux)
+	}
+}
+
+func TestDuplicate_StdinRefused(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	m, cmd := m.duplicateSession()
+	if cmd != nil || m.errMsg != "Can't duplicate: piped input can only be read once" {
+		t.Errorf("expected stdin duplication to be refused, got %q", m.errMsg)
+	}
+}
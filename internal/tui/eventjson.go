@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// marshalEvent renders e as a single JSON object
+func marshalEvent(e core.LogEvent) (string, error) {
+	data, err := json.Marshal(core.NewEventJSON(e))
+	return string(data), err
+}
+
+// centeredEvent returns the event on the middle row of the viewport
+func (m Model) centeredEvent() (core.LogEvent, bool) {
+	if len(m.contentLines) == 0 {
+		return core.LogEvent{}, false
+	}
+	e, ok := m.eventAtRow(min(m.vp.YOffset+m.vp.Height/2, len(m.contentLines)-1))
+	if !ok {
+		return core.LogEvent{}, false
+	}
+	return m.ring.GetBySeq(e.Seq)
+}
+
+// copyEventJSON copies the centered event as a JSON object
+func (m Model) copyEventJSON() (Model, tea.Cmd) {
+	e, ok := m.centeredEvent()
+	if !ok {
+		return m.setError("No event to copy"), nil
+	}
+	text, err := marshalEvent(e)
+	if err != nil {
+		return m.setError("Copy failed: " + err.Error()), nil
+	}
+	return m, copyTextCmd(text, fmt.Sprintf("Copied event #%d as JSON", e.Seq))
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// exportLines renders the events passing the current filters as they appear
+// in the view (timestamp, container and level prefix) without color.
+func (m Model) exportLines() []string {
+	plan := core.VisiblePlan{Include: m.textFilters(), LevelMap: m.levels, DockerVisible: m.dockerUI.Containers, SinceSeq: m.sinceSeq, UntilSeq: m.untilSeq, Chronological: m.chronological}
+	full := m
+	full.xOffset = 0
+	var lines []string
+	var prev core.LogEvent
+	for i, e := range core.ComputeVisible(m.ring.Snapshot(), plan) {
+		lines = append(lines, stripANSI(full.renderEventStyled(e, i > 0 && m.sameSecond(prev.Time, e.Time))))
+		prev = e
+	}
+	return lines
+}
+
+// exportVisible writes the visible lines to path; a leading ~ is the home
+// directory.
+func (m Model) exportVisible(path string) Model {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	lines := m.exportLines()
+	if len(lines) == 0 {
+		return m.setError("No visible lines to export")
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		return m.setError("Export failed: " + err.Error())
+	}
+	return m.setError(fmt.Sprintf("Exported %d lines to %s", len(lines), path))
+}
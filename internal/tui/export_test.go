@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestExportVisible_WritesPlainFilteredLines(t *testing.T) {
+	ring := core.NewRing(100)
+	filters := core.NewFilters()
+	m := *NewModel(ring, filters, core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 30, Height: 10})
+	m = nm.(Model)
+	m.wrapLines = false
+	m.location = time.UTC
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	m.dockerUI.Containers["api"] = true
+	ring.Append(core.LogEvent{Time: at, Source: core.SourceDocker, Container: "api", LevelStr: "INFO", Level: core.SevInfo, Line: "request served in 12ms, well past the viewport edge"})
+	ring.Append(core.LogEvent{Time: at.Add(time.Second), Source: core.SourceDocker, Container: "api", Line: "healthz ok"})
+	ring.Append(core.LogEvent{Time: at.Add(2 * time.Second), Source: core.SourceDocker, Container: "api", LevelStr: "ERROR", Level: core.SevError, Line: "upstream timed out"})
+	exclude, _ := core.NewMatcher("healthz")
+	filters.AddExclude(exclude)
+	m = m.updateViewportContent()
+
+	path := filepath.Join(t.TempDir(), "visible.log")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated.(Model)
+	if !m.inPrompt || m.promptKind != PromptExportPath {
+		t.Fatal("e did not open the export prompt")
+	}
+	m.input.SetValue(path)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("export not written: %v (status %q)", err, m.errMsg)
+	}
+	want := "12:00:00.000 [api] INFO  request served in 12ms, well past the viewport edge\n" +
+		"12:00:02.000 [api] ERROR upstream timed out\n"
+	if string(data) != want {
+		t.Errorf("exported\n%q\nwant\n%q", data, want)
+	}
+	if strings.Contains(string(data), "\x1b") {
+		t.Error("export contains escape sequences")
+	}
+	if m.errMsg != "Exported 2 lines to "+path {
+		t.Errorf("status = %q", m.errMsg)
+	}
+
+	m = m.exportVisible(filepath.Join(t.TempDir(), "missing", "out.log"))
+	if !strings.HasPrefix(m.errMsg, "Export failed") {
+		t.Errorf("expected a failure status, got %q", m.errMsg)
+	}
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// SetSourcePaths maps the origin of file events to the path they were read
+// from, for copying file:line references (f); a single file has origin "".
+func (m *Model) SetSourcePaths(paths map[string]string) {
+	m.sourcePaths = paths
+}
+
+// fileRef returns the path:line reference of e, or an error message
+func (m Model) fileRef(e core.LogEvent) (string, string) {
+	if e.Source != core.SourceFile {
+		return "", "Not a file line"
+	}
+	path, ok := m.sourcePaths[e.Origin]
+	if !ok || path == "" {
+		return "", "Unknown file for this line"
+	}
+	line := e.FileLine()
+	if line <= 0 {
+		return "", "Unknown line number for this line"
+	}
+	return fmt.Sprintf("%s:%d", path, line), ""
+}
+
+// copyFileRef copies the path:line reference of the centered event
+func (m Model) copyFileRef() (Model, tea.Cmd) {
+	e, ok := m.centeredEvent()
+	if !ok {
+		return m.setError("No event to copy"), nil
+	}
+	ref, errMsg := m.fileRef(e)
+	if errMsg != "" {
+		return m.setError(errMsg), nil
+	}
+	return m, copyTextCmd(ref, "Copied "+ref)
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestCopyFileRef(t *testing.T) {
+	copied := captureClipboard(t)
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m.SetSourcePaths(map[string]string{"": "/var/log/app.log", "api.log": "logs/api.log"})
+
+	ring.Append(core.LogEvent{Source: core.SourceFile, Origin: "api.log", Line: "boom", LineNo: 42})
+	m = m.updateViewportContent()
+	m, cmd := m.copyFileRef()
+	if cmd == nil {
+		t.Fatalf("expected a copy command, status %q", m.errMsg)
+	}
+	msg := cmd()
+	if *copied != "logs/api.log:42" {
+		t.Errorf("copied %q, want logs/api.log:42", *copied)
+	}
+	if r, ok := msg.(clipboardResultMsg); !ok || r.message != "Copied logs/api.log:42" {
+		t.Errorf("status = %#v", msg)
+	}
+
+	e := core.LogEvent{Source: core.SourceFile, Line: "x"}
+	if _, errMsg := m.fileRef(e); errMsg == "" {
+		t.Error("expected an error for a line without a number")
+	}
+	e.LineNo = 7
+	if ref, _ := m.fileRef(e); ref != "/var/log/app.log:7" {
+		t.Errorf("single file ref = %q", ref)
+	}
+}
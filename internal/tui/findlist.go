@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// findListRows is how many matches the find list shows per page
+const findListRows = 15
+
+// findListState is the find results overlay: every hit of the active find,
+// previewed from the ring.
+type findListState struct {
+	open bool
+	hits []uint64
+	sel  int
+}
+
+// openFindList lists the hits of the active find, starting at the current one
+func (m Model) openFindList() Model {
+	if !m.search.IsActive() {
+		return m.setError("No active find (Ctrl+F)")
+	}
+	_, hits, cursor := m.search.GetSnapshot()
+	if len(hits) == 0 {
+		return m.setError("No find matches")
+	}
+	m.findList = findListState{open: true, hits: hits, sel: max(cursor, 0)}
+	return m
+}
+
+// handleFindListKey navigates the list; enter jumps to the selected match
+func (m Model) handleFindListKey(key string) Model {
+	l := &m.findList
+	last := len(l.hits) - 1
+	switch key {
+	case "up":
+		l.sel = max(l.sel-1, 0)
+	case "down":
+		l.sel = min(l.sel+1, last)
+	case "pgup":
+		l.sel = max(l.sel-findListRows, 0)
+	case "pgdown":
+		l.sel = min(l.sel+findListRows, last)
+	case "home":
+		l.sel = 0
+	case "end":
+		l.sel = last
+	case "enter":
+		seq := l.hits[l.sel]
+		l.open = false
+		if _, ok := m.ring.GetBySeq(seq); !ok {
+			return m.setError(fmt.Sprintf("#%d was evicted", seq))
+		}
+		m.search.SetCurrentBySeq(seq)
+		m = m.scrollToSequence(seq)
+	case "esc", "q", "l":
+		l.open = false
+	}
+	return m
+}
+
+// renderFindListOverlay shows the page of matches holding the selection
+func (m Model) renderFindListOverlay() string {
+	l := m.findList
+	width := min(100, m.width-4)
+	pages := (len(l.hits) + findListRows - 1) / findListRows
+	page := l.sel / findListRows
+	lines := []string{
+		fmt.Sprintf("Find %q — %d matches (page %d/%d)", m.search.GetMatcher().Raw(), len(l.hits), page+1, pages),
+		"",
+	}
+
+	start := page * findListRows
+	for i := start; i < min(start+findListRows, len(l.hits)); i++ {
+		cursor := "  "
+		if i == l.sel {
+			cursor = "> "
+		}
+		preview := "(evicted)"
+		if e, ok := m.ring.GetBySeq(l.hits[i]); ok {
+			preview = e.Line
+		}
+		lines = append(lines, fmt.Sprintf("%s%5d/%d  #%-7d %s", cursor, i+1, len(l.hits), l.hits[i], preview))
+	}
+	lines = append(lines, "", "Up/Down: select • PgUp/PgDn: page • Enter: jump • Esc: close")
+
+	for i, line := range lines {
+		lines[i] = xansi.Truncate(line, width-2, "…")
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
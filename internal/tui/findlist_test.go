@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestFindList_ListsAndJumpsToMatch(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = nm.(Model)
+	for i := 1; i <= 200; i++ {
+		line := fmt.Sprintf("line %03d", i)
+		if i%5 == 0 {
+			line += " needle"
+		}
+		ring.Append(core.LogEvent{Line: line})
+	}
+	m = m.updateViewportContent()
+
+	press := func(key tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	press(runes("l"))
+	if m.findList.open || !strings.Contains(m.errMsg, "No active find") {
+		t.Fatalf("expected l without a find to report it, got %q", m.errMsg)
+	}
+
+	matcher, _ := core.NewMatcher("needle")
+	m.search.SetMatcher(matcher)
+	m.search.SetActive(true)
+	m = m.refreshFindIndex()
+	m.search.JumpToFirst()
+
+	press(runes("l"))
+	if !m.findList.open || len(m.findList.hits) != 40 {
+		t.Fatalf("list open=%v hits=%d, want 40", m.findList.open, len(m.findList.hits))
+	}
+	view := m.View()
+	for _, want := range []string{"40 matches (page 1/3)", "1/40  #5 ", "line 005 needle", "line 075 needle"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("missing %q in:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "line 080 needle") {
+		t.Error("expected only the first page")
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyPgDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	if view := m.View(); !strings.Contains(view, "page 2/3") || !strings.Contains(view, "> ") || !strings.Contains(view, "line 085 needle") {
+		t.Errorf("expected the second page:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.findList.open {
+		t.Fatal("enter should close the list")
+	}
+	if got := m.search.Current(); got != 85 {
+		t.Errorf("current hit = #%d, want #85", got)
+	}
+	if e, _ := m.centeredEvent(); e.Line != "line 085 needle" {
+		t.Errorf("centered %q, want line 085 needle", e.Line)
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gotoSequence scrolls to the event with the given ring sequence number, or
+// the nearest visible one after it when filters hide it. Numbers past the
+// newest event clamp to it; evicted ones are reported.
+func (m Model) gotoSequence(seq uint64) Model {
+	oldest, newest := m.ring.OldestSeq(), m.ring.CurrentSeq()
+	if oldest == 0 {
+		return m.setError("No lines yet")
+	}
+	if seq < oldest {
+		return m.setError(fmt.Sprintf("#%d was evicted; oldest is #%d", seq, oldest))
+	}
+	if seq > newest {
+		seq = newest
+	}
+
+	m = m.updateViewportContent()
+	var found, before uint64
+	for _, e := range m.ring.Snapshot() {
+		if _, ok := m.seqIndex[e.Seq]; !ok {
+			continue
+		}
+		if e.Seq >= seq {
+			found = e.Seq
+			break
+		}
+		before = e.Seq
+	}
+	if found == 0 {
+		found = before
+	}
+	if found == 0 {
+		return m.setError("No visible lines")
+	}
+
+	m = m.scrollToSequence(found)
+	if found != seq {
+		return m.setError(fmt.Sprintf("#%d is hidden; showing #%d", seq, found))
+	}
+	return m.setError(fmt.Sprintf("Line #%d", seq))
+}
+
+// parseSequence reads the number typed at the goto prompt, with or without
+// the leading # used in the status line.
+func parseSequence(text string) (uint64, error) {
+	return strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(text), "#"), 10, 64)
+}
+
+// gotoLevelDiscovery scrolls to the first line seen at a level, given as a
+// slot number (1-9) or a level name.
+func (m Model) gotoLevelDiscovery(text string) Model {
+	text = strings.TrimSpace(text)
+	slot, err := strconv.Atoi(text)
+	if err != nil {
+		var ok bool
+		if slot, ok = m.levels.SlotIndex(text); !ok {
+			return m.setError("Unknown level: " + text)
+		}
+	}
+	if slot < 1 || slot > 9 {
+		return m.setError("Level slots are 1-9")
+	}
+	names, _ := m.levels.GetSnapshot()
+	name := names[slot]
+	if name == "" {
+		name = strconv.Itoa(slot)
+	}
+	seq := m.levels.DiscoverySeq(slot)
+	if seq == 0 {
+		return m.setError(fmt.Sprintf("No %s line seen yet", name))
+	}
+	if seq < m.ring.OldestSeq() {
+		return m.setError(fmt.Sprintf("First %s line #%d was evicted", name, seq))
+	}
+	m = m.gotoSequence(seq)
+	if strings.HasPrefix(m.errMsg, "Line #") {
+		m = m.setError(fmt.Sprintf("First %s line: #%d", name, seq))
+	}
+	return m
+}
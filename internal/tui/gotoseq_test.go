@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestGotoSeq_JumpsToSequence(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 1; i <= 100; i++ {
+		kind := "odd"
+		if i%2 == 0 {
+			kind = "even"
+		}
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("%s-%03d", kind, i)})
+	}
+	m = m.updateViewportContent()
+
+	jump := func(text string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+		m = updated.(Model)
+		if !m.inPrompt || m.promptKind != PromptGotoSeq {
+			t.Fatal("g did not open the goto prompt")
+		}
+		m.input.SetValue(text)
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+	}
+	centered := func() string {
+		e, _ := m.centeredEvent()
+		return e.Line
+	}
+
+	jump("42")
+	if got := centered(); got != "even-042" || m.followTail {
+		t.Fatalf("centered %q (follow %v), want even-042", got, m.followTail)
+	}
+	if !strings.Contains(m.errMsg, "#42") {
+		t.Errorf("status = %q", m.errMsg)
+	}
+
+	// A filtered-out sequence lands on the next visible line
+	matcher, _ := core.NewMatcher("even")
+	m.filters.AddInclude(matcher)
+	jump("#43")
+	if got := centered(); got != "even-044" || !strings.Contains(m.errMsg, "#43 is hidden") {
+		t.Errorf("centered %q (%q), want even-044", got, m.errMsg)
+	}
+
+	jump("abc")
+	if !m.inPrompt || m.promptErr == "" {
+		t.Error("expected a non-number to keep the prompt open with an error")
+	}
+	m = m.cancelPrompt()
+
+	// Evicted sequences are reported
+	for i := 0; i < 1000; i++ {
+		ring.Append(core.LogEvent{Line: "later"})
+	}
+	jump("42")
+	if !strings.Contains(m.errMsg, "evicted") {
+		t.Errorf("expected an eviction error, got %q", m.errMsg)
+	}
+}
+
+func TestGotoLevelDiscovery_JumpsToFirstLine(t *testing.T) {
+	ring := core.NewRing(1000)
+	levels := core.NewLevelMap()
+	detector := core.NewDefaultSeverityDetector(levels)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), levels, ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 1; i <= 60; i++ {
+		line := fmt.Sprintf("[INFO] tick %d", i)
+		if i == 37 || i == 50 {
+			line = fmt.Sprintf("[AUDIT] login %d", i)
+		}
+		e := core.LogEvent{Line: line}
+		e.LevelStr, e.Level, _ = detector.Detect(line)
+		ring.Append(e)
+	}
+	m = m.updateViewportContent()
+
+	jump := func(text string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+		m = updated.(Model)
+		if !m.inPrompt || m.promptKind != PromptLevelDiscovery {
+			t.Fatal("K did not open the level prompt")
+		}
+		m.input.SetValue(text)
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+	}
+
+	jump("audit")
+	if e, _ := m.centeredEvent(); e.Seq != 37 || m.errMsg != "First AUDIT line: #37" {
+		t.Errorf("centered #%d (%q), want the first AUDIT line #37", e.Seq, m.errMsg)
+	}
+	jump("5")
+	if e, _ := m.centeredEvent(); e.Seq != 37 {
+		t.Errorf("slot 5 centered #%d, want #37", e.Seq)
+	}
+	jump("6")
+	if !strings.Contains(m.errMsg, "No 6 line seen yet") {
+		t.Errorf("empty slot status = %q", m.errMsg)
+	}
+	jump("bogus")
+	if m.errMsg != "Unknown level: bogus" {
+		t.Errorf("unknown level status = %q", m.errMsg)
+	}
+}
+
+func TestGotoLevelDiscovery_NotedWithoutRender(t *testing.T) {
+	ring := core.NewRing(5)
+	levels := core.NewLevelMap()
+	detector := core.NewDefaultSeverityDetector(levels)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), levels, ModeFile)
+	// The AUDIT line is evicted before the view is ever rendered
+	for _, line := range []string{"[AUDIT] login", "[INFO] a", "[INFO] b", "[INFO] c", "[INFO] d", "[INFO] e"} {
+		e := core.LogEvent{Line: line}
+		e.LevelStr, e.Level, _ = detector.Detect(line)
+		ring.Append(e)
+	}
+	m = m.gotoLevelDiscovery("audit")
+	if m.errMsg != "First AUDIT line #1 was evicted" {
+		t.Errorf("status = %q, want the evicted discovery line", m.errMsg)
+	}
+}
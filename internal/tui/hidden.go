@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// hiddenCounts tallies why buffered lines are hidden when none is shown,
+// each line under the first reason that hides it
+type hiddenCounts struct {
+	pause, window, levels, containers, filters int
+}
+
+// countHidden attributes each event to the check of plan, or the text
+// filters, that hides it
+func countHidden(events []core.LogEvent, plan core.VisiblePlan) hiddenCounts {
+	var c hiddenCounts
+	window := core.VisiblePlan{SinceSeq: plan.SinceSeq, UntilSeq: plan.UntilSeq}
+	levels := core.VisiblePlan{LevelMap: plan.LevelMap}
+	containers := core.VisiblePlan{DockerVisible: plan.DockerVisible}
+	for _, e := range events {
+		switch {
+		case plan.BeforeSeq != 0 && e.Seq >= plan.BeforeSeq:
+			c.pause++
+		case !core.ShouldShowEvent(e, window):
+			c.window++
+		case !core.ShouldShowEvent(e, levels):
+			c.levels++
+		case !core.ShouldShowEvent(e, containers):
+			c.containers++
+		default:
+			c.filters++
+		}
+	}
+	return c
+}
+
+// note words the counts for the empty viewport: the reason and how to undo
+// it when there is one, else the share of each
+func (c hiddenCounts) note() string {
+	reasons := []struct {
+		n           int
+		label, hint string
+	}{
+		{c.filters, "filters", "P to peek / c to clear"},
+		{c.levels, "levels", "0 to enable all"},
+		{c.containers, "containers", "Ctrl+D to pick containers"},
+		{c.window, "the { / } window", "{ or } again to undo"},
+		{c.pause, "the pause", "Space to resume"},
+	}
+	var total int
+	var parts []string
+	for _, r := range reasons {
+		if r.n > 0 {
+			total += r.n
+			parts = append(parts, fmt.Sprintf("%d by %s", r.n, r.label))
+		}
+	}
+	if len(parts) == 1 {
+		for _, r := range reasons {
+			if r.n > 0 {
+				return fmt.Sprintf("%d lines hidden by %s — press %s", r.n, r.label, r.hint)
+			}
+		}
+	}
+	return fmt.Sprintf("%d lines hidden: %s", total, strings.Join(parts, ", "))
+}
@@ -0,0 +1,97 @@
+package tui
+
+// promptHistoryLimit bounds the recent submissions kept per prompt
+const promptHistoryLimit = 50
+
+// promptHistoryNames keys each prompt's history in config.json
+var promptHistoryNames = map[PromptKind]string{
+	PromptFind:      "find",
+	PromptHighlight: "highlight",
+	PromptFilterIn:  "filterIn",
+	PromptFilterOut: "filterOut",
+	PromptDiskFind:  "diskFind",
+	PromptGotoSeq:   "goto",
+}
+
+// promptHistory holds recent prompt submissions per kind, oldest first
+type promptHistory struct {
+	entries map[PromptKind][]string
+	pos     int    // index being recalled; -1 when editing fresh text
+	draft   string // text typed before recalling started
+	save    bool   // persist to config.json ("savePromptHistory")
+}
+
+// recordHistory remembers a submitted prompt text, moving a repeat to the end
+func (m Model) recordHistory(kind PromptKind, text string) Model {
+	if _, ok := promptHistoryNames[kind]; !ok {
+		return m
+	}
+	if m.history.entries == nil {
+		m.history.entries = make(map[PromptKind][]string)
+	}
+	var entries []string
+	for _, e := range m.history.entries[kind] {
+		if e != text {
+			entries = append(entries, e)
+		}
+	}
+	entries = append(entries, text)
+	if len(entries) > promptHistoryLimit {
+		entries = entries[len(entries)-promptHistoryLimit:]
+	}
+	m.history.entries[kind] = entries
+	if m.history.save {
+		m.persistSettings()
+	}
+	return m
+}
+
+// recallHistory replaces the prompt text with an older (up) or newer entry
+// of the current prompt's history; going past the newest restores the draft.
+func (m Model) recallHistory(older bool) Model {
+	entries := m.history.entries[m.promptKind]
+	h := &m.history
+	switch {
+	case len(entries) == 0:
+		return m
+	case older && h.pos == -1:
+		h.draft = m.input.Value()
+		h.pos = len(entries) - 1
+	case older:
+		h.pos = max(h.pos-1, 0)
+	case h.pos == -1:
+		return m
+	case h.pos < len(entries)-1:
+		h.pos++
+	default:
+		h.pos = -1
+		m.input.SetValue(h.draft)
+		m.input.CursorEnd()
+		return m
+	}
+	m.input.SetValue(entries[h.pos])
+	m.input.CursorEnd()
+	m.promptErr = ""
+	return m
+}
+
+// historySettings returns the history keyed for config.json
+func (m Model) historySettings() map[string][]string {
+	out := make(map[string][]string)
+	for kind, entries := range m.history.entries {
+		if name, ok := promptHistoryNames[kind]; ok && len(entries) > 0 {
+			out[name] = entries
+		}
+	}
+	return out
+}
+
+// loadHistory restores the history saved in config.json
+func (m *Model) loadHistory(saved map[string][]string) {
+	m.history.entries = make(map[PromptKind][]string)
+	for kind, name := range promptHistoryNames {
+		if entries := saved[name]; len(entries) > 0 {
+			m.history.entries[kind] = entries[max(len(entries)-promptHistoryLimit, 0):]
+		}
+	}
+}
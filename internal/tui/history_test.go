@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/persist"
+)
+
+func TestPromptHistory_RecallCycles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	up, down := tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyDown}
+	find := func(text string) {
+		t.Helper()
+		press(tea.KeyMsg{Type: tea.KeyCtrlF})
+		m.input.SetValue(text)
+		press(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	find("timeout")
+	find("panic")
+	find("timeout") // a repeat moves to the newest slot
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m.input.SetValue("other kind")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m.input.SetValue("draft")
+	want := []struct {
+		key  tea.KeyMsg
+		text string
+	}{{up, "timeout"}, {up, "panic"}, {up, "panic"}, {down, "timeout"}, {down, "draft"}, {down, "draft"}}
+	for i, step := range want {
+		press(step.key)
+		if got := m.input.Value(); got != step.text {
+			t.Fatalf("step %d: prompt = %q, want %q", i, got, step.text)
+		}
+	}
+	if !m.inPrompt {
+		t.Fatal("up/down should stay in the prompt")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// Up/Down outside a prompt still navigate find matches, not history
+	press(up)
+	if m.inPrompt || m.input.Value() != "draft" {
+		t.Errorf("up outside the prompt changed the input: %q", m.input.Value())
+	}
+
+	// Saved across restarts only when enabled
+	if s, _ := m.settingsStore.Load(); len(s.PromptHistory) != 0 {
+		t.Errorf("history saved without savePromptHistory: %v", s.PromptHistory)
+	}
+	if err := m.settingsStore.Save(persist.Settings{ShowTimestamps: true, SavePromptHistory: true}); err != nil {
+		t.Fatal(err)
+	}
+	m = *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	find("error")
+	m = *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	press(tea.KeyMsg{Type: tea.KeyCtrlF})
+	press(up)
+	if got := m.input.Value(); got != "error" {
+		t.Errorf("restored history recall = %q, want error", got)
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// prettyJSON indents a JSON object line, or reports false when the line
+// isn't one
+func prettyJSON(line string) (string, bool) {
+	if !core.IsJSONObjectLine(line) {
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(strings.TrimSpace(line)), "", "  "); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// toggleJSON expands the centered JSON event to indented, multi-line form,
+// or collapses it back to its single line
+func (m Model) toggleJSON() Model {
+	e, ok := m.centeredEvent()
+	if !ok {
+		return m.setError("No event to expand")
+	}
+	if _, ok := prettyJSON(e.Line); !ok {
+		return m.setError("Not a JSON line")
+	}
+	if m.expandedJSON == nil {
+		m.expandedJSON = make(map[uint64]bool)
+	}
+	if m.expandedJSON[e.Seq] {
+		delete(m.expandedJSON, e.Seq)
+	} else {
+		m.expandedJSON[e.Seq] = true
+	}
+	if m.renderCache != nil {
+		delete(m.renderCache.rows, e.Seq)
+	}
+	m.dirty = true
+	m = m.updateViewportContent()
+	if !m.followTail {
+		m = m.scrollToSequence(e.Seq)
+	}
+	if m.expandedJSON[e.Seq] {
+		return m.setError("JSON expanded")
+	}
+	return m.setError("JSON collapsed")
+}
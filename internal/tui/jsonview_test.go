@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestToggleJSON_ExpandsAndCollapses(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 12})
+	m = nm.(Model)
+	m.showTimestamps = false
+	line := `{"msg":"login","user":{"id":7}}`
+	ring.Append(core.LogEvent{Line: "plain text"})
+	ring.Append(core.LogEvent{Line: line})
+	m = m.updateViewportContent()
+	m.followTail = false
+
+	press := func() {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+		m = updated.(Model)
+	}
+	shown := func() string { return strings.Join(m.contentPlainLines, "\n") }
+
+	m = m.scrollToSequence(2)
+	press()
+	want := "plain text\n{\n  \"msg\": \"login\",\n  \"user\": {\n    \"id\": 7\n  }\n}"
+	if shown() != want || m.errMsg != "JSON expanded" {
+		t.Fatalf("expanded view\n%s\nwant\n%s (status %q)", shown(), want, m.errMsg)
+	}
+	if e, ok := m.eventAtRow(4); !ok || e.Seq != 2 {
+		t.Errorf("continuation row maps to %+v, want the JSON event", e)
+	}
+
+	press()
+	if shown() != "plain text\n"+line || m.errMsg != "JSON collapsed" {
+		t.Errorf("collapsed view\n%s (status %q)", shown(), m.errMsg)
+	}
+
+	if _, ok := prettyJSON("plain text"); ok {
+		t.Error("plain text treated as JSON")
+	}
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+var (
+	linkURLRe  = regexp.MustCompile("https?://[^\\s<>\"'`]+")
+	linkPathRe = regexp.MustCompile(`(?:^|[\s=:"'(\[,])(/[\w.@%+~-]+(?:/[\w.@%+~-]+)+/?)`)
+)
+
+// linkSpan is a byte range of a URL or absolute path within a line
+type linkSpan struct {
+	start, end int
+	url        bool
+}
+
+// findLinks returns the URL and absolute-path spans in line, in order. Paths
+// inside URLs are skipped and trailing sentence punctuation is left out.
+func findLinks(line string) []linkSpan {
+	var spans []linkSpan
+	for _, loc := range linkURLRe.FindAllStringIndex(line, -1) {
+		end := loc[0] + len(strings.TrimRight(line[loc[0]:loc[1]], ".,;:!?)]}"))
+		spans = append(spans, linkSpan{start: loc[0], end: end, url: true})
+	}
+	urls := len(spans)
+	for _, loc := range linkPathRe.FindAllStringSubmatchIndex(line, -1) {
+		start, end := loc[2], loc[3]
+		inURL := false
+		for _, u := range spans[:urls] {
+			if start < u.end && end > u.start {
+				inURL = true
+				break
+			}
+		}
+		if !inURL {
+			spans = append(spans, linkSpan{start: start, end: end})
+		}
+	}
+	// Merge the two sorted runs
+	for i := urls; i < len(spans); i++ {
+		for j := i; j > 0 && spans[j].start < spans[j-1].start; j-- {
+			spans[j], spans[j-1] = spans[j-1], spans[j]
+		}
+	}
+	return spans
+}
+
+// renderMessage styles the message part of a line. With link detection on,
+// URLs and paths are highlighted as separate segments so find/highlight
+// styling and the link style never nest; URLs are also wrapped in OSC 8.
+func (m Model) renderMessage(line string, seq uint64) string {
+	if !m.linkify {
+		return m.applyHighlighting(line, seq)
+	}
+	spans := findLinks(line)
+	if len(spans) == 0 {
+		return m.applyHighlighting(line, seq)
+	}
+
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		if s.start > pos {
+			b.WriteString(m.applySpanHighlighting(line[pos:s.start], line, seq))
+		}
+		text := line[s.start:s.end]
+		styled := m.applySpanHighlighting(text, line, seq)
+		if styled == text {
+			// Highlighting takes precedence; only plain spans get the link style
+			styled = m.theme.LinkStyle.Render(text)
+		}
+		if s.url {
+			styled = xansi.SetHyperlink(text) + styled + xansi.ResetHyperlink()
+		}
+		b.WriteString(styled)
+		pos = s.end
+	}
+	if pos < len(line) {
+		b.WriteString(m.applySpanHighlighting(line[pos:], line, seq))
+	}
+	return b.String()
+}
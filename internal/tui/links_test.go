@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/muesli/termenv"
+)
+
+func TestFindLinks(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"see https://example.com/a?b=1.", []string{"https://example.com/a?b=1"}},
+		{"open /var/log/app.log failed", []string{"/var/log/app.log"}},
+		{"path=/etc/hosts url=http://h/x/y", []string{"/etc/hosts", "http://h/x/y"}},
+		{"ratio 1/2 and /single", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, s := range findLinks(tt.line) {
+			got = append(got, tt.line[s.start:s.end])
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("findLinks(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMessage_WrapsURLInOSC8(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	line := "fetch https://example.com/api failed"
+	osc := "\x1b]8;;https://example.com/api\x07"
+
+	m.SetLinkify(false)
+	if got := m.renderMessage(line, 1); strings.Contains(got, "\x1b]8;") {
+		t.Errorf("expected no hyperlink when disabled, got %q", got)
+	}
+
+	m.SetLinkify(true)
+	got := m.renderMessage(line, 1)
+	if !strings.Contains(got, osc) || !strings.Contains(got, "\x1b]8;;\x07") {
+		t.Errorf("expected URL wrapped in OSC 8, got %q", got)
+	}
+	if stripANSI(got) != line {
+		t.Errorf("expected plain text unchanged, got %q", stripANSI(got))
+	}
+
+	// A highlight inside the URL keeps a single hyperlink around the span
+	hl, _ := core.NewMatcher("example")
+	m.filters.AddHighlight(hl)
+	got = m.renderMessage(line, 1)
+	if n := strings.Count(got, osc); n != 1 {
+		t.Errorf("expected exactly one hyperlink, got %d in %q", n, got)
+	}
+	if !strings.Contains(got, m.theme.HighlightStyle.Render("example")) {
+		t.Errorf("expected highlight to compose with the link, got %q", got)
+	}
+}
+
+func TestRenderMessage_FieldRulesSeeWholeLine(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m.SetLinkify(true)
+	hl, err := core.NewMatcher("field:status>=500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.filters.AddHighlight(hl)
+
+	line := "status=503 path=/api/orders"
+	got := m.renderMessage(line, 1)
+	if want := m.theme.HighlightStyle.Render("status=503 path="); !strings.Contains(got, want) {
+		t.Errorf("expected the text before the link highlighted, got %q", got)
+	}
+	if want := m.theme.HighlightStyle.Render("/api/orders"); !strings.Contains(got, want) {
+		t.Errorf("expected the link highlighted, got %q", got)
+	}
+
+	if got := m.renderMessage("status=200 path=/api/orders", 1); strings.Contains(got, m.theme.HighlightStyle.Render("status=200 path=")) {
+		t.Errorf("expected no highlight below 500, got %q", got)
+	}
+}
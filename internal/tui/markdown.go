@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// markdownSnapshot renders events for pasting into issues: a table with one
+// column per configured JSON field, or a fenced code block of the raw lines
+// when no columns are configured.
+func markdownSnapshot(columns []string, events []core.LogEvent) string {
+	if len(columns) == 0 {
+		lines := make([]string, len(events))
+		for i, e := range events {
+			lines[i] = e.Line
+		}
+		body := strings.Join(lines, "\n")
+		fence := "```"
+		for strings.Contains(body, fence) {
+			fence += "`"
+		}
+		return fence + "\n" + body + "\n" + fence
+	}
+
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, e := range events {
+		cells := make([]string, len(columns))
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(e.Line), &obj); err != nil {
+			// Unstructured lines keep their text in the first column
+			cells[0] = markdownCell(e.Line)
+		} else {
+			for i, col := range columns {
+				cells[i] = markdownCell(jsonField(obj, col))
+			}
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// jsonField looks up a dotted path (e.g. "http.status") in a decoded object.
+// Strings are returned as-is; other values in their JSON form.
+func jsonField(obj map[string]any, path string) string {
+	var v any = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return ""
+		}
+		if v, ok = m[key]; !ok {
+			return ""
+		}
+	}
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
+// markdownCell escapes pipes and flattens newlines so a value fits one cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.NewReplacer("\r\n", " ", "\n", " ").Replace(s)
+}
+
+// visibleEvents returns the events with at least one row inside the viewport
+func (m Model) visibleEvents() []core.LogEvent {
+	top, bottom := m.vp.YOffset, m.vp.YOffset+m.vp.Height
+	plan := core.VisiblePlan{Include: m.textFilters(), LevelMap: m.levels, DockerVisible: m.dockerUI.Containers, SinceSeq: m.sinceSeq, UntilSeq: m.untilSeq, Chronological: m.chronological}
+
+	var out []core.LogEvent
+	var prev *core.LogEvent
+	prevStart := 0
+	for _, e := range core.ComputeVisible(m.ring.Snapshot(), plan) {
+		start, ok := m.seqIndex[e.Seq]
+		if !ok {
+			continue
+		}
+		// The previous event spans rows [prevStart, start)
+		if prev != nil && prevStart < bottom && start > top {
+			out = append(out, *prev)
+		}
+		prev, prevStart = &e, start
+	}
+	if prev != nil && prevStart < bottom && len(m.contentLines) > top {
+		out = append(out, *prev)
+	}
+	return out
+}
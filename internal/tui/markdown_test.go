@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestMarkdownSnapshot_Table(t *testing.T) {
+	events := []core.LogEvent{
+		{Line: `{"time":"10:00:01","level":"info","msg":"started","http":{"status":200}}`},
+		{Line: `{"time":"10:00:02","level":"error","msg":"a|b failed"}`},
+		{Line: `plain text line`},
+	}
+	got := markdownSnapshot([]string{"time", "level", "msg", "http.status"}, events)
+	want := strings.Join([]string{
+		"| time | level | msg | http.status |",
+		"| --- | --- | --- | --- |",
+		"| 10:00:01 | info | started | 200 |",
+		`| 10:00:02 | error | a\|b failed |  |`,
+		"| plain text line |  |  |  |",
+	}, "\n")
+	if got != want {
+		t.Errorf("unexpected table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownSnapshot_FencedWithoutColumns(t *testing.T) {
+	got := markdownSnapshot(nil, []core.LogEvent{{Line: "one"}, {Line: "two ``` three"}})
+	want := "````\none\ntwo ``` three\n````"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCopyVisibleRowsAsMarkdown(t *testing.T) {
+	copied := captureClipboard(t)
+	ring := core.NewRing(50)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 8})
+	m = nm.(Model)
+	for i := 0; i < 20; i++ {
+		ring.Append(core.LogEvent{Line: strings.Repeat("x", i+1)})
+	}
+	m = m.updateViewportContent()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	cmd()
+	lines := strings.Split(*copied, "\n")
+	// Fence + one line per visible viewport row + fence
+	if len(lines) != m.vp.Height+2 || lines[len(lines)-2] != strings.Repeat("x", 20) {
+		t.Errorf("expected the %d rows at the tail, got %q", m.vp.Height, *copied)
+	}
+}
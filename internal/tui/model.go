@@ -1,7 +1,7 @@
 package tui
 
 import (
-	"sort"
+	"fmt"
 	"time"
 
 	"regexp"
@@ -23,6 +23,7 @@ const (
 	ModeFile Mode = iota
 	ModeStdin
 	ModeDocker
+	ModeCommand
 )
 
 // PromptKind represents the type of text input prompt currently active
@@ -34,6 +35,11 @@ const (
 	PromptFilterIn
 	PromptFilterOut
 	PromptPresetName
+	PromptDiskFind
+	PromptGotoSeq
+	PromptBufferSize
+	PromptExportPath
+	PromptLevelDiscovery
 )
 
 // DockerUIState manages Docker-specific UI state
@@ -41,12 +47,21 @@ type DockerUIState struct {
 	ContainerListOpen bool
 	PresetManagerOpen bool
 	Containers        map[string]bool // container id/name -> visible
+	Pinned            map[string]bool // containers sorted to the top of the list
 	AllToggle         bool
 	SelectedContainer int              // index in sorted container list for navigation
 	Presets           []persist.Preset // loaded presets for UI
 	SelectedPreset    int              // index in presets list for navigation
 }
 
+// Source controls the input feeding the ring so the model can re-read it.
+type Source interface {
+	// Seekable reports whether the input can be re-read from the start.
+	Seekable() bool
+	// Restart clears the ring and re-reads the input from the start.
+	Restart()
+}
+
 // PerformanceConfig holds performance-related configuration
 type PerformanceConfig struct {
 	MaxLineLength  int           // maximum line length before truncation (default: 2048)
@@ -62,6 +77,14 @@ type Model struct {
 	// Prompt state
 	inPrompt   bool
 	promptKind PromptKind
+	promptErr  string // pattern error shown inline while the prompt stays open
+	history    promptHistory
+
+	// Live check of the pattern being typed, for the ✓/✗ hint: the text it
+	// was made for, and whether it is a regex that compiled or why it failed
+	patternFor   string
+	patternValid bool
+	patternErr   error
 
 	// Data and filters
 	ring    *core.Ring
@@ -69,14 +92,57 @@ type Model struct {
 	search  *core.SearchState
 	levels  *core.LevelMap
 
+	// Input source for reload/replay; nil behaves like a non-seekable source
+	source Source
+
 	// Docker UI state
 	dockerUI DockerUIState
-	presets  *persist.PresetsManager
+
+	// Per-container log rates for the container list sparklines
+	rates           *rateTracker
+	showSparklines  bool
+	sparklines      map[string]string // container -> rendered sparkline
+	lastSparkUpdate time.Time
+	presets         *persist.PresetsManager
 
 	// Clear menu state
 	clearMenuOpen bool
 	clearMenuSel  int // 0..N-1
 
+	// Filters overlay with per-filter match counts
+	filtersOpen bool
+	filterHits  core.FilterHits
+
+	// Find over the whole file on disk (file mode)
+	diskPath string
+	diskFind diskFindState
+
+	// Paths of the tailed files by event origin, for file:line references
+	sourcePaths map[string]string
+
+	// List of all find matches
+	findList findListState
+
+	// Command palette
+	palette paletteState
+
+	// Theme picker with a sample line per theme
+	themePicker themePickerState
+
+	// Tab focus through the visible containers (docker mode)
+	containerCycle containerCycleState
+
+	// While following, stay on the newest find hit instead of the bottom
+	findFollow bool
+
+	// Histogram of message templates
+	topMessages topMessagesState
+
+	// Sequence window: events before sinceSeq or after untilSeq are hidden
+	// (0 = no cut)
+	sinceSeq uint64
+	untilSeq uint64
+
 	// Performance configuration
 	perf PerformanceConfig
 
@@ -89,22 +155,93 @@ type Model struct {
 	errTime    time.Time // timestamp of the error for auto-clearing
 
 	// Throttling for smooth updates
-	lastRender time.Time
-	dirty      bool // needs re-render
+	lastRender  time.Time
+	dirty       bool         // needs re-render
+	renderCache *renderCache // per-event visibility and styled rows
+
+	// Idle follow pause: stop auto-following after no key input for idleTimeout
+	idleTimeout time.Duration // 0 disables
+	lastKeyTime time.Time
+	tapKey      string // last main-view key, for double-press detection
+	idlePaused  bool
+
+	// Bookmarked sequence numbers, sorted; bookmarkAt is the last one visited
+	bookmarks  []uint64
+	bookmarkAt uint64
+
+	// Order the view by line timestamps rather than arrival (--chrono)
+	chronological bool
+
+	// A separator row marks gaps longer than burstGap between visible lines
+	burstGap time.Duration // 0 disables
+
+	// Non-recoverable input error that ended the session
+	fatalErr error
+
+	// Prefix columns are joined with prefixSep and padded to prefixPad
+	// columns (0 = no padding) before the message
+	prefixSep string
+	prefixPad int
+
+	// Startup loading state (file mode), cleared on the first content refresh
+	loading      bool
+	loadLines    int
+	loadBytes    int64
+	loadStarted  time.Time
+	loadFrameIdx int
 
 	// Sequence -> current line index mapping
 	seqIndex map[uint64]int
+	// Filter generation the viewport content was last built with
+	renderedFilterGen uint64
+	// Events that passed the filters in the last content refresh, out of
+	// bufferedCount in the ring
+	visibleCount  int
+	bufferedCount int
+	hidden        hiddenCounts // why, while none of the buffered lines is shown
 
 	// Cached content lines (styled and plain) currently set in the viewport
 	contentLines      []string // includes ANSI styling
 	contentPlainLines []string // ANSI stripped for selection/copy
 
 	// Theme
-	theme    *Theme
-	themeIdx int
+	theme          *Theme
+	themeIdx       int
+	themeOverrides map[string]string // per-style overrides merged onto the base theme
+
+	// Display names for containers, keyed by real name or ID
+	containerAliases map[string]string
+
+	// JSON fields used as table columns in Markdown snapshots
+	columns []string
+
+	// Keywords highlighted by the ops profile (defaults when empty)
+	opsKeywords []string
+
+	// With the "warn" level overflow, how many overflowed levels were reported
+	warnOverflow   bool
+	overflowWarned int
+
+	// Lines/s and error rate in the status line, refreshed once per second
+	showStats       bool
+	stats           *rateTracker
+	statsText       string
+	lastStatsUpdate time.Time
+
+	// ] and [ jump between error lines
+	errorNav    bool
+	errorCursor uint64 // last error jumped to
+
+	// Arguments selecting the input, for duplicating the session (nil: stdin)
+	launchArgs []string
+
+	// Alert patterns posted to a webhook when they match new lines
+	alerts  []core.TextMatcher
+	webhook *alertWebhook
 
 	// Selection-friendly mode (mouse disabled, alt screen off)
 	selectionMode bool
+	mouseCapture  bool // false when started with --no-mouse
 
 	// Mouse selection state within the viewport
 	selecting bool
@@ -116,8 +253,44 @@ type Model struct {
 	// Help overlay
 	helpOpen bool
 
+	// Transient popup with the full text of a clipped line (click to open)
+	peekOpen bool
+	peekLine string
+
+	// Line wrapping; when off, xOffset scrolls the message past the prefix
+	wrapLines bool
+	xOffset   int
+
+	// Render control bytes in caret notation (^A, \xNN)
+	showControl bool
+
+	// Hide the [container] / [file] prefix column
+	hideSource bool
+
+	// JSON events shown indented over several rows, by sequence
+	expandedJSON map[uint64]bool
+
+	// Peek: include/exclude filters are bypassed but kept
+	peeking bool
+
+	// Pause freezes the view: pauseEnd is the first sequence it hides, one
+	// past the newest seen when it started
+	paused   bool
+	pauseEnd uint64
+
+	// Input backpressure over the last second, reported by the reader pump
+	overload OverloadMsg
+
+	// Time zone and layout timestamps are shown in
+	location   *time.Location
+	timeLayout string
+
 	// Settings
 	showTimestamps   bool
+	compactTime      bool // with timestamps on, print them only when the second changes
+	linkify          bool // emphasize URLs and paths; URLs become OSC 8 links
+	decodeCopies     bool // decode JSON/URL/base64 in single-line selections on copy
+	severityDisplay  int  // severityBadge, severityBar or severityNone
 	settingsMenuOpen bool
 	settingsSel      int // 0..N-1
 	settingsStore    *persist.SettingsManager
@@ -137,6 +310,9 @@ func NewModel(ring *core.Ring, filters *core.Filters, search *core.SearchState,
 	// Initialize presets manager (ignore error for now)
 	presetsManager, _ := persist.NewPresetsManager()
 
+	// Level slots learn their discovery lines as events are appended
+	ring.OnAppend(levels.NoteEvent)
+
 	m := &Model{
 		vp:         vp,
 		input:      input,
@@ -148,6 +324,7 @@ func NewModel(ring *core.Ring, filters *core.Filters, search *core.SearchState,
 		followTail: true,
 		dockerUI: DockerUIState{
 			Containers: make(map[string]bool),
+			Pinned:     make(map[string]bool),
 			AllToggle:  true,
 		},
 		presets: presetsManager,
@@ -158,9 +335,21 @@ func NewModel(ring *core.Ring, filters *core.Filters, search *core.SearchState,
 		width:          80,
 		height:         24,
 		seqIndex:       make(map[uint64]int),
+		renderCache:    newRenderCache(),
+		rates:          newRateTracker(sparkWindow),
+		stats:          newRateTracker(statsWindow),
 		theme:          DarkTheme(),
 		themeIdx:       0,
 		showTimestamps: true,
+		location:       time.Local,
+		timeLayout:     timestampLayout,
+		prefixSep:      " ",
+		wrapLines:      true,
+		mouseCapture:   true,
+		loading:        mode == ModeFile,
+		loadStarted:    time.Now(),
+		lastKeyTime:    time.Now(),
+		warnOverflow:   levels.Overflow() == core.OverflowWarn,
 	}
 
 	// Load persisted settings (best-effort; ignore errors)
@@ -168,6 +357,25 @@ func NewModel(ring *core.Ring, filters *core.Filters, search *core.SearchState,
 		m.settingsStore = sm
 		if s, err := sm.Load(); err == nil {
 			m.showTimestamps = s.ShowTimestamps
+			m.compactTime = s.CompactTimestamps
+			m.linkify = s.Links
+			m.decodeCopies = s.DecodeCopies
+			m.severityDisplay = parseSeverityDisplay(s.SeverityDisplay)
+			for _, name := range s.PinnedContainers {
+				m.dockerUI.Pinned[name] = true
+			}
+			m.opsKeywords = s.OpsKeywords
+			if s.PrefixSeparator != "" {
+				m.prefixSep = s.PrefixSeparator
+			}
+			m.prefixPad = max(s.PrefixWidth, 0)
+			if s.SavePromptHistory {
+				m.history.save = true
+				m.loadHistory(s.PromptHistory)
+			}
+			if err := m.SetThemeOverrides(s.ThemeOverrides); err != nil {
+				*m = m.setError("Ignoring theme overrides: " + err.Error())
+			}
 			// Theme may be overridden by CLI; we still initialize index
 			m.SetTheme(s.Theme)
 		}
@@ -195,6 +403,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.MouseMsg:
 		// Custom selection + copy handler (left drag, copy on release)
+		if m.peekOpen {
+			// Any click dismisses the peek popup
+			if msg.Action == tea.MouseActionPress {
+				m.peekOpen = false
+			}
+			break
+		}
 		if !m.helpOpen && !m.dockerUI.ContainerListOpen && !m.dockerUI.PresetManagerOpen && !m.clearMenuOpen {
 			vpTopY := 1
 			vpBottomY := vpTopY + m.vp.Height - 1
@@ -225,9 +440,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.selEndY = clamp(msg.Y-vpTopY, 0, m.vp.Height-1)
 						if len(m.contentPlainLines) > 0 {
 							if selected := m.extractSelectedText(); strings.TrimSpace(selected) != "" {
-								if cmd := copySelectionCmd(selected); cmd != nil {
+								if cmd := m.selectionCopyCmd(selected, msg.Alt); cmd != nil {
 									cmds = append(cmds, cmd)
 								}
+							} else if m.selStartX == m.selEndX && m.selStartY == m.selEndY {
+								// A plain click on a clipped row peeks at the full line
+								if line, ok := m.clippedLineAt(m.vp.YOffset + m.selEndY); ok {
+									m.peekOpen = true
+									m.peekLine = line
+								}
 							}
 						}
 						m.selecting = false
@@ -244,18 +465,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m = m.updateFollowTail()
 
 	case tea.KeyMsg:
+		prevKeyTime := m.lastKeyTime
+		m.lastKeyTime = time.Now()
+		doubled := m.isDoubleTap(msg.String(), m.lastKeyTime.Sub(prevKeyTime))
+		m.tapKey = ""
+		if m.idlePaused {
+			// Any key resumes following and catches up to the tail
+			m.idlePaused = false
+			if m.followTail {
+				m.vp.GotoBottom()
+			}
+			m.dirty = true
+		}
+		if m.peekOpen {
+			// Any key dismisses the peek popup
+			m.peekOpen = false
+			return m, nil
+		}
 		// Key handling branches below
-		if m.inPrompt {
+		if doubled {
+			m = doubleTapActions[msg.String()](m)
+			m.dirty = true
+		} else if m.inPrompt {
 			// Handle prompt-specific keys
 			switch msg.String() {
 			case "enter":
+				kind, pattern := m.promptKind, m.input.Value()
 				m = m.handlePromptSubmit()
+				if kind == PromptDiskFind && !m.inPrompt && pattern != "" {
+					cmds = append(cmds, m.diskFindCmd(pattern))
+				}
 			case "esc":
 				m = m.cancelPrompt()
+			case "up", "down":
+				m = m.recallHistory(msg.String() == "up")
+				m = m.updatePatternHint()
 			default:
-				// Pass other keys to text input
+				// Pass other keys to text input; editing clears a pattern error
 				var cmd tea.Cmd
 				m.input, cmd = m.input.Update(msg)
+				m.promptErr = ""
+				m = m.updatePatternHint()
 				cmds = append(cmds, cmd)
 			}
 		} else if m.dockerUI.ContainerListOpen {
@@ -265,6 +515,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			case "esc", "enter", "q":
 				m.dockerUI.ContainerListOpen = false
+			case "s":
+				m.showSparklines = !m.showSparklines
+				if m.showSparklines {
+					m = m.refreshSparklines(time.Now())
+				}
 			case "up":
 				m = m.navigateContainerList(true) // up
 			case "down":
@@ -273,6 +528,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.toggleSelectedContainer()
 			case "a":
 				m = m.toggleAllContainers()
+			case "p":
+				m = m.togglePinSelected()
 			}
 		} else if m.dockerUI.PresetManagerOpen {
 			// Handle Docker preset manager navigation
@@ -294,12 +551,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "r":
 				m = m.refreshPresetsList()
 			}
+		} else if m.palette.open {
+			var key *tea.KeyMsg
+			m, key = m.handlePaletteKey(msg)
+			m.dirty = true
+			if key != nil {
+				nm, cmd := m.Update(*key)
+				m = nm.(Model)
+				cmds = append(cmds, cmd)
+			}
 		} else if m.helpOpen {
 			// Help overlay interactions
 			switch msg.String() {
 			case "q", "esc", "?", "enter", "f1":
 				m.helpOpen = false
 			}
+		} else if m.diskFind.open {
+			switch msg.String() {
+			case "ctrl+q", "ctrl+c":
+				return m, tea.Quit
+			default:
+				m = m.handleDiskFindKey(msg.String())
+			}
+		} else if m.findList.open {
+			switch msg.String() {
+			case "ctrl+q", "ctrl+c":
+				return m, tea.Quit
+			default:
+				m = m.handleFindListKey(msg.String())
+			}
+		} else if m.themePicker.open {
+			switch msg.String() {
+			case "ctrl+q", "ctrl+c":
+				return m, tea.Quit
+			default:
+				m = m.handleThemePickerKey(msg.String())
+			}
+		} else if m.topMessages.open {
+			switch msg.String() {
+			case "ctrl+q", "ctrl+c":
+				return m, tea.Quit
+			default:
+				m = m.handleTopMessagesKey(msg.String())
+			}
+		} else if m.filtersOpen {
+			switch msg.String() {
+			case "ctrl+q", "ctrl+c":
+				return m, tea.Quit
+			case "esc", "q", "i", "enter":
+				m.filtersOpen = false
+			}
 		} else if m.settingsMenuOpen {
 			switch msg.String() {
 			case "ctrl+q", "ctrl+c":
@@ -310,10 +611,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.settingsSel > 0 {
 					m.settingsSel--
 				} else {
-					m.settingsSel = 1
+					m.settingsSel = settingsItems - 1
 				}
 			case "down":
-				if m.settingsSel < 1 {
+				if m.settingsSel < settingsItems-1 {
 					m.settingsSel++
 				} else {
 					m.settingsSel = 0
@@ -329,13 +630,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.persistSettings()
 				}
 			case "enter", " ":
-				if m.settingsSel == 0 { // toggle timestamps
-					m.showTimestamps = !m.showTimestamps
+				if m.settingsSel == 0 { // cycle timestamps: on, compact, off
+					switch {
+					case m.showTimestamps && !m.compactTime:
+						m.compactTime = true
+					case m.showTimestamps:
+						m.showTimestamps, m.compactTime = false, false
+					default:
+						m.showTimestamps = true
+					}
 					m.dirty = true
 					m.persistSettings()
 				} else if m.settingsSel == 1 { // theme next
 					m.cycleTheme(1)
 					m.persistSettings()
+				} else if m.settingsSel == 2 { // toggle link detection
+					m.linkify = !m.linkify
+					m.dirty = true
+					m.persistSettings()
+				} else if m.settingsSel == 3 { // toggle decoding copies
+					m.decodeCopies = !m.decodeCopies
+					m.persistSettings()
+				} else if m.settingsSel == 4 { // cycle badge/bar/none
+					m.severityDisplay = (m.severityDisplay + 1) % len(severityDisplayNames)
+					m.dirty = true
+					m.persistSettings()
 				}
 			}
 		} else if m.clearMenuOpen {
@@ -377,6 +696,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		} else {
 			// Handle main app keys
+			m.tapKey = msg.String()
 			switch msg.String() {
 			case "ctrl+q", "ctrl+c":
 				return m, tea.Quit
@@ -386,6 +706,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.startPrompt(PromptHighlight, "Highlight: ")
 			case "ctrl+f":
 				m = m.startPrompt(PromptFind, "Find: ")
+			case "ctrl+g":
+				if m.diskPath == "" {
+					m = m.setError("Disk find needs a single file source")
+					break
+				}
+				m = m.startPrompt(PromptDiskFind, "Find on disk: ")
+			case "A":
+				m = m.toggleFindCase()
+			case "G":
+				m.findFollow = !m.findFollow
+				m.dirty = true
+				if m.findFollow {
+					m = m.setError("Find follow on: new matches are jumped to while following")
+				} else {
+					m = m.setError("Find follow off")
+				}
+			case "l":
+				m = m.openFindList()
 			case "ctrl+o":
 				m.settingsMenuOpen = true
 				m.settingsSel = 0
@@ -393,9 +731,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.startPrompt(PromptFilterIn, "Filter In: ")
 			case "O":
 				m = m.startPrompt(PromptFilterOut, "Filter Out: ")
-			case "0":
-				m.levels.EnableAll()
-				m.dirty = true
+			case "F":
+				m = m.filterBySelection()
+			case "i":
+				m.filtersOpen = true
+				m = m.refreshFilterHits()
+			case "T":
+				m = m.openTopMessages()
+			case "P":
+				m = m.togglePeek()
 			case "home":
 				m.vp.GotoTop()
 				m.followTail = false
@@ -409,6 +753,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.dirty = true
 					break
 				}
+				var ended bool
+				if m, ended = m.endContainerFocus(); ended {
+					m = m.setError("Container focus ended")
+				}
 			case "c":
 				m.clearMenuOpen = true
 				m.clearMenuSel = 0
@@ -416,8 +764,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.clearAllFilters()
 			case "?", "f1":
 				m.helpOpen = true
+			case ":", "ctrl+p":
+				m.palette = paletteState{open: true}
 
-				// Find navigation (only when find is active)
+			// Find navigation (only when find is active)
 			case "up":
 				if m.search.IsActive() {
 					m = m.navigateFind(true) // previous
@@ -473,31 +823,134 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					m.dirty = true
 				}
+			case "0":
+				m.levels.EnableAll()
+				m.dirty = true
+			case "K":
+				m = m.startPrompt(PromptLevelDiscovery, "slot 1-9 or level name")
+			case "o":
+				m = m.applyOpsProfile()
+
+			// Navigation
+			case "g":
+				m = m.startPrompt(PromptGotoSeq, "Go to line #: ")
+			case "m":
+				m = m.toggleBookmark()
+			case "'", "\"":
+				m = m.cycleBookmark(msg.String() == "'")
+			case "]", "[":
+				if m.errorNav {
+					m = m.jumpToError(msg.String() == "[")
+				}
+			case "{":
+				m = m.toggleCut(false)
+			case "}":
+				m = m.toggleCut(true)
+			case " ":
+				m = m.togglePause()
+			case "left", "right":
+				if !m.wrapLines {
+					m = m.scrollHorizontal(msg.String() == "right")
+				}
 
-			// Docker mode keys
-			case "ctrl+d":
-				if m.mode == ModeDocker {
-					m.dockerUI.ContainerListOpen = !m.dockerUI.ContainerListOpen
-					m.dockerUI.SelectedContainer = -1 // Reset selection to "All"
+			// Display
+			case "w":
+				m.wrapLines = !m.wrapLines
+				m.xOffset = 0
+				m.dirty = true
+				if m.wrapLines {
+					m = m.setError("Wrap on")
+				} else {
+					m = m.setError("Wrap off: Left/Right scroll the message")
 				}
-			case "p":
-				if m.mode == ModeDocker {
-					m.dockerUI.PresetManagerOpen = true
-					m.dockerUI.SelectedPreset = 0
-					m = m.refreshPresetsList()
+			case "n":
+				m.hideSource = !m.hideSource
+				m.dirty = true
+				if m.hideSource {
+					m = m.setError("Container/file names hidden")
+				} else {
+					m = m.setError("Container/file names shown")
+				}
+			case "V":
+				m.showControl = !m.showControl
+				m.dirty = true
+				if m.showControl {
+					m = m.setError("Control characters shown as ^X / \\xNN")
+				} else {
+					m = m.setError("Control characters hidden")
 				}
+			case "j":
+				m = m.toggleJSON()
 			case "t":
 				// Cycle theme
-				m.themeIdx = (m.themeIdx + 1) % len(themes)
-				m.theme = themes[m.themeIdx]
+				m.cycleTheme(1)
+				m.persistSettings()
+			case "ctrl+t":
+				m = m.openThemePicker()
+			case "d":
+				// Toggle timestamps; turning them back on keeps the compact choice
+				m.showTimestamps = !m.showTimestamps
 				m.dirty = true
 				m.persistSettings()
+
+			// Copy and export
+			case "y":
+				if !m.search.IsActive() || m.search.Current() == 0 {
+					m = m.setError("No current find match")
+					break
+				}
+				e, ok := m.ring.GetBySeq(m.search.Current())
+				if !ok {
+					m = m.setError("Find match was evicted")
+					break
+				}
+				cmds = append(cmds, copyTextCmd(e.Line, "Copied line"))
+			case "Y":
+				if !m.search.IsActive() || strings.TrimSpace(m.search.GetMatcher().Raw()) == "" {
+					m = m.setError("No active find pattern")
+					break
+				}
+				cmds = append(cmds, copyTextCmd(m.search.GetMatcher().Raw(), "Pattern copied"))
+			case "J":
+				var cmd tea.Cmd
+				m, cmd = m.copyEventJSON()
+				cmds = append(cmds, cmd)
+			case "f":
+				var cmd tea.Cmd
+				m, cmd = m.copyFileRef()
+				cmds = append(cmds, cmd)
+			case "M":
+				events := m.visibleEvents()
+				if len(events) == 0 {
+					m = m.setError("Nothing visible to copy")
+					break
+				}
+				cmds = append(cmds, copyTextCmd(markdownSnapshot(m.columns, events),
+					fmt.Sprintf("Copied %d rows as Markdown", len(events))))
+			case "L":
+				cmds = append(cmds, copyTextCmd(core.FormatLevelLegend(m.levels.Slots(), false), "Level legend copied"))
+			case "ctrl+y":
+				expr := filterExpression(m.filters)
+				if expr == "" {
+					m = m.setError("No active filters")
+					break
+				}
+				cmds = append(cmds, copyTextCmd(expr, "Filters copied"))
+			case "e":
+				m = m.startPrompt(PromptExportPath, "file path for the visible lines")
+			case "D":
+				var cmd tea.Cmd
+				m, cmd = m.duplicateSession()
+				cmds = append(cmds, cmd)
 			case "ctrl+s":
 				if m.selectionMode {
 					// Return to interactive mode: re-enter alt screen and re-enable mouse
 					m.selectionMode = false
 					m = m.setError("Selection mode off")
-					cmds = append(cmds, tea.EnterAltScreen, tea.EnableMouseCellMotion)
+					cmds = append(cmds, tea.EnterAltScreen)
+					if m.mouseCapture {
+						cmds = append(cmds, tea.EnableMouseCellMotion)
+					}
 				} else {
 					// Enable selection: disable mouse + exit alt screen
 					m.selectionMode = true
@@ -505,6 +958,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, tea.DisableMouse, tea.ExitAltScreen)
 				}
 
+			// Input
+			case "ctrl+r":
+				var cmd tea.Cmd
+				m, cmd = m.reload()
+				cmds = append(cmds, cmd)
+			case "R":
+				var cmd tea.Cmd
+				m, cmd = m.replay()
+				cmds = append(cmds, cmd)
+			case "B":
+				m = m.startPrompt(PromptBufferSize, fmt.Sprintf("%d (%d–%d)", m.ring.Capacity(), minBufferSize, maxBufferSize))
+
+			// Docker mode keys
+			case "ctrl+d":
+				if m.mode == ModeDocker {
+					m.dockerUI.ContainerListOpen = !m.dockerUI.ContainerListOpen
+					m.dockerUI.SelectedContainer = -1 // Reset selection to "All"
+				}
+			case "p":
+				m.dockerUI.PresetManagerOpen = true
+				m.dockerUI.SelectedPreset = 0
+				m = m.refreshPresetsList()
+			case "tab", "shift+tab":
+				if m.mode == ModeDocker {
+					delta := 1
+					if msg.String() == "shift+tab" {
+						delta = -1
+					}
+					m = m.cycleContainerFocus(delta)
+				}
+
 				// Viewport navigation
 			default:
 				var cmd tea.Cmd
@@ -514,6 +998,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case diskFindMsg:
+		m = m.handleDiskFindResult(msg)
+
+	case duplicateResultMsg:
+		m = m.setError("Duplicate failed: " + msg.err.Error())
+
+	case alertResultMsg:
+		m = m.setError("Alert webhook failed: " + msg.err.Error())
+
 	case clipboardResultMsg:
 		if msg.message != "" {
 			m = m.setError(msg.message)
@@ -526,16 +1019,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case refreshMsg:
 		// Force refresh of visible content
+		m.loading = false
 		m = m.refreshContent()
 
+	case OverloadMsg:
+		m.overload = msg
+		m.dirty = true
+
+	case LoadProgressMsg:
+		if m.loading {
+			m.loadLines = msg.Lines
+			m.loadBytes = msg.Bytes
+		}
+
 	case LogAppendedMsg:
+		if msg.Event.Container != "" {
+			m.rates.Add(msg.Event.Container, time.Now())
+		}
+		m.recordStats(msg.Event, time.Now())
+		if cmd := m.checkAlerts(msg.Event); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 		// When find is active, add new hits incrementally
 		if m.search.IsActive() {
 			matcher := m.search.GetMatcher()
 			if matcher.Match(msg.Event.Line) {
 				m.search.AddHit(msg.Event.Seq)
-				// If we’re following tail and this is a new match, optionally auto-jump?
-				// Keeping behavior passive; user navigates with up/down.
+				// Passive unless find follow is on: the next render scrolls
+				// to the new hit while following
+				if m.findFollow && m.followTail && !m.paused {
+					m.search.SetCurrentBySeq(msg.Event.Seq)
+					m.dirty = true
+				}
 			}
 		}
 
@@ -543,6 +1058,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update container list from Docker reader
 		m = m.updateDockerContainers(msg.Containers)
 
+	case InputFailedMsg:
+		m.fatalErr = msg.Err
+		return m, tea.Quit
+
 	case DockerErrorMsg:
 		// Handle Docker connection errors
 		if msg.Error == nil {
@@ -558,12 +1077,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-// SetTheme applies the theme by name; falls back to dark.
+// SetTheme applies the theme by name; falls back to dark. Any theme
+// overrides are merged on top of the base theme.
 func (m *Model) SetTheme(name string) {
-	m.theme = themeByName(name)
+	base := themeByName(name)
+	m.theme = applyThemeOverrides(base, m.themeOverrides)
 	// sync index for cycling
 	for i, t := range themes {
-		if t.Name == m.theme.Name {
+		if t.Name == base.Name {
 			m.themeIdx = i
 			break
 		}
@@ -571,6 +1092,98 @@ func (m *Model) SetTheme(name string) {
 	m.dirty = true
 }
 
+// SetThemeOverrides validates and stores per-style overrides (e.g.
+// "error": "dracula" or "highlight": "#ffaf00") and reapplies the theme.
+// Invalid overrides are rejected and leave the current theme untouched.
+func (m *Model) SetThemeOverrides(overrides map[string]string) error {
+	if err := ValidateThemeOverrides(overrides); err != nil {
+		return err
+	}
+	m.themeOverrides = overrides
+	if m.theme != nil {
+		m.SetTheme(m.theme.Name)
+	}
+	return nil
+}
+
+// SetContainerAliases sets display names for containers, keyed by real name
+// or ID. Visibility and presets keep using the real names.
+func (m *Model) SetContainerAliases(aliases map[string]string) {
+	m.containerAliases = aliases
+	m.dirty = true
+}
+
+// containerLabel returns the alias for a container, or its real name
+func (m Model) containerLabel(name string) string {
+	if alias, ok := m.containerAliases[name]; ok && alias != "" {
+		return alias
+	}
+	return name
+}
+
+// SetColumns sets the JSON fields used as Markdown table columns.
+func (m *Model) SetColumns(columns []string) {
+	m.columns = columns
+}
+
+// SetLinkify turns URL and path emphasis on or off.
+func (m *Model) SetLinkify(enabled bool) {
+	m.linkify = enabled
+	m.dirty = true
+}
+
+// SetSource sets the input source used by reload and replay.
+func (m *Model) SetSource(src Source) {
+	m.source = src
+}
+
+// SetMouseCapture records whether the program captures the mouse. When false,
+// leaving selection mode does not re-enable mouse reporting.
+func (m *Model) SetMouseCapture(enabled bool) {
+	m.mouseCapture = enabled
+}
+
+// SetLocation sets the time zone timestamps are shown in.
+func (m *Model) SetLocation(loc *time.Location) {
+	m.location = loc
+	m.dirty = true
+}
+
+// SetTimeFormat sets the Go layout of the timestamp column; empty keeps the
+// default.
+func (m *Model) SetTimeFormat(layout string) {
+	if layout == "" {
+		layout = timestampLayout
+	}
+	m.timeLayout = layout
+	m.dirty = true
+}
+
+// SetBurstGap separates bursts of lines with a rule when consecutive visible
+// lines are more than gap apart. Zero disables it.
+func (m *Model) SetBurstGap(gap time.Duration) {
+	m.burstGap = gap
+	m.dirty = true
+}
+
+// SetChronological orders the view by the timestamps lines carry instead of
+// by arrival, merging several inputs into one timeline.
+func (m *Model) SetChronological(enabled bool) {
+	m.chronological = enabled
+	m.dirty = true
+}
+
+// SetStatus shows msg in the status line, e.g. a setting that was ignored.
+func (m *Model) SetStatus(msg string) {
+	*m = m.setError(msg)
+}
+
+// SetIdleTimeout sets how long without key input before auto-follow pauses.
+// Zero disables the idle pause.
+func (m *Model) SetIdleTimeout(d time.Duration) {
+	m.idleTimeout = d
+}
+
 // cycleTheme moves theme index by delta and applies it.
 func (m *Model) cycleTheme(delta int) {
 	if len(themes) == 0 {
@@ -580,7 +1193,7 @@ func (m *Model) cycleTheme(delta int) {
 	if m.themeIdx < 0 {
 		m.themeIdx += len(themes)
 	}
-	m.theme = themes[m.themeIdx]
+	m.theme = applyThemeOverrides(themes[m.themeIdx], m.themeOverrides)
 	m.dirty = true
 }
 
@@ -589,10 +1202,23 @@ func (m *Model) persistSettings() {
 	if m.settingsStore == nil {
 		return
 	}
-	_ = m.settingsStore.Save(persist.Settings{
-		ShowTimestamps: m.showTimestamps,
-		Theme:          m.theme.Name,
-	})
+	// Start from the stored settings so hand-edited keys like aliases survive
+	s, _ := m.settingsStore.Load()
+	s.ShowTimestamps = m.showTimestamps
+	s.CompactTimestamps = m.compactTime
+	s.Links = m.linkify
+	s.DecodeCopies = m.decodeCopies
+	s.SeverityDisplay = ""
+	if m.severityDisplay != severityBadge {
+		s.SeverityDisplay = severityDisplayNames[m.severityDisplay]
+	}
+	s.PinnedContainers = m.pinnedContainers()
+	s.Theme = m.theme.Name
+	s.ThemeOverrides = m.themeOverrides
+	if m.history.save {
+		s.PromptHistory = m.historySettings()
+	}
+	_ = m.settingsStore.Save(s)
 }
 
 // handleResize adjusts viewport and other components to new terminal size
@@ -620,29 +1246,89 @@ func (m Model) startPrompt(kind PromptKind, placeholder string) Model {
 	m.input.Placeholder = placeholder
 	m.input.SetValue("")
 	m.input.Focus()
+	m.promptErr = ""
+	m.patternFor, m.patternValid, m.patternErr = "", false, nil
+	m.history.pos = -1
+	return m
+}
+
+// updatePatternHint compiles the prompt text when it changed, without
+// keeping the matcher, to hint whether a regex (or any pattern that fails)
+// is valid before Enter
+func (m Model) updatePatternHint() Model {
+	text := m.input.Value()
+	if text == m.patternFor {
+		return m
+	}
+	m.patternFor, m.patternValid, m.patternErr = text, false, nil
+	newMatcher := core.NewMatcher
+	switch m.promptKind {
+	case PromptFind, PromptHighlight, PromptFilterOut:
+	case PromptFilterIn:
+		newMatcher = core.NewIncludeMatcher
+	default:
+		return m
+	}
+	matcher, err := newMatcher(text)
+	m.patternErr = err
+	m.patternValid = err == nil && matcher.IsRegex()
 	return m
 }
 
 // cancelPrompt cancels the current prompt and returns to normal mode
 func (m Model) cancelPrompt() Model {
 	m.inPrompt = false
+	m.promptErr = ""
 	m.input.Blur()
 	return m
 }
 
-// handlePromptSubmit processes the entered text based on prompt type
+// handlePromptSubmit processes the entered text based on prompt type. An
+// invalid pattern keeps the prompt open with the text intact so it can be
+// fixed in place.
 func (m Model) handlePromptSubmit() Model {
 	text := m.input.Value()
-	m = m.cancelPrompt()
-
 	if text == "" {
-		return m
+		return m.cancelPrompt()
 	}
 
-	matcher, err := core.NewMatcher(text)
-	if err != nil {
-		return m.setError("Invalid pattern: " + err.Error())
+	if m.promptKind == PromptGotoSeq {
+		seq, err := parseSequence(text)
+		if err != nil {
+			m.promptErr = "Not a line number: " + text
+			m.input.CursorEnd()
+			return m
+		}
+		return m.recordHistory(PromptGotoSeq, text).cancelPrompt().gotoSequence(seq)
 	}
+	if m.promptKind == PromptBufferSize {
+		capacity, err := parseBufferSize(text)
+		if err != nil {
+			m.promptErr = err.Error()
+			m.input.CursorEnd()
+			return m
+		}
+		return m.cancelPrompt().resizeBuffer(capacity)
+	}
+	if m.promptKind == PromptLevelDiscovery {
+		return m.cancelPrompt().gotoLevelDiscovery(text)
+	}
+	if m.promptKind == PromptExportPath {
+		return m.cancelPrompt().exportVisible(strings.TrimSpace(text))
+	}
+
+	newMatcher := core.NewMatcher
+	if m.promptKind == PromptFilterIn {
+		newMatcher = core.NewIncludeMatcher // "+pattern" is a required include
+	}
+	matcher, err := newMatcher(text)
+	if err != nil && m.promptKind != PromptPresetName {
+		m.promptErr = "Invalid pattern: " + err.Error()
+		m.input.CursorEnd()
+		return m
+	}
+	m = m.recordHistory(m.promptKind, text)
+	m = m.cancelPrompt()
 
 	switch m.promptKind {
 	case PromptHighlight:
@@ -651,26 +1337,36 @@ func (m Model) handlePromptSubmit() Model {
 		m.search.SetMatcher(matcher)
 		m.search.SetActive(true)
 		m = m.refreshFindIndex()
-		// Jump to first match if found
-		if seq := m.search.JumpToFirst(); seq != 0 {
+		// Resume where this pattern was last left, else jump to the first match
+		if seq := m.search.RestoreCursor(); seq != 0 {
+			m = m.scrollToSequence(seq)
+			return m.setError(fmt.Sprintf("Find resumed at #%d", seq))
+		} else if seq := m.search.JumpToFirst(); seq != 0 {
 			m = m.scrollToSequence(seq)
 		}
 	case PromptFilterIn:
 		m.filters.AddInclude(matcher)
 	case PromptFilterOut:
 		m.filters.AddExclude(matcher)
+	case PromptDiskFind:
+		return m.setError("Searching the whole file…")
 	case PromptPresetName:
-		// Save current container visibility as a preset
-		if m.mode == ModeDocker && m.presets != nil {
-			preset := persist.CreatePresetFromCurrent(text, m.dockerUI.Containers)
-			if err := m.presets.SavePreset(preset); err != nil {
-				return m.setError("Failed to save preset: " + err.Error())
-			} else {
-				m = m.setError("Preset '" + text + "' saved successfully")
-				m = m.refreshPresetsList() // Refresh the presets list
+		// Save current container visibility (Docker) or filters as a preset
+		if m.presets == nil {
+			return m.setError("Presets manager not available")
+		}
+		preset := persist.CreatePresetFromCurrent(text, m.dockerUI.Containers)
+		if m.mode != ModeDocker {
+			preset = persist.CreateFilterPreset(text, m.filters)
+			if len(preset.Includes)+len(preset.Excludes)+len(preset.Highlights) == 0 {
+				return m.setError("No filters or highlights to save")
 			}
+		}
+		if err := m.presets.SavePreset(preset); err != nil {
+			return m.setError("Failed to save preset: " + err.Error())
 		} else {
-			return m.setError("Presets are only available in Docker mode")
+			m = m.setError("Preset '" + text + "' saved successfully")
+			m = m.refreshPresetsList() // Refresh the presets list
 		}
 		// Don't use matcher for preset names, so exit early
 		m.dirty = true
@@ -702,25 +1398,100 @@ func (m Model) navigateFind(isPrev bool) Model {
 	return m
 }
 
+// eventAtRow returns the event rendered on the given content row
+func (m Model) eventAtRow(row int) (core.LogEvent, bool) {
+	var (
+		found core.LogEvent
+		best  = -1
+	)
+	for _, e := range m.ring.Snapshot() {
+		if start, ok := m.seqIndex[e.Seq]; ok && start <= row && start > best {
+			found, best = e, start
+		}
+	}
+	return found, best >= 0 && row < len(m.contentLines)
+}
+
+// clippedLineAt returns the full text of the event on row when wrapping is
+// off and the row doesn't show all of it.
+func (m Model) clippedLineAt(row int) (string, bool) {
+	if m.wrapLines {
+		return "", false
+	}
+	e, ok := m.eventAtRow(row)
+	if !ok {
+		return "", false
+	}
+	full := m
+	full.xOffset = 0
+	if m.xOffset == 0 && xansi.StringWidth(full.renderEventWithFullStyling(e)) <= m.vp.Width-m.gutterWidth() {
+		return "", false
+	}
+	return e.Line, true
+}
+
+// hScrollStep is how many columns Left/Right move the message when unwrapped
+const hScrollStep = 8
+
+// scrollHorizontal shifts the unwrapped message columns left or right
+func (m Model) scrollHorizontal(right bool) Model {
+	if right {
+		m.xOffset = min(m.xOffset+hScrollStep, m.perf.MaxLineLength)
+	} else {
+		m.xOffset = max(m.xOffset-hScrollStep, 0)
+	}
+	m.dirty = true
+	return m
+}
+
+// clipMarker takes the last column of a row clipped to the viewport width
+const clipMarker = "»"
+
+// layoutRows splits a styled event into viewport rows: soft-wrapped, or
+// clipped to the viewport width when wrapping is off, ending in clipMarker
+// when content is hidden to the right. plain holds the rows without styling
+// for selection and copies, with the text under the marker instead of it.
+func (m Model) layoutRows(styled string) (rows, plain []string) {
+	if m.wrapLines {
+		rows = wrapStyledToWidth(styled, m.vp.Width)
+		plain = make([]string, len(rows))
+		for i, row := range rows {
+			plain[i] = stripANSI(row)
+		}
+		return rows, plain
+	}
+	width := max(m.vp.Width, 0)
+	rows = strings.Split(strings.ReplaceAll(styled, "\r\n", "\n"), "\n")
+	plain = make([]string, len(rows))
+	for i, p := range rows {
+		rows[i] = xansi.Truncate(p, width, "")
+		plain[i] = stripANSI(rows[i])
+		if width > 1 && xansi.StringWidth(p) > width {
+			rows[i] = xansi.Truncate(p, width-1, "") + m.theme.PromptStyle.Render(clipMarker)
+		}
+	}
+	return rows, plain
+}
+
 // scrollToSequence scrolls the viewport to show the event with the given sequence number
 func (m Model) scrollToSequence(seq uint64) Model {
 	// Look up line index for sequence; rebuild mapping if necessary
 	idx, ok := m.seqIndex[seq]
 	if !ok {
-		plan := core.VisiblePlan{Include: m.filters, LevelMap: m.levels, DockerVisible: m.dockerUI.Containers}
+		plan := core.VisiblePlan{Include: m.textFilters(), LevelMap: m.levels, DockerVisible: m.dockerUI.Containers, SinceSeq: m.sinceSeq, UntilSeq: m.untilSeq, Chronological: m.chronological}
 		events := core.ComputeVisible(m.ring.Snapshot(), plan)
 		// Rebuild mapping consistent with wrapping.
 		m.seqIndex = make(map[uint64]int, len(events))
 		lineCursor := 0
+		var prevTime time.Time
 		for _, e := range events {
-			m.seqIndex[e.Seq] = lineCursor
-			styled := m.renderEventWithFullStyling(e)
-			wrapped := wrapStyledToWidth(styled, m.vp.Width)
-			if len(wrapped) == 0 {
+			if _, ok := m.burstSeparator(prevTime, e.Time); ok {
 				lineCursor++
-			} else {
-				lineCursor += len(wrapped)
 			}
+			m.seqIndex[e.Seq] = lineCursor
+			rows, _ := m.eventRows(e, false)
+			lineCursor += len(rows)
+			prevTime = e.Time
 		}
 		idx, ok = m.seqIndex[seq]
 		if !ok {
@@ -762,6 +1533,42 @@ func (m Model) refreshFindIndex() Model {
 	return m
 }
 
+// onNewestHit reports whether the find cursor is on the newest hit
+func (m Model) onNewestHit() bool {
+	current, total := m.search.Position()
+	return total > 0 && current == total
+}
+
+// toggleFindCase rebuilds the active find in the opposite case mode and
+// re-indexes hits, keeping the cursor near the previous hit when possible.
+func (m Model) toggleFindCase() Model {
+	if !m.search.IsActive() {
+		return m.setError("No active find")
+	}
+	old := m.search.GetMatcher()
+	raw := old.Raw()
+	if old.CaseSensitive() {
+		raw = core.TrimCaseFlag(raw) // a flag would keep it case-sensitive
+	}
+	matcher, err := core.NewMatcherWithCase(raw, !old.CaseSensitive())
+	if err != nil {
+		return m.setError("Invalid pattern: " + err.Error())
+	}
+	prev := m.search.Current()
+	m.search.SetMatcher(matcher)
+	m = m.refreshFindIndex()
+	if prev == 0 || !m.search.SetCurrentBySeq(prev) {
+		if seq := m.search.JumpToFirst(); seq != 0 {
+			m = m.scrollToSequence(seq)
+		}
+	}
+	m.dirty = true
+	if matcher.CaseSensitive() {
+		return m.setError("Find: case-sensitive")
+	}
+	return m.setError("Find: ignoring case")
+}
+
 // refreshContent forces a refresh of the viewport content
 func (m Model) refreshContent() Model {
 	m.dirty = true
@@ -777,8 +1584,30 @@ func (m Model) handleTick() Model {
 		m = m.clearError()
 	}
 
+	if m.idleTimeout > 0 && !m.idlePaused && now.Sub(m.lastKeyTime) > m.idleTimeout {
+		m.idlePaused = true
+	}
+
+	// Sparklines only change meaningfully once per second
+	if m.showSparklines && m.dockerUI.ContainerListOpen && now.Sub(m.lastSparkUpdate) >= time.Second {
+		m = m.refreshSparklines(now)
+	}
+	if m.showStats && now.Sub(m.lastStatsUpdate) >= time.Second {
+		m = m.refreshStats(now)
+	}
+	if m.warnOverflow {
+		m = m.checkLevelOverflow()
+	}
+
+	if m.loading {
+		m.loadFrameIdx = int(now.Sub(m.loadStarted) / (100 * time.Millisecond))
+	}
+
 	// Throttle rendering based on configuration
 	if m.dirty && now.Sub(m.lastRender) > m.perf.RenderThrottle {
+		if m.filtersOpen {
+			m = m.refreshFilterHits()
+		}
 		m = m.updateViewportContent()
 		m.lastRender = now
 		m.dirty = false
@@ -787,31 +1616,88 @@ func (m Model) handleTick() Model {
 	return m
 }
 
+// checkLevelOverflow warns once per level grouped into slot 9 because the
+// level slots are full
+func (m Model) checkLevelOverflow() Model {
+	names := m.levels.Overflowed()
+	if len(names) <= m.overflowWarned {
+		return m
+	}
+	m.overflowWarned = len(names)
+	indexToName, _ := m.levels.GetSnapshot()
+	return m.setError(fmt.Sprintf("Level slots full: %s grouped into 9:%s", names[len(names)-1], indexToName[9]))
+}
+
 // updateViewportContent refreshes the viewport with current log data
 func (m Model) updateViewportContent() Model {
-	// Get visible events based on filters and docker visibility
+	// Level and docker visibility are cheap and checked every time; text
+	// filters and styling come from the render cache when still valid.
 	plan := core.VisiblePlan{
-		Include:       m.filters,
 		LevelMap:      m.levels,
 		DockerVisible: m.dockerUI.Containers,
+		SinceSeq:      m.sinceSeq,
+		UntilSeq:      m.untilSeq,
+	}
+	if m.paused {
+		plan.BeforeSeq = m.pauseEnd
 	}
 
+	// When the filters changed, remember the event at the viewport centre so
+	// the reading position survives the recompute
+	var anchor uint64
+	if gen := m.filters.FilterGeneration(); gen != m.renderedFilterGen {
+		if !m.followTail && len(m.contentLines) > 0 {
+			if e, ok := m.eventAtRow(min(m.vp.YOffset+m.vp.Height/2, len(m.contentLines)-1)); ok {
+				anchor = e.Seq
+			}
+		}
+		m.renderedFilterGen = gen
+	}
+
+	m = m.pruneBookmarks()
 	events := m.ring.Snapshot()
-	visibleEvents := core.ComputeVisible(events, plan)
+	if m.renderCache == nil {
+		m.renderCache = newRenderCache()
+	}
+	m.renderCache.sync(m.filters.FilterGeneration(), m.styleKey(), m.ring.OldestSeq(), m.ring.Capacity())
+
+	var currentHit uint64
+	if m.search.IsActive() {
+		currentHit = m.search.Current()
+	}
 
 	// Build wrapped content lines and a sequence->line-index map.
 	// Each event may span multiple wrapped lines; map seq to the first line.
-	m.seqIndex = make(map[uint64]int, len(visibleEvents))
-	var lines []string
-	for _, e := range visibleEvents {
-		styled := m.renderEventWithFullStyling(e)
-		wrapped := wrapStyledToWidth(styled, m.vp.Width)
-		if len(wrapped) == 0 {
-			wrapped = []string{""}
+	visible := make([]core.LogEvent, 0, len(events))
+	for _, e := range events {
+		if core.ShouldShowEvent(e, plan) && m.isVisible(e) {
+			visible = append(visible, e)
+		}
+	}
+	if m.chronological {
+		core.SortChronological(visible, m.eventTime)
+	}
+
+	m.seqIndex = make(map[uint64]int, len(visible))
+	var lines, plainLines []string
+	var prevTime time.Time
+	for _, e := range visible {
+		if sep, ok := m.burstSeparator(prevTime, e.Time); ok {
+			lines = append(lines, sep)
+			plainLines = append(plainLines, stripANSI(sep))
 		}
 		// Record the starting line index for this event
 		m.seqIndex[e.Seq] = len(lines)
-		lines = append(lines, wrapped...)
+		rows, plain := m.renderRows(e, currentHit, m.sameSecond(prevTime, e.Time))
+		lines = append(lines, rows...)
+		plainLines = append(plainLines, plain...)
+		prevTime = e.Time
+	}
+	m.visibleCount = len(m.seqIndex)
+	m.bufferedCount = len(events)
+	m.hidden = hiddenCounts{}
+	if len(visible) == 0 {
+		m.hidden = countHidden(events, plan)
 	}
 
 	// Apply selection overlay if actively selecting
@@ -825,14 +1711,19 @@ func (m Model) updateViewportContent() Model {
 
 	// Cache content as lines (styled + plain) for selection/copy
 	m.contentLines = lines
-	m.contentPlainLines = make([]string, len(m.contentLines))
-	for i := range m.contentLines {
-		m.contentPlainLines[i] = stripANSI(m.contentLines[i])
+	m.contentPlainLines = plainLines
+
+	if _, ok := m.seqIndex[anchor]; ok && anchor != 0 {
+		m = m.scrollToSequence(anchor)
 	}
 
-	// Auto-scroll if following tail
-	if m.followTail {
-		m.vp.GotoBottom()
+	// Auto-scroll if following tail; with find follow, to the newest hit
+	if m.followTail && !m.idlePaused && !m.paused {
+		if idx, ok := m.seqIndex[currentHit]; ok && m.findFollow && m.onNewestHit() {
+			m.vp.SetYOffset(max(idx-m.vp.Height/2, 0))
+		} else {
+			m.vp.GotoBottom()
+		}
 	}
 
 	return m
@@ -860,6 +1751,21 @@ type LogAppendedMsg struct {
 	Event core.LogEvent
 }
 
+// LoadProgressMsg reports progress of the initial file read so the UI can
+// show that a large file is being loaded.
+type LoadProgressMsg struct {
+	Lines int
+	Bytes int64
+}
+
+// OverloadMsg reports that the UI fell behind the input in the last second:
+// Dropped lines were discarded (drop policy), or Paused readers were
+// blocked waiting (block policy). A zero message clears the indicator.
+type OverloadMsg struct {
+	Dropped int64
+	Paused  bool
+}
+
 // DockerContainersMsg updates the list of available containers
 type DockerContainersMsg struct {
 	Containers map[string]bool // container name -> initially visible
@@ -871,6 +1777,17 @@ type DockerErrorMsg struct {
 	Recoverable bool // true if user can attempt reconnection
 }
 
+// InputFailedMsg reports a non-recoverable input error; the program exits
+// and FatalError returns it.
+type InputFailedMsg struct {
+	Err error
+}
+
+// FatalError returns the input error that ended the session, if any
+func (m Model) FatalError() error {
+	return m.fatalErr
+}
+
 // tickCmd returns a command that sends tick messages for render throttling
 func tickCmd() tea.Cmd {
 	return tea.Tick(16*time.Millisecond, func(t time.Time) tea.Msg {
@@ -936,6 +1853,30 @@ func (m Model) applySelectionHighlight(lines []string) []string {
 	return out
 }
 
+// refreshFilterHits recounts the include/exclude matches over the ring
+func (m Model) refreshFilterHits() Model {
+	plan := core.VisiblePlan{Include: m.filters, LevelMap: m.levels, DockerVisible: m.dockerUI.Containers, SinceSeq: m.sinceSeq, UntilSeq: m.untilSeq, Hits: &m.filterHits}
+	core.ComputeVisible(m.ring.Snapshot(), plan)
+	return m
+}
+
+// filterBySelection adds the current single-line mouse selection as a
+// literal include filter.
+func (m Model) filterBySelection() Model {
+	text := m.extractSelectedText()
+	if strings.Contains(text, "\n") {
+		return m.setError("Filter by selection needs a single-line selection")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return m.setError("Nothing selected")
+	}
+	m.filters.AddInclude(core.NewSubstringMatcher(text))
+	m.selStartX, m.selStartY, m.selEndX, m.selEndY = 0, 0, 0, 0
+	m.dirty = true
+	return m.setError("Filtering to: " + text)
+}
+
 // extractSelectedText builds the selected plain text based on the selection
 // box (viewport coordinates) and current viewport offset.
 func (m Model) extractSelectedText() string {
@@ -965,8 +1906,9 @@ func (m Model) extractSelectedText() string {
 		if ex < sx {
 			sx, ex = ex, sx
 		}
-		sx = clamp(sx, 0, m.vp.Width)
-		ex = clamp(ex, 0, m.vp.Width)
+		// The severity bar column is never part of a copy
+		sx = clamp(sx, m.gutterWidth(), m.vp.Width)
+		ex = clamp(ex, m.gutterWidth(), m.vp.Width)
 		if sx == ex {
 			if absStart != absEnd {
 				out = append(out, "")
@@ -999,7 +1941,8 @@ func sliceByColumns(s string, start, end int) string {
 	return b.String()
 }
 
-var ansiRegexp = regexp.MustCompile("\x1b\x5b[0-9;]*[ -/]*[@-~]")
+// OSC sequences (e.g. hyperlinks) are matched first, then CSI
+var ansiRegexp = regexp.MustCompile("\x1b\\][^\x07\x1b]*(?:\x07|\x1b\\\\)|\x1b\x5b[0-9;]*[ -/]*[@-~]")
 
 func stripANSI(s string) string    { return ansiRegexp.ReplaceAllString(s, "") }
 func ansiStringWidth(s string) int { return runewidth.StringWidth(s) }
@@ -1079,11 +2022,7 @@ func (m Model) toggleSelectedContainer() Model {
 	}
 
 	// Get sorted container list to find the selected container
-	var containers []string
-	for name := range m.dockerUI.Containers {
-		containers = append(containers, name)
-	}
-	sort.Strings(containers)
+	containers := m.sortedContainers()
 
 	if m.dockerUI.SelectedContainer >= 0 && m.dockerUI.SelectedContainer < len(containers) {
 		selectedContainer := containers[m.dockerUI.SelectedContainer]
@@ -1140,6 +2079,14 @@ func (m Model) refreshPresetsList() Model {
 		m.errMsg = "Failed to load presets: " + err.Error()
 		m.dockerUI.Presets = nil
 	} else {
+		// Docker mode lists container presets, other modes filter presets
+		var shown []persist.Preset
+		for _, p := range presets {
+			if p.IsFilterPreset() == (m.mode != ModeDocker) {
+				shown = append(shown, p)
+			}
+		}
+		presets = shown
 		m.dockerUI.Presets = presets
 		// Reset selection if it's out of bounds
 		if m.dockerUI.SelectedPreset >= len(presets) {
@@ -1188,7 +2135,13 @@ func (m Model) applySelectedPreset() Model {
 	}
 
 	selectedPreset := m.dockerUI.Presets[m.dockerUI.SelectedPreset]
-	m.dockerUI.Containers = persist.ApplyPreset(selectedPreset, m.dockerUI.Containers)
+	if selectedPreset.IsFilterPreset() {
+		if err := persist.ApplyFilterPreset(selectedPreset, m.filters); err != nil {
+			return m.setError("Failed to apply preset: " + err.Error())
+		}
+	} else {
+		m.dockerUI.Containers = persist.ApplyPreset(selectedPreset, m.dockerUI.Containers)
+	}
 
 	m.errMsg = "Applied preset '" + selectedPreset.Name + "'"
 	m.dockerUI.PresetManagerOpen = false
@@ -1293,18 +2246,54 @@ func DockerReconnectCmd() tea.Cmd {
 	}
 }
 
-// truncateLine truncates a line to the maximum configured length, adding "..." if truncated
+// truncateLine cuts a line to the maximum configured length, counted in
+// runes for both the check and the cut, so multibyte text is never split
+// mid-character. It is a hard cut, without an ellipsis.
 func (m Model) truncateLine(line string) string {
-	if len(line) <= m.perf.MaxLineLength {
-		return line
+	n := 0
+	for i := range line {
+		if n == m.perf.MaxLineLength {
+			return line[:i]
+		}
+		n++
 	}
+	return line
+}
 
-	// Use rune-based truncation to handle Unicode properly
-	runes := []rune(line)
-	if len(runes) <= m.perf.MaxLineLength {
-		return line
+// reload re-reads the source from the start and follows the tail. Sources that
+// can't be re-read (stdin, docker) are cleared and keep following instead.
+func (m Model) reload() (Model, tea.Cmd) {
+	m.search.Clear() // hits refer to sequences that are about to go away
+	m.followTail = true
+	if m.source != nil && m.source.Seekable() {
+		src := m.source
+		m = m.setError("Reloading from start")
+		return m, func() tea.Msg {
+			src.Restart()
+			return refreshMsg{}
+		}
 	}
+	m.ring.Clear()
+	m.dirty = true
+	return m.setError("Source can't be re-read; cleared and following"), nil
+}
 
-	// Hard cut without ellipsis (requested behavior)
-	return string(runes[:m.perf.MaxLineLength])
+// replay shows the log again from its oldest line. Seekable sources are
+// re-read so lines evicted from the ring come back; otherwise only the
+// in-ring history is replayed.
+func (m Model) replay() (Model, tea.Cmd) {
+	m.followTail = false
+	if m.source != nil && m.source.Seekable() {
+		m.search.Clear()
+		m.vp.GotoTop()
+		src := m.source
+		m = m.setError("Replaying from start")
+		return m, func() tea.Msg {
+			src.Restart()
+			return refreshMsg{}
+		}
+	}
+	m = m.updateViewportContent()
+	m.vp.GotoTop()
+	return m.setError(fmt.Sprintf("Replaying %d buffered lines; source can't be re-read", m.ring.Size())), nil
 }
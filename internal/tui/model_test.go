@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/germanoeich/siftail/internal/core"
@@ -252,6 +254,265 @@ func TestNewModel(t *testing.T) {
 	}
 }
 
+func TestStatusLine_VisibleCount(t *testing.T) {
+	ring := core.NewRing(100)
+	filters := core.NewFilters()
+	m := *NewModel(ring, filters, core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 20})
+	m = updated.(Model)
+	for _, line := range []string{"GET /api ok", "GET /health ok", "POST /api fail", "GET /api slow", "noise"} {
+		ring.Append(core.LogEvent{Line: line})
+	}
+
+	m = m.updateViewportContent()
+	if status := m.renderStatusLine(); strings.Contains(status, "Visible:") {
+		t.Errorf("Expected no visible count without filters, got %q", status)
+	}
+
+	include, _ := core.NewIncludeMatcher("/api")
+	exclude, _ := core.NewMatcher("fail")
+	filters.AddInclude(include)
+	filters.AddExclude(exclude)
+	m = m.updateViewportContent()
+	if status := m.renderStatusLine(); !strings.Contains(status, "Visible: 2/5") {
+		t.Errorf("Expected Visible: 2/5, got %q", status)
+	}
+}
+
+func TestViewport_NoEmptyNoteFlashAtStartup(t *testing.T) {
+	ring := core.NewRing(100)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+
+	// Lines arriving within the grace period replace a blank viewport
+	m = m.updateViewportContent()
+	if view := m.View(); strings.Contains(view, "No log entries") {
+		t.Fatal("expected no empty note right after startup")
+	}
+	ring.Append(core.LogEvent{Line: "first line"})
+	updated, _ = m.Update(RefreshCmd()())
+	m = updated.(Model)
+	if view := m.View(); strings.Contains(view, "No log entries") {
+		t.Fatal("expected no empty note before the next render")
+	}
+	m = m.updateViewportContent()
+	if view := m.View(); strings.Contains(view, "No log entries") || !strings.Contains(view, "first line") {
+		t.Errorf("expected the first line without the empty note, got %q", view)
+	}
+
+	// Without any line, the note shows once the grace period is over
+	m = *NewModel(core.NewRing(100), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	m.loadStarted = time.Now().Add(-emptyNoteDelay)
+	if view := m.View(); !strings.Contains(view, "No log entries") {
+		t.Error("expected the empty note after the grace period")
+	}
+}
+
+func TestViewport_FilteredEmptyMessage(t *testing.T) {
+	ring := core.NewRing(100)
+	filters := core.NewFilters()
+	m := *NewModel(ring, filters, core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	m.loadStarted = time.Now().Add(-emptyNoteDelay)
+
+	m = m.updateViewportContent()
+	if view := m.renderViewport(); !strings.Contains(view, "No log entries") {
+		t.Errorf("expected the empty-buffer note, got %q", view)
+	}
+
+	for _, line := range []string{"alpha", "beta", "gamma"} {
+		ring.Append(core.LogEvent{Line: line})
+	}
+	exclude, _ := core.NewMatcher("/a/")
+	filters.AddExclude(exclude)
+	m = m.updateViewportContent()
+	view := m.renderViewport()
+	if !strings.Contains(view, "3 lines hidden by filters") || !strings.Contains(view, "P to peek") {
+		t.Errorf("expected the filtered-empty note, got %q", view)
+	}
+
+	filters.ClearExcludes()
+	m = m.updateViewportContent()
+	if view := m.renderViewport(); strings.Contains(view, "hidden by filters") || !strings.Contains(view, "gamma") {
+		t.Errorf("expected the lines once the filter is gone, got %q", view)
+	}
+
+	// Other reasons are named and counted apart from the filters
+	m.levels.Focus(4) // only ERROR; the lines have no level
+	m = m.updateViewportContent()
+	if view := m.renderViewport(); !strings.Contains(view, "3 lines hidden by levels") || !strings.Contains(view, "0 to enable all") {
+		t.Errorf("expected the levels note, got %q", view)
+	}
+	m.levels.EnableAll()
+	m.untilSeq = 1
+	m.sinceSeq = 1
+	filters.AddExclude(exclude)
+	m = m.updateViewportContent()
+	if view := m.renderViewport(); !strings.Contains(view, "3 lines hidden: 1 by filters, 2 by the { / } window") {
+		t.Errorf("expected the mixed note, got %q", view)
+	}
+}
+
+func TestModel_LoadingUntilFirstContent(t *testing.T) {
+	ring := core.NewRing(100)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+
+	if !m.loading {
+		t.Fatal("Expected file mode model to start in loading state")
+	}
+
+	updated, _ = m.Update(LoadProgressMsg{Lines: 20000, Bytes: 3 * 1024 * 1024})
+	m = updated.(Model)
+	status := m.renderStatusLine()
+	if !strings.Contains(status, "Loading") || !strings.Contains(status, "20000 lines") || !strings.Contains(status, "3.0 MiB") {
+		t.Errorf("Expected loading progress in status line, got %q", status)
+	}
+
+	ring.Append(core.LogEvent{Line: "first line"})
+	updated, _ = m.Update(refreshMsg{})
+	m = updated.(Model)
+	if m.loading {
+		t.Error("Expected loading state to clear on first refresh")
+	}
+	if status := m.renderStatusLine(); strings.Contains(status, "Loading") || !strings.Contains(status, "Lines: 1") {
+		t.Errorf("Expected line count after loading, got %q", status)
+	}
+
+	for _, mode := range []Mode{ModeStdin, ModeDocker} {
+		if NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), mode).loading {
+			t.Errorf("Expected mode %v not to start in loading state", mode)
+		}
+	}
+}
+
+func TestModel_IdleTimeoutPausesFollow(t *testing.T) {
+	ring := core.NewRing(200)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	m.SetIdleTimeout(time.Minute)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	m = updated.(Model)
+
+	for i := 0; i < 50; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+	m = m.updateViewportContent()
+	if !m.vp.AtBottom() {
+		t.Fatal("Expected viewport at bottom while following")
+	}
+
+	// Simulate a long stretch without key input
+	m.lastKeyTime = time.Now().Add(-2 * time.Minute)
+	m = m.handleTick()
+	if !m.idlePaused {
+		t.Fatal("Expected follow to pause after idle timeout")
+	}
+
+	for i := 50; i < 100; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+	m = m.updateViewportContent()
+	if m.vp.AtBottom() {
+		t.Error("Expected viewport to stay put while idle-paused")
+	}
+
+	// Any key resumes following
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = updated.(Model)
+	if m.idlePaused {
+		t.Fatal("Expected key press to resume following")
+	}
+	m = m.updateViewportContent()
+	if !m.vp.AtBottom() {
+		t.Error("Expected viewport to catch up to tail after resuming")
+	}
+}
+
+type fakeSource struct {
+	seekable bool
+	restarts int
+}
+
+func (f *fakeSource) Seekable() bool { return f.seekable }
+func (f *fakeSource) Restart()       { f.restarts++ }
+
+func TestModel_ReloadAndReplay_BySeekability(t *testing.T) {
+	newModel := func(mode Mode, src Source) Model {
+		ring := core.NewRing(100)
+		m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), mode)
+		m.SetSource(src)
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+		m = updated.(Model)
+		for i := 0; i < 30; i++ {
+			ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+		}
+		return m.updateViewportContent()
+	}
+	press := func(m Model, key tea.KeyMsg) (Model, tea.Cmd) {
+		updated, cmd := m.Update(key)
+		return updated.(Model), cmd
+	}
+	ctrlR := tea.KeyMsg{Type: tea.KeyCtrlR}
+	shiftR := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}}
+
+	// Stdin: reload clears and keeps following without touching the source
+	stdin := &fakeSource{seekable: false}
+	m := newModel(ModeStdin, stdin)
+	m, _ = press(m, ctrlR)
+	if m.ring.Size() != 0 || !m.followTail || stdin.restarts != 0 {
+		t.Errorf("stdin reload: size=%d follow=%t restarts=%d", m.ring.Size(), m.followTail, stdin.restarts)
+	}
+
+	// Stdin: replay uses in-ring history from the top
+	m = newModel(ModeStdin, stdin)
+	m, _ = press(m, shiftR)
+	if m.ring.Size() != 30 || m.followTail || !m.vp.AtTop() || stdin.restarts != 0 {
+		t.Errorf("stdin replay: size=%d follow=%t top=%t restarts=%d", m.ring.Size(), m.followTail, m.vp.AtTop(), stdin.restarts)
+	}
+
+	// File: reload and replay restart the source from the start
+	file := &fakeSource{seekable: true}
+	m = newModel(ModeFile, file)
+	m, cmd := press(m, ctrlR)
+	if !m.followTail {
+		t.Error("file reload: expected follow to stay on")
+	}
+	runCmds(cmd)
+	if file.restarts != 1 {
+		t.Errorf("file reload: expected 1 restart, got %d", file.restarts)
+	}
+
+	m, cmd = press(m, shiftR)
+	runCmds(cmd)
+	if file.restarts != 2 || m.followTail {
+		t.Errorf("file replay: restarts=%d follow=%t", file.restarts, m.followTail)
+	}
+
+	// No source behaves like a non-seekable one
+	m = newModel(ModeDocker, nil)
+	m, _ = press(m, ctrlR)
+	if m.ring.Size() != 0 {
+		t.Errorf("docker reload: expected ring cleared, got %d", m.ring.Size())
+	}
+}
+
+// runCmds executes a command, recursing into batches, and drops the messages.
+func runCmds(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runCmds(c)
+		}
+	}
+}
+
 func TestDockerUI_ToggleSingle(t *testing.T) {
 	// Setup
 	ring := core.NewRing(100)
@@ -292,6 +553,54 @@ func TestDockerUI_ToggleSingle(t *testing.T) {
 	}
 }
 
+func TestDockerUI_PinnedContainersSortFirst(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	newModel := func() Model {
+		m := *NewModel(core.NewRing(100), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+		m.dockerUI.Containers = map[string]bool{"api": true, "nginx": true, "postgres": true, "redis": true}
+		m.dockerUI.ContainerListOpen = true
+		return m
+	}
+	m := newModel()
+	pin := func(sel int) {
+		t.Helper()
+		m.dockerUI.SelectedContainer = sel
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+		m = updated.(Model)
+	}
+
+	pin(3) // redis
+	pin(3) // postgres
+	if got := m.sortedContainers(); !reflect.DeepEqual(got, []string{"postgres", "redis", "api", "nginx"}) {
+		t.Fatalf("sorted = %v, want pinned first", got)
+	}
+	if m.dockerUI.SelectedContainer != 0 {
+		t.Errorf("selection = %d, want it to follow postgres to 0", m.dockerUI.SelectedContainer)
+	}
+	list := m.renderDockerContainerList()
+	if strings.Index(list, "redis (pinned)") > strings.Index(list, "api") {
+		t.Errorf("pinned redis should be listed before api:\n%s", list)
+	}
+
+	// Space acts on the container shown at the cursor
+	m.dockerUI.SelectedContainer = 1
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	m = updated.(Model)
+	if m.dockerUI.Containers["redis"] || !m.dockerUI.Containers["api"] {
+		t.Errorf("space toggled the wrong container: %v", m.dockerUI.Containers)
+	}
+
+	// The pinned set is remembered
+	if got := newModel().sortedContainers(); !reflect.DeepEqual(got, []string{"postgres", "redis", "api", "nginx"}) {
+		t.Errorf("after restart sorted = %v", got)
+	}
+	pin(0)
+	if got := m.sortedContainers(); !reflect.DeepEqual(got, []string{"redis", "api", "nginx", "postgres"}) {
+		t.Errorf("after unpinning postgres sorted = %v", got)
+	}
+}
+
 func TestDockerUI_ToggleAll(t *testing.T) {
 	// Setup
 	ring := core.NewRing(100)
@@ -427,6 +736,35 @@ func TestLineLength_Truncation_NoEllipsis(t *testing.T) {
 	}
 }
 
+func TestLineLength_Truncation_Multibyte(t *testing.T) {
+	model := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	model.perf.MaxLineLength = 10
+
+	cases := []struct {
+		line, want string
+	}{
+		// More bytes than the limit but exactly 10 runes: kept whole
+		{strings.Repeat("é", 10), strings.Repeat("é", 10)},
+		// One rune over the limit
+		{strings.Repeat("é", 11), strings.Repeat("é", 10)},
+		{"日本語のログ行です。長い", "日本語のログ行です。"},
+		{"ok 🙂🙂🙂🙂🙂🙂🙂🙂", "ok 🙂🙂🙂🙂🙂🙂🙂"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		got := model.truncateLine(c.line)
+		if got != c.want {
+			t.Errorf("truncateLine(%q) = %q, want %q", c.line, got, c.want)
+		}
+		if n := utf8.RuneCountInString(got); n > 10 || !utf8.ValidString(got) {
+			t.Errorf("truncateLine(%q) gave %d runes (valid=%v)", c.line, n, utf8.ValidString(got))
+		}
+		if !strings.HasPrefix(c.line, got) || strings.HasSuffix(got, "...") {
+			t.Errorf("truncateLine(%q) = %q, want a plain prefix", c.line, got)
+		}
+	}
+}
+
 // TestViewportScrollingAndFindJump ensures that the viewport receives full content
 // (so it can scroll) and that find navigation jumps to off-screen matches.
 func TestViewportScrollingAndFindJump(t *testing.T) {
@@ -492,3 +830,390 @@ func TestViewportScrollingAndFindJump(t *testing.T) {
 		t.Fatal("expected followTail to be disabled after jumping to a match")
 	}
 }
+
+func TestModel_FilterBySelection(t *testing.T) {
+	model := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	model.contentPlainLines = []string{"GET /users req=/abc-123/ 200", "second line"}
+
+	// Select "/abc-123/" plus a trailing space on the first row
+	model.selStartX, model.selStartY, model.selEndX, model.selEndY = 15, 0, 25, 0
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	model = updated.(Model)
+
+	if len(model.filters.Include) != 1 {
+		t.Fatalf("expected 1 include filter, got %d", len(model.filters.Include))
+	}
+	m := model.filters.Include[0]
+	if m.Raw() != "/abc-123/" || m.IsRegex() {
+		t.Errorf("expected literal matcher for %q, got raw %q regex=%v", "/abc-123/", m.Raw(), m.IsRegex())
+	}
+	if !m.Match("req=/ABC-123/ ok") || m.Match("req=abc-123") {
+		t.Error("expected case-insensitive literal substring matching")
+	}
+
+	// Multi-line and empty selections are rejected
+	model.selStartX, model.selStartY, model.selEndX, model.selEndY = 3, 0, 4, 1
+	model = model.filterBySelection()
+	if len(model.filters.Include) != 1 || !strings.Contains(model.errMsg, "single-line") {
+		t.Errorf("expected multi-line selection to be rejected, got %d filters, status %q", len(model.filters.Include), model.errMsg)
+	}
+	model.selStartX, model.selStartY, model.selEndX, model.selEndY = 5, 1, 5, 1
+	model = model.filterBySelection()
+	if len(model.filters.Include) != 1 || model.errMsg != "Nothing selected" {
+		t.Errorf("expected empty selection to be rejected, got status %q", model.errMsg)
+	}
+}
+
+func TestModel_FindResumesAfterClear(t *testing.T) {
+	ring := core.NewRing(100)
+	model := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	model = updated.(Model)
+	for i := 0; i < 20; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d timeout", i)})
+	}
+	model = model.updateViewportContent()
+
+	find := func(pattern string) {
+		t.Helper()
+		model = model.startPrompt(PromptFind, "Find: ")
+		model.input.SetValue(pattern)
+		model = model.handlePromptSubmit()
+	}
+	key := func(msg tea.KeyMsg) {
+		updated, _ := model.Update(msg)
+		model = updated.(Model)
+	}
+
+	find("timeout")
+	for i := 0; i < 4; i++ {
+		key(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	left := model.search.Current()
+	if pos, _ := model.search.Position(); pos != 5 {
+		t.Fatalf("expected to be on the 5th hit, got %d", pos)
+	}
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.search.IsActive() {
+		t.Fatal("esc should clear the find")
+	}
+
+	find("timeout")
+	if got := model.search.Current(); got != left || !strings.Contains(model.errMsg, "resumed") {
+		t.Errorf("reactivated find at #%d (status %q), want #%d", got, model.errMsg, left)
+	}
+}
+
+func TestModel_ToggleFindCase(t *testing.T) {
+	ring := core.NewRing(10)
+	model := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	for _, line := range []string{"Timeout talking to db", "timeout retrying", "all good", "TIMEOUT"} {
+		ring.Append(core.LogEvent{Line: line})
+	}
+
+	model = model.startPrompt(PromptFind, "Find: ")
+	model.input.SetValue("timeout")
+	model = model.handlePromptSubmit()
+	if got := model.search.Count(); got != 3 {
+		t.Fatalf("expected 3 case-insensitive hits, got %d", got)
+	}
+
+	press := func() {
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+		model = updated.(Model)
+	}
+
+	press()
+	if !model.search.GetMatcher().CaseSensitive() {
+		t.Fatal("expected find to be case-sensitive after toggle")
+	}
+	if got := model.search.Count(); got != 1 {
+		t.Errorf("expected 1 case-sensitive hit, got %d", got)
+	}
+	if status := model.renderStatusLine(); !strings.Contains(status, "Find: 1/1 Aa") {
+		t.Errorf("expected case indicator Aa in status, got %q", status)
+	}
+
+	press()
+	if got := model.search.Count(); got != 3 || model.search.GetMatcher().CaseSensitive() {
+		t.Errorf("expected toggling back to restore 3 case-insensitive hits, got %d", got)
+	}
+
+	// A pattern flagged case-sensitive can still be toggled off
+	model = model.startPrompt(PromptFind, "Find: ")
+	model.input.SetValue("/TIMEOUT/c")
+	model = model.handlePromptSubmit()
+	if got := model.search.Count(); got != 1 {
+		t.Fatalf("expected 1 hit for /TIMEOUT/c, got %d", got)
+	}
+	press()
+	if got := model.search.Count(); got != 3 || model.search.GetMatcher().CaseSensitive() {
+		t.Errorf("expected toggling /TIMEOUT/c to ignore case, got %d hits", got)
+	}
+}
+
+func TestModel_InvalidPatternKeepsPromptOpen(t *testing.T) {
+	for _, kind := range []PromptKind{PromptFind, PromptHighlight, PromptFilterIn, PromptFilterOut} {
+		model := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+		model = model.startPrompt(kind, "")
+		model.input.SetValue("/[unclosed/")
+
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		model = updated.(Model)
+
+		if !model.inPrompt || model.promptKind != kind {
+			t.Fatalf("kind %v: expected prompt to stay open", kind)
+		}
+		if got := model.input.Value(); got != "/[unclosed/" {
+			t.Errorf("kind %v: expected text retained, got %q", kind, got)
+		}
+		if model.input.Position() != len("/[unclosed/") {
+			t.Errorf("kind %v: expected cursor at end, got %d", kind, model.input.Position())
+		}
+		if !strings.Contains(model.renderPrompt(), "Invalid pattern") {
+			t.Errorf("kind %v: expected inline error, got %q", kind, model.renderPrompt())
+		}
+		if len(model.filters.Include)+len(model.filters.Exclude)+len(model.filters.Highlights) != 0 || model.search.IsActive() {
+			t.Errorf("kind %v: expected invalid pattern not to be applied", kind)
+		}
+
+		// Fixing the pattern clears the error and applies it
+		model.input.SetValue("/unclosed/")
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		model = updated.(Model)
+		if model.inPrompt || model.promptErr != "" {
+			t.Errorf("kind %v: expected prompt closed after a valid pattern", kind)
+		}
+	}
+}
+
+func TestModel_PatternHintWhileTyping(t *testing.T) {
+	for _, kind := range []PromptKind{PromptFind, PromptHighlight, PromptFilterIn, PromptFilterOut} {
+		model := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+		model = model.startPrompt(kind, "")
+		typeText := func(text string) {
+			for _, r := range text {
+				updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+				model = updated.(Model)
+			}
+		}
+
+		typeText("/time(out")
+		if model.patternErr != nil || model.patternValid {
+			t.Errorf("kind %v: expected no hint before the closing slash, got %q", kind, model.renderPrompt())
+		}
+		typeText("/")
+		if model.patternErr == nil || !strings.Contains(model.renderPrompt(), "✗") {
+			t.Errorf("kind %v: expected an invalid hint, got %q", kind, model.renderPrompt())
+		}
+		if !model.inPrompt || len(model.filters.Include)+len(model.filters.Exclude)+len(model.filters.Highlights) != 0 || model.search.IsActive() {
+			t.Errorf("kind %v: expected nothing applied while typing", kind)
+		}
+
+		model.input.SetValue("/time(out)?/")
+		updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+		model = updated.(Model)
+		if !model.patternValid || !strings.Contains(model.renderPrompt(), "✓ valid") {
+			t.Errorf("kind %v: expected a valid hint, got %q", kind, model.renderPrompt())
+		}
+
+		model = model.cancelPrompt().startPrompt(kind, "")
+		if model.patternErr != nil || model.patternValid {
+			t.Errorf("kind %v: expected a new prompt to start without a hint", kind)
+		}
+	}
+
+	// Other prompts are not patterns
+	model := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	model = model.startPrompt(PromptExportPath, "")
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/a(/")})
+	if m := updated.(Model); m.patternErr != nil || m.patternValid {
+		t.Errorf("expected no hint for the export prompt, got %q", m.renderPrompt())
+	}
+}
+
+func TestModel_FindFollowJumpsToNewHits(t *testing.T) {
+	ring := core.NewRing(1000)
+	search := core.NewSearchState()
+	m := *NewModel(ring, core.NewFilters(), search, core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	matcher, _ := core.NewMatcher("error")
+	search.SetMatcher(matcher)
+	search.SetActive(true)
+
+	appendLines := func(match bool, infos int) uint64 {
+		var hit uint64
+		if match {
+			e := ring.Append(core.LogEvent{Line: fmt.Sprintf("error %d", ring.Size())})
+			nm, _ := m.Update(LogAppendedMsg{Event: e})
+			m = nm.(Model)
+			hit = e.Seq
+		}
+		for i := 0; i < infos; i++ {
+			e := ring.Append(core.LogEvent{Line: "info"})
+			nm, _ := m.Update(LogAppendedMsg{Event: e})
+			m = nm.(Model)
+		}
+		m = m.updateViewportContent()
+		return hit
+	}
+	atBottom := func() bool { return m.vp.YOffset == len(m.contentLines)-m.vp.Height }
+
+	// Off by default: hits are indexed but the view stays at the bottom
+	appendLines(false, 30)
+	appendLines(true, 20)
+	if search.Count() != 1 || search.Current() != 0 || !atBottom() {
+		t.Fatalf("expected a passive find, got cursor #%d, offset %d", search.Current(), m.vp.YOffset)
+	}
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	m = nm.(Model)
+	if !m.findFollow || !strings.Contains(m.renderStatusLine(), "follow") {
+		t.Fatal("G did not turn find follow on")
+	}
+	for i := 0; i < 2; i++ {
+		hit := appendLines(true, 20)
+		idx := m.seqIndex[hit]
+		if search.Current() != hit || m.vp.YOffset != idx-m.vp.Height/2 || atBottom() {
+			t.Errorf("hit %d: cursor #%d, offset %d; want #%d centered at %d", i, search.Current(), m.vp.YOffset, hit, idx-m.vp.Height/2)
+		}
+		if !m.followTail {
+			t.Error("expected find follow to keep following")
+		}
+	}
+
+	// Navigating the hits stops following; new hits no longer move the view
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = nm.(Model)
+	current, offset := search.Current(), m.vp.YOffset
+	appendLines(true, 5)
+	if search.Current() != current || m.vp.YOffset != offset {
+		t.Errorf("expected the view to stay put after navigating, got cursor #%d offset %d", search.Current(), m.vp.YOffset)
+	}
+}
+
+func TestModel_FilterChangeKeepsCenteredEvent(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+
+	var target core.LogEvent
+	for i := 0; i < 100; i++ {
+		kind := "odd"
+		if i%2 == 0 {
+			kind = "even"
+		}
+		e := ring.Append(core.LogEvent{Line: fmt.Sprintf("%s-%03d", kind, i)})
+		if i == 60 {
+			target = e
+		}
+	}
+	m = m.updateViewportContent()
+	m = m.scrollToSequence(target.Seq)
+
+	centered := func() string {
+		e, _ := m.eventAtRow(m.vp.YOffset + m.vp.Height/2)
+		return e.Line
+	}
+	if got := centered(); got != target.Line {
+		t.Fatalf("setup: centered %q, want %q", got, target.Line)
+	}
+
+	matcher, _ := core.NewMatcher("even")
+	m.filters.AddInclude(matcher)
+	m = m.updateViewportContent()
+	if got := centered(); got != target.Line {
+		t.Errorf("after filter: centered %q, want %q", got, target.Line)
+	}
+	if m.followTail {
+		t.Error("expected follow to stay off")
+	}
+
+	// Removing the filter keeps the position too
+	m.filters.ClearIncludes()
+	m = m.updateViewportContent()
+	if got := centered(); got != target.Line {
+		t.Errorf("after clearing: centered %q, want %q", got, target.Line)
+	}
+}
+
+func TestLevelOverflow_Warn(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	levels := core.NewLevelMap()
+	levels.SetOverflow(core.OverflowWarn, "misc")
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), levels, ModeFile)
+	for _, level := range []string{"NOTICE", "ALERT", "AUDIT", "TRACE"} {
+		levels.GetOrAssignIndex(level)
+	}
+	m = m.handleTick()
+	if m.errMsg != "" {
+		t.Fatalf("unexpected warning before overflow: %q", m.errMsg)
+	}
+
+	levels.GetOrAssignIndex("VERBOSE")
+	m = m.handleTick()
+	if m.errMsg != "Level slots full: VERBOSE grouped into 9:MISC" {
+		t.Errorf("status = %q", m.errMsg)
+	}
+	m.errMsg = ""
+	if m = m.handleTick(); m.errMsg != "" {
+		t.Errorf("warned twice for the same level: %q", m.errMsg)
+	}
+}
+
+func TestFilterPresets_SaveAndApplyInFileMode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	filters := core.NewFilters()
+	m := *NewModel(core.NewRing(10), filters, core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = nm.(Model)
+	include, _ := core.NewIncludeMatcher("+error")
+	filters.AddInclude(include)
+	hl, _ := core.NewMatcher("/timeout \\d+/")
+	filters.AddHighlight(hl)
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	press(runes("p"))
+	if !m.dockerUI.PresetManagerOpen {
+		t.Fatal("p did not open the preset manager outside Docker mode")
+	}
+	press(runes("s"))
+	m.input.SetValue("errors")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.dockerUI.Presets) != 1 || m.dockerUI.Presets[0].Name != "errors" {
+		t.Fatalf("expected the filter preset listed, got %+v (%q)", m.dockerUI.Presets, m.errMsg)
+	}
+	if !strings.Contains(m.View(), "errors (1 in, 0 out, 1 highlights)") {
+		t.Errorf("preset not rendered:\n%s", m.View())
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+
+	press(runes("C"))
+	if len(filters.Include) != 0 {
+		t.Fatal("setup: filters not cleared")
+	}
+	press(runes("p"))
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(filters.Include) != 1 || !filters.Include[0].Required() || len(filters.Highlights) != 1 || filters.Highlights[0].Raw() != "/timeout \\d+/" {
+		t.Errorf("preset not applied: %+v (%q)", filters, m.errMsg)
+	}
+	if m.dockerUI.PresetManagerOpen {
+		t.Error("expected applying to close the manager")
+	}
+
+	// Docker mode keeps listing container presets only
+	d := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	if d = d.refreshPresetsList(); len(d.dockerUI.Presets) != 0 {
+		t.Errorf("filter preset listed in Docker mode: %+v", d.dockerUI.Presets)
+	}
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// paletteRows is how many actions the command palette shows at once
+const paletteRows = 12
+
+// paletteState is the command palette overlay (: or Ctrl+P)
+type paletteState struct {
+	open  bool
+	query string
+	sel   int
+}
+
+// fuzzyMatch reports whether query's characters appear in name in order,
+// ignoring case; rank is 0 for a substring match and 1 otherwise.
+func fuzzyMatch(name, query string) (rank int, ok bool) {
+	name, query = strings.ToLower(name), strings.ToLower(query)
+	if strings.Contains(name, query) {
+		return 0, true
+	}
+	rest := name
+	for _, r := range query {
+		i := strings.IndexRune(rest, r)
+		if i < 0 {
+			return 0, false
+		}
+		rest = rest[i+len(string(r)):]
+	}
+	return 1, true
+}
+
+// paletteMatches returns the available actions matching the query,
+// substring matches first
+func (m Model) paletteMatches() []paletteAction {
+	var ranked [2][]paletteAction
+	for _, ka := range keyActions {
+		if !ka.available(m) {
+			continue
+		}
+		for _, a := range ka.palette {
+			if rank, ok := fuzzyMatch(a.name, m.palette.query); ok {
+				ranked[rank] = append(ranked[rank], a)
+			}
+		}
+	}
+	return append(ranked[0], ranked[1]...)
+}
+
+// handlePaletteKey edits the query and moves the selection; enter closes the
+// palette and returns the key of the action to run
+func (m Model) handlePaletteKey(msg tea.KeyMsg) (Model, *tea.KeyMsg) {
+	p := &m.palette
+	matches := m.paletteMatches()
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.open = false
+	case tea.KeyUp:
+		p.sel = max(p.sel-1, 0)
+	case tea.KeyDown:
+		p.sel = min(p.sel+1, max(len(matches)-1, 0))
+	case tea.KeyEnter:
+		p.open = false
+		if p.sel < len(matches) {
+			return m, &matches[p.sel].key
+		}
+	case tea.KeyBackspace:
+		if p.query != "" {
+			runes := []rune(p.query)
+			p.query = string(runes[:len(runes)-1])
+			p.sel = 0
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		p.query += string(msg.Runes)
+		p.sel = 0
+	}
+	return m, nil
+}
+
+// renderPaletteOverlay shows the query and the page of matching actions
+// holding the selection
+func (m Model) renderPaletteOverlay() string {
+	p := m.palette
+	width := min(60, m.width-4)
+	matches := m.paletteMatches()
+	lines := []string{"> " + p.query + "█", ""}
+	start := p.sel / paletteRows * paletteRows
+	for i := start; i < min(start+paletteRows, len(matches)); i++ {
+		cursor := "  "
+		if i == p.sel {
+			cursor = "> "
+		}
+		a := matches[i]
+		lines = append(lines, cursor+a.name+"  "+m.theme.TimestampStyle.Render(a.key.String()))
+	}
+	if len(matches) == 0 {
+		lines = append(lines, "  (no matching action)")
+	}
+	lines = append(lines, "", "Type to filter • Up/Down: select • Enter: run • Esc: close")
+
+	for i, line := range lines {
+		lines[i] = xansi.Truncate(line, width-2, "…")
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
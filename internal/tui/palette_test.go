@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestPalette_ListsFiltersAndRuns(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = nm.(Model)
+	press := func(keys ...tea.KeyMsg) {
+		t.Helper()
+		for _, k := range keys {
+			nm, _ := m.Update(k)
+			m = nm.(Model)
+		}
+	}
+
+	press(runeKey(':'))
+	if !m.palette.open {
+		t.Fatal(": did not open the palette")
+	}
+	all := m.paletteMatches()
+	names := make(map[string]bool)
+	for _, a := range all {
+		names[a.name] = true
+	}
+	for _, want := range []string{"Toggle wrap", "Clear all filters", "Export lines to a file", "Presets"} {
+		if !names[want] {
+			t.Errorf("palette is missing %q", want)
+		}
+	}
+	if names["Containers list"] || names["Copy file:line"] {
+		t.Error("palette lists actions unavailable in stdin mode")
+	}
+
+	// A subsequence narrows the list, substring matches first
+	press(runeKey('w'), runeKey('r'), runeKey('a'), runeKey('p'))
+	got := m.paletteMatches()
+	if len(got) == 0 || len(got) >= len(all) || got[0].name != "Toggle wrap" {
+		t.Fatalf("filtered to %d of %d, first %+v", len(got), len(all), got)
+	}
+	if !strings.Contains(m.renderPaletteOverlay(), "Toggle wrap") {
+		t.Error("overlay does not show the match")
+	}
+	press(runeKey('z'), runeKey('z'))
+	if len(m.paletteMatches()) != 0 {
+		t.Error("expected no match for wrapzz")
+	}
+	press(tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace})
+
+	wrap := m.wrapLines
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.palette.open || m.wrapLines == wrap {
+		t.Errorf("enter should close the palette and toggle wrap (open %v, wrap %v)", m.palette.open, m.wrapLines)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlP}, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.palette.open || m.wrapLines == wrap {
+		t.Error("Ctrl+P then Esc should open and close without running anything")
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	for _, tt := range []struct {
+		name, query string
+		rank        int
+		ok          bool
+	}{
+		{"Toggle wrap", "wrap", 0, true},
+		{"Toggle wrap", "TGW", 1, true},
+		{"Toggle wrap", "", 0, true},
+		{"Toggle wrap", "pw", 0, false},
+	} {
+		rank, ok := fuzzyMatch(tt.name, tt.query)
+		if rank != tt.rank || ok != tt.ok {
+			t.Errorf("fuzzyMatch(%q, %q) = %d, %v; want %d, %v", tt.name, tt.query, rank, ok, tt.rank, tt.ok)
+		}
+	}
+}
+
+func TestPalette_LevelsAndSelectionFilterShareTheHelpTable(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = nm.(Model)
+
+	m.palette = paletteState{open: true}
+	names := make(map[string]bool)
+	for _, a := range m.paletteMatches() {
+		names[a.name] = true
+	}
+	for _, want := range []string{"Filter in by mouse selection", "Toggle level 1", "Toggle level 9", "Focus level 3"} {
+		if !names[want] {
+			t.Errorf("palette is missing %q", want)
+		}
+	}
+
+	m.palette = paletteState{open: true, query: "Focus level 3"}
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = nm.(Model)
+	if _, enabled := m.levels.GetSnapshot(); !enabled[3] || enabled[1] {
+		t.Errorf("Focus level 3 should leave only slot 3 enabled, got %v", enabled)
+	}
+
+	help := m.renderHelpOverlay()
+	for _, want := range []string{"F          — Filter In by mouse selection", "1..9", "Shift+1..9"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help is missing %q", want)
+		}
+	}
+	if strings.Contains(help, "Docker:") || strings.Contains(help, "Containers list") {
+		t.Error("help lists Docker keys outside docker mode")
+	}
+}
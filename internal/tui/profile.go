@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// ProfileOps is the built-in "operational view": noisy levels hidden and
+// common failure keywords highlighted.
+const ProfileOps = "ops"
+
+// defaultOpsKeywords are highlighted by the ops profile unless overridden
+// with "opsKeywords" in config.json.
+var defaultOpsKeywords = []string{"panic", "exception", "fatal"}
+
+// opsHiddenLevels are the level names the ops profile turns off.
+var opsHiddenLevels = []string{"DEBUG", "TRACE"}
+
+// ApplyProfile applies a built-in filter preset by name.
+func (m *Model) ApplyProfile(name string) error {
+	if name != ProfileOps {
+		return fmt.Errorf("unknown profile %q (want %q)", name, ProfileOps)
+	}
+	*m = m.applyOpsProfile()
+	return nil
+}
+
+// applyOpsProfile hides DEBUG/TRACE, also when they are first seen later,
+// enables every other level and adds the ops keywords as highlights (skipping
+// ones already highlighted).
+func (m Model) applyOpsProfile() Model {
+	names, enabled := m.levels.GetSnapshot()
+	for i := 1; i <= 9; i++ {
+		want := !slices.Contains(opsHiddenLevels, names[i])
+		if enabled[i] != want {
+			m.levels.Toggle(i)
+		}
+	}
+	m.levels.HideOnDiscovery(opsHiddenLevels)
+
+	keywords := m.opsKeywords
+	if len(keywords) == 0 {
+		keywords = defaultOpsKeywords
+	}
+	var bad []string
+	for _, kw := range keywords {
+		if slices.ContainsFunc(m.filters.Highlights, func(h core.TextMatcher) bool { return h.Raw() == kw }) {
+			continue
+		}
+		matcher, err := core.NewMatcher(kw)
+		if err != nil {
+			bad = append(bad, kw)
+			continue
+		}
+		m.filters.AddHighlight(matcher)
+	}
+	m.dirty = true
+	if len(bad) > 0 {
+		return m.setError("Ops view applied; invalid keywords skipped: " + strings.Join(bad, ", "))
+	}
+	return m.setError("Ops view: DEBUG/TRACE hidden, highlighting " + strings.Join(keywords, ", "))
+}
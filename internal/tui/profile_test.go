@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestOpsProfile_HidesNoiseAndHighlightsFailures(t *testing.T) {
+	levels := core.NewLevelMap()
+	detector := core.NewDefaultSeverityDetector(levels)
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), levels, ModeFile)
+	m.opsKeywords = nil // ignore any user config
+
+	var events []core.LogEvent
+	for _, line := range []string{"[TRACE] tick", "[DEBUG] cache miss", "[INFO] ready", "[WARN] slow", "[ERROR] panic: boom"} {
+		levelStr, level, _ := detector.Detect(line)
+		events = append(events, ring.Append(core.LogEvent{Line: line, LevelStr: levelStr, Level: level}))
+	}
+	levels.Toggle(3) // a prior manual toggle is reset by the profile
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = updated.(Model)
+
+	plan := core.VisiblePlan{LevelMap: levels}
+	want := map[string]bool{"[TRACE] tick": false, "[DEBUG] cache miss": false, "[INFO] ready": true, "[WARN] slow": true, "[ERROR] panic: boom": true}
+	for _, e := range events {
+		if got := core.ShouldShowEvent(e, plan); got != want[e.Line] {
+			t.Errorf("%q: expected visible=%v, got %v", e.Line, want[e.Line], got)
+		}
+	}
+
+	// TRACE and DEBUG stay hidden when first seen after the profile
+	later := NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	later.opsKeywords = nil
+	if err := later.ApplyProfile(ProfileOps); err != nil {
+		t.Fatalf("ApplyProfile: %v", err)
+	}
+	lateDetector := core.NewDefaultSeverityDetector(later.levels)
+	for _, line := range []string{"[TRACE] tick", "[NOTICE] rotated"} {
+		levelStr, level, _ := lateDetector.Detect(line)
+		e := core.LogEvent{Line: line, LevelStr: levelStr, Level: level}
+		if got, want := core.ShouldShowEvent(e, core.VisiblePlan{LevelMap: later.levels}), line != "[TRACE] tick"; got != want {
+			t.Errorf("%q discovered after the profile: expected visible=%v, got %v", line, want, got)
+		}
+	}
+
+	var raws []string
+	for _, h := range m.filters.Highlights {
+		raws = append(raws, h.Raw())
+	}
+	if len(raws) != len(defaultOpsKeywords) {
+		t.Fatalf("expected highlights %v, got %v", defaultOpsKeywords, raws)
+	}
+	for i, kw := range defaultOpsKeywords {
+		if raws[i] != kw {
+			t.Errorf("expected highlight %q, got %q", kw, raws[i])
+		}
+	}
+
+	// Applying again does not duplicate highlights
+	m = m.applyOpsProfile()
+	if len(m.filters.Highlights) != len(defaultOpsKeywords) {
+		t.Errorf("expected no duplicate highlights, got %d", len(m.filters.Highlights))
+	}
+
+	// Keywords are configurable
+	m2 := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m2.opsKeywords = []string{"oom", "/segfault|sigsegv/"}
+	if err := m2.ApplyProfile(ProfileOps); err != nil {
+		t.Fatalf("ApplyProfile: %v", err)
+	}
+	if len(m2.filters.Highlights) != 2 || !m2.filters.Highlights[1].IsRegex() {
+		t.Errorf("expected configured keywords as highlights, got %d", len(m2.filters.Highlights))
+	}
+	if err := m2.ApplyProfile("nope"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sparkWindow is the number of one-second buckets kept per container.
+const sparkWindow = 20
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// rateTracker counts log lines per container in one-second buckets over a
+// short sliding window. It is shared between model copies, hence the lock.
+type rateTracker struct {
+	mu     sync.Mutex
+	window int
+	series map[string]*rateSeries
+}
+
+// rateSeries holds per-second counts; buckets[len-1] is the second newest.
+type rateSeries struct {
+	buckets []int
+	newest  int64
+}
+
+func newRateTracker(window int) *rateTracker {
+	return &rateTracker{window: window, series: make(map[string]*rateSeries)}
+}
+
+// Add records one line for container at time t.
+func (r *rateTracker) Add(container string, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.series[container]
+	if !ok {
+		s = &rateSeries{buckets: make([]int, r.window), newest: t.Unix()}
+		r.series[container] = s
+	}
+	sec := t.Unix()
+	s.advance(sec)
+	age := s.newest - sec
+	if age >= int64(len(s.buckets)) {
+		return // older than the window
+	}
+	s.buckets[len(s.buckets)-1-int(age)]++
+}
+
+// Series returns the counts for container from oldest to newest, with the
+// last bucket being the second containing now.
+func (r *rateTracker) Series(container string, now time.Time) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]int, r.window)
+	s, ok := r.series[container]
+	if !ok {
+		return out
+	}
+	s.advance(now.Unix())
+	copy(out, s.buckets)
+	return out
+}
+
+// advance shifts the buckets so the newest one covers sec.
+func (s *rateSeries) advance(sec int64) {
+	if sec <= s.newest {
+		return
+	}
+	shift := sec - s.newest
+	s.newest = sec
+	if shift >= int64(len(s.buckets)) {
+		clear(s.buckets)
+		return
+	}
+	n := copy(s.buckets, s.buckets[shift:])
+	clear(s.buckets[n:])
+}
+
+// sparkline renders counts as block characters scaled to peak; a zero peak
+// renders a flat baseline.
+func sparkline(counts []int, peak int) string {
+	out := make([]rune, len(counts))
+	for i, c := range counts {
+		idx := 0
+		if peak > 0 && c > 0 {
+			idx = (c*(len(sparkBars)-1) + peak - 1) / peak
+		}
+		out[i] = sparkBars[idx]
+	}
+	return string(out)
+}
+
+// refreshSparklines recomputes the container sparklines. All containers share
+// one scale so the noisiest stands out.
+func (m Model) refreshSparklines(now time.Time) Model {
+	series := make(map[string][]int, len(m.dockerUI.Containers))
+	peak := 0
+	for name := range m.dockerUI.Containers {
+		counts := m.rates.Series(name, now)
+		series[name] = counts
+		for _, c := range counts {
+			peak = max(peak, c)
+		}
+	}
+	m.sparklines = make(map[string]string, len(series))
+	for name, counts := range series {
+		// The newest bucket is still filling; report the last complete second
+		m.sparklines[name] = fmt.Sprintf("%s %4d/s", sparkline(counts, peak), counts[len(counts)-2])
+	}
+	m.lastSparkUpdate = now
+	return m
+}
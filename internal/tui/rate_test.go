@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestRateTracker_Bucketing(t *testing.T) {
+	r := newRateTracker(4)
+	base := time.Unix(1000, 0)
+
+	r.Add("api", base)
+	r.Add("api", base.Add(300*time.Millisecond))
+	r.Add("api", base.Add(2*time.Second))
+	r.Add("db", base.Add(time.Second))
+
+	if got, want := r.Series("api", base.Add(2*time.Second)), []int{0, 2, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("api series: got %v, want %v", got, want)
+	}
+	if got, want := r.Series("api", base.Add(3*time.Second)), []int{2, 0, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("api series after a quiet second: got %v, want %v", got, want)
+	}
+	if got, want := r.Series("db", base.Add(3*time.Second)), []int{0, 1, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("db series: got %v, want %v", got, want)
+	}
+
+	// Late events within the window land in their own second; older ones are dropped
+	r.Add("api", base.Add(time.Second))
+	r.Add("api", base.Add(-10*time.Second))
+	if got, want := r.Series("api", base.Add(3*time.Second)), []int{2, 1, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("api series with late events: got %v, want %v", got, want)
+	}
+
+	// Everything ages out after the window
+	if got := r.Series("api", base.Add(time.Minute)); !reflect.DeepEqual(got, []int{0, 0, 0, 0}) {
+		t.Errorf("expected empty series after window, got %v", got)
+	}
+	if got := r.Series("unknown", base); !reflect.DeepEqual(got, []int{0, 0, 0, 0}) {
+		t.Errorf("expected empty series for unknown container, got %v", got)
+	}
+}
+
+func TestSparkline_Scale(t *testing.T) {
+	if got := sparkline([]int{0, 1, 4, 8}, 8); got != "▁▂▅█" {
+		t.Errorf("unexpected sparkline %q", got)
+	}
+	if got := sparkline([]int{0, 0}, 0); got != "▁▁" {
+		t.Errorf("expected flat sparkline for no traffic, got %q", got)
+	}
+}
+
+func TestContainerList_SparklineToggle(t *testing.T) {
+	m := *NewModel(core.NewRing(100), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	updated, _ := m.Update(DockerContainersMsg{Containers: map[string]bool{"api": true, "db": true}})
+	m = updated.(Model)
+	for i := 0; i < 5; i++ {
+		updated, _ = m.Update(LogAppendedMsg{Event: core.LogEvent{Container: "api", Line: "x"}})
+		m = updated.(Model)
+	}
+	m.dockerUI.ContainerListOpen = true
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(Model)
+	if !m.showSparklines {
+		t.Fatal("expected s to enable sparklines")
+	}
+	view := m.renderDockerContainerList()
+	if !strings.Contains(view, "█") || !strings.Contains(view, "/s") {
+		t.Errorf("expected sparkline in container list, got:\n%s", view)
+	}
+}
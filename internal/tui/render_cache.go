@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// renderCache memoizes per-event work across renders so that, while filters
+// and highlights are stable, each tick only matches and styles new lines.
+// Visibility is keyed by the include/exclude generation; styled rows by
+// everything that affects how a line is drawn.
+type renderCache struct {
+	filterGen uint64
+	visible   map[uint64]bool
+
+	style renderStyleKey
+	rows  map[uint64]renderedRows
+
+	// times memoizes core.EventTime for the chronological order
+	times map[uint64]timedEvent
+
+	// misses counts events styled from scratch (visibility misses are counted
+	// separately) so tests and benchmarks can observe cache effectiveness.
+	misses    int
+	visMisses int
+}
+
+// renderStyleKey captures the inputs that change an event's styled rows.
+type renderStyleKey struct {
+	highlightGen   uint64
+	findActive     bool
+	findRaw        string
+	findCase       bool
+	theme          *Theme
+	width          int
+	showTimestamps bool
+	wrapLines      bool
+	xOffset        int
+	linkify        bool
+	showControl    bool
+	compactTime    bool
+	location       *time.Location
+	timeLayout     string
+	prefixSep      string
+	prefixPad      int
+	hideSource     bool
+	severity       int
+	gutter         int
+}
+
+type timedEvent struct {
+	t  time.Time
+	ok bool
+}
+
+type renderedRows struct {
+	rows      []string
+	plain     []string // rows without styling, see layoutRows
+	current   bool     // rendered as the current find hit
+	blankTime bool     // timestamp elided (compact timestamps)
+}
+
+func newRenderCache() *renderCache {
+	return &renderCache{
+		visible: make(map[uint64]bool),
+		rows:    make(map[uint64]renderedRows),
+		times:   make(map[uint64]timedEvent),
+	}
+}
+
+// styleKey returns the style inputs for the model's current state
+func (m Model) styleKey() renderStyleKey {
+	key := renderStyleKey{
+		highlightGen:   m.filters.HighlightGeneration(),
+		findActive:     m.search.IsActive(),
+		theme:          m.theme,
+		width:          m.vp.Width,
+		showTimestamps: m.showTimestamps,
+		wrapLines:      m.wrapLines,
+		xOffset:        m.xOffset,
+		linkify:        m.linkify,
+		showControl:    m.showControl,
+		compactTime:    m.compactTime,
+		location:       m.location,
+		timeLayout:     m.timeLayout,
+		prefixSep:      m.prefixSep,
+		prefixPad:      m.prefixPad,
+		hideSource:     m.hideSource,
+		severity:       m.severityDisplay,
+		gutter:         m.gutterWidth(),
+	}
+	if key.findActive {
+		key.findRaw = m.search.GetMatcher().Raw()
+		key.findCase = m.search.GetMatcher().CaseSensitive()
+	}
+	return key
+}
+
+// sync drops cached entries whose inputs changed since the last render and
+// prunes entries for events that left a ring holding ringSize events.
+func (c *renderCache) sync(filterGen uint64, style renderStyleKey, oldestSeq uint64, ringSize int) {
+	if filterGen != c.filterGen {
+		c.filterGen = filterGen
+		c.visible = make(map[uint64]bool)
+	}
+	if style != c.style {
+		c.style = style
+		c.rows = make(map[uint64]renderedRows)
+	}
+	if len(c.visible) > 2*ringSize {
+		for seq := range c.visible {
+			if seq < oldestSeq {
+				delete(c.visible, seq)
+			}
+		}
+	}
+	if len(c.rows) > 2*ringSize {
+		for seq := range c.rows {
+			if seq < oldestSeq {
+				delete(c.rows, seq)
+			}
+		}
+	}
+	if len(c.times) > 2*ringSize {
+		for seq := range c.times {
+			if seq < oldestSeq {
+				delete(c.times, seq)
+			}
+		}
+	}
+}
+
+// eventTime is core.EventTime, parsed once per event
+func (m Model) eventTime(e core.LogEvent) (time.Time, bool) {
+	if t, ok := m.renderCache.times[e.Seq]; ok {
+		return t.t, t.ok
+	}
+	t, ok := core.EventTime(e)
+	m.renderCache.times[e.Seq] = timedEvent{t, ok}
+	return t, ok
+}
+
+// isVisible applies include/exclude filters, reusing the cached result
+func (m Model) isVisible(e core.LogEvent) bool {
+	if m.peeking {
+		return true
+	}
+	if v, ok := m.renderCache.visible[e.Seq]; ok {
+		return v
+	}
+	m.renderCache.visMisses++
+	v := m.filters.ShouldShowLine(e.Line)
+	m.renderCache.visible[e.Seq] = v
+	return v
+}
+
+// renderRows returns the styled, wrapped rows for an event and their plain
+// text, reusing the cached rows unless the event's current-find-hit status
+// or timestamp elision changed.
+func (m Model) renderRows(e core.LogEvent, currentHit uint64, blankTime bool) (rows, plain []string) {
+	isCurrent := currentHit != 0 && currentHit == e.Seq
+	if r, ok := m.renderCache.rows[e.Seq]; ok && r.current == isCurrent && r.blankTime == blankTime {
+		return r.rows, r.plain
+	}
+	m.renderCache.misses++
+	rows, plain = m.eventRows(e, blankTime)
+	if len(rows) == 0 {
+		rows, plain = []string{""}, []string{""}
+	}
+	m.renderCache.rows[e.Seq] = renderedRows{rows: rows, plain: plain, current: isCurrent, blankTime: blankTime}
+	return rows, plain
+}
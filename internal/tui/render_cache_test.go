@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func newCacheTestModel(n int) Model {
+	ring := core.NewRing(n)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	for i := 0; i < n; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("request id=%d status=%d path=/api/v1/items", i, 200+i%5)})
+	}
+	return m
+}
+
+func TestRenderCache_ReusesStableRows(t *testing.T) {
+	m := newCacheTestModel(50)
+	hl, _ := core.NewMatcher("/status=20[34]/")
+	m.filters.AddHighlight(hl)
+
+	m = m.updateViewportContent()
+	if m.renderCache.misses != 50 || m.renderCache.visMisses != 50 {
+		t.Fatalf("Expected 50 cold renders, got %d rows / %d visibility", m.renderCache.misses, m.renderCache.visMisses)
+	}
+
+	// Stable filters: a second render reuses everything
+	m = m.updateViewportContent()
+	if m.renderCache.misses != 50 || m.renderCache.visMisses != 50 {
+		t.Errorf("Expected no new work on stable render, got %d rows / %d visibility", m.renderCache.misses, m.renderCache.visMisses)
+	}
+
+	// New events only render themselves
+	m.ring.Append(core.LogEvent{Line: "status=204 late"})
+	m = m.updateViewportContent()
+	if m.renderCache.misses != 51 {
+		t.Errorf("Expected one new render for one new event, got %d total", m.renderCache.misses)
+	}
+
+	// Highlight change restyles the 50 buffered events but keeps visibility
+	hl2, _ := core.NewMatcher("items")
+	m.filters.AddHighlight(hl2)
+	m = m.updateViewportContent()
+	if m.renderCache.misses != 101 || m.renderCache.visMisses != 51 {
+		t.Errorf("Expected restyle only after highlight change, got %d rows / %d visibility", m.renderCache.misses, m.renderCache.visMisses)
+	}
+
+	// Filter change re-evaluates visibility but keeps rows
+	ex, _ := core.NewMatcher("late")
+	m.filters.AddExclude(ex)
+	m = m.updateViewportContent()
+	if m.renderCache.visMisses != 101 || m.renderCache.misses != 101 {
+		t.Errorf("Expected visibility-only recompute after filter change, got %d rows / %d visibility", m.renderCache.misses, m.renderCache.visMisses)
+	}
+	if len(m.seqIndex) != 49 {
+		t.Errorf("Expected excluded line to be hidden, got %d visible", len(m.seqIndex))
+	}
+}
+
+func TestRenderCache_FindCursorRestylesOnlyChangedHits(t *testing.T) {
+	m := newCacheTestModel(50)
+	m.search.SetActive(true)
+	matcher, _ := core.NewMatcher("status=200")
+	m.search.SetMatcher(matcher)
+	for _, e := range m.ring.Snapshot() {
+		if matcher.Match(e.Line) {
+			m.search.AddHit(e.Seq)
+		}
+	}
+	m.search.JumpToFirst()
+
+	m = m.updateViewportContent()
+	before := m.renderCache.misses
+
+	m.search.Next()
+	m = m.updateViewportContent()
+	if got := m.renderCache.misses - before; got != 2 {
+		t.Errorf("Expected only the old and new current hit to restyle, got %d", got)
+	}
+}
+
+// BenchmarkUpdateViewportContent compares re-rendering with stable highlights
+// (served from the render cache) against a highlight change on every tick.
+// The "renders/op" metric counts events that went through regex highlighting.
+func BenchmarkUpdateViewportContent(b *testing.B) {
+	b.Run("stable-highlights", func(b *testing.B) {
+		m := newCacheTestModel(2000)
+		hl, _ := core.NewMatcher("/status=20[34]/")
+		m.filters.AddHighlight(hl)
+		m = m.updateViewportContent()
+		start := m.renderCache.misses
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m = m.updateViewportContent()
+		}
+		b.ReportMetric(float64(m.renderCache.misses-start)/float64(b.N), "renders/op")
+	})
+
+	b.Run("changing-highlights", func(b *testing.B) {
+		m := newCacheTestModel(2000)
+		hl, _ := core.NewMatcher("/status=20[34]/")
+		m = m.updateViewportContent()
+		start := m.renderCache.misses
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.filters.ClearHighlights()
+			m.filters.AddHighlight(hl)
+			m = m.updateViewportContent()
+		}
+		b.ReportMetric(float64(m.renderCache.misses-start)/float64(b.N), "renders/op")
+	})
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// Severity display modes, cycled from the settings menu
+const (
+	severityBadge = iota // INFO badge in the prefix
+	severityBar          // colored column at the left edge of every row
+	severityNone
+)
+
+var severityDisplayNames = []string{"badge", "bar", "none"}
+
+// severityBarGlyph fills the bar column; events without a level get a blank
+const severityBarGlyph = "▌"
+
+// parseSeverityDisplay maps a persisted name to a mode; unknown names fall
+// back to the badge.
+func parseSeverityDisplay(name string) int {
+	for i, n := range severityDisplayNames {
+		if n == name {
+			return i
+		}
+	}
+	return severityBadge
+}
+
+// gutterWidth is the number of columns in front of every row: one for
+// bookmark marks while there are any, one for the severity bar
+func (m Model) gutterWidth() int {
+	width := 0
+	if len(m.bookmarks) > 0 {
+		width++
+	}
+	if m.severityDisplay == severityBar {
+		width++
+	}
+	return width
+}
+
+// eventRows lays out an event into viewport rows, prefixing each one with
+// the gutter: the bookmark mark on the first row, then the severity bar in
+// bar mode.
+func (m Model) eventRows(e core.LogEvent, blankTime bool) (rows, plain []string) {
+	gutter := m.gutterWidth()
+	if gutter == 0 {
+		return m.layoutRows(m.renderEventStyled(e, blankTime))
+	}
+	narrow := m
+	narrow.vp.Width = max(m.vp.Width-gutter, 1)
+	rows, plain = narrow.layoutRows(narrow.renderEventStyled(e, blankTime))
+	bar := ""
+	if m.severityDisplay == severityBar {
+		bar = " "
+		if e.LevelStr != "" {
+			bar = m.severityStyle(e.Level).Render(severityBarGlyph)
+		}
+	}
+	mark := ""
+	if len(m.bookmarks) > 0 {
+		mark = " "
+		if m.isBookmarked(e.Seq) {
+			mark = m.theme.PromptStyle.Render(bookmarkMark)
+		}
+	}
+	for i, row := range rows {
+		rows[i] = mark + bar + row
+		plain[i] = stripANSI(mark+bar) + plain[i]
+		if i == 0 && mark != "" {
+			mark = " "
+		}
+	}
+	return rows, plain
+}
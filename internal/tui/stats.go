@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// statsWindow is the number of one-second buckets behind the status line
+// rates: the last ten complete seconds plus the one filling.
+const statsWindow = 11
+
+// Series names in the stats tracker
+const (
+	statsLines  = "lines"
+	statsErrors = "errors"
+)
+
+// SetStats shows the lines/s and error rate indicator in the status line.
+func (m *Model) SetStats(enabled bool) {
+	m.showStats = enabled
+	if enabled {
+		*m = m.refreshStats(time.Now())
+	}
+}
+
+// SetErrorNav enables ] and [ to jump between error lines.
+func (m *Model) SetErrorNav(enabled bool) {
+	m.errorNav = enabled
+}
+
+// recordStats counts an appended event for the status line rates
+func (m Model) recordStats(e core.LogEvent, now time.Time) {
+	m.stats.Add(statsLines, now)
+	if e.Level == core.SevError {
+		m.stats.Add(statsErrors, now)
+	}
+}
+
+// refreshStats recomputes the status line rates, averaged over the last
+// complete seconds; the error rate is the share of those lines at ERROR.
+func (m Model) refreshStats(now time.Time) Model {
+	lines, errs := 0, 0
+	for _, c := range m.stats.Series(statsLines, now)[:statsWindow-1] {
+		lines += c
+	}
+	for _, c := range m.stats.Series(statsErrors, now)[:statsWindow-1] {
+		errs += c
+	}
+	rate := 0.0
+	if lines > 0 {
+		rate = float64(errs) * 100 / float64(lines)
+	}
+	m.statsText = fmt.Sprintf("%.1f lines/s, %.1f%% err", float64(lines)/(statsWindow-1), rate)
+	m.lastStatsUpdate = now
+	return m
+}
+
+// jumpToError scrolls to the next (or previous) visible error line, counted
+// from the last one jumped to while it is on screen, else from the middle of
+// the viewport.
+func (m Model) jumpToError(prev bool) Model {
+	ref := min(m.vp.YOffset+m.vp.Height/2, max(len(m.contentLines)-1, 0))
+	if row, ok := m.seqIndex[m.errorCursor]; ok && row >= m.vp.YOffset && row < m.vp.YOffset+m.vp.Height {
+		ref = row
+	}
+
+	var (
+		target uint64
+		found  bool
+	)
+	for _, e := range m.ring.Snapshot() {
+		row, ok := m.seqIndex[e.Seq]
+		if !ok || e.Level != core.SevError {
+			continue
+		}
+		if prev && row < ref {
+			target, found = e.Seq, true
+		} else if !prev && row > ref {
+			target, found = e.Seq, true
+			break
+		}
+	}
+	if !found {
+		if prev {
+			return m.setError("No error lines above")
+		}
+		return m.setError("No error lines below")
+	}
+	m.errorCursor = target
+	return m.scrollToSequence(target)
+}
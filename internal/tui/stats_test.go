@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestStats_LinesAndErrorRate(t *testing.T) {
+	m := *NewModel(core.NewRing(100), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	base := time.Unix(1000, 0)
+	for i := 0; i < 40; i++ {
+		e := core.LogEvent{Line: "ok", Level: core.SevInfo}
+		if i%4 == 0 {
+			e.Level = core.SevError
+		}
+		m.recordStats(e, base.Add(time.Duration(i)*100*time.Millisecond))
+	}
+
+	// Four seconds of traffic averaged over the ten-second window
+	m = m.refreshStats(base.Add(5 * time.Second))
+	if want := "4.0 lines/s, 25.0% err"; m.statsText != want {
+		t.Errorf("stats = %q, want %q", m.statsText, want)
+	}
+	if strings.Contains(m.renderStatusLine(), "lines/s") {
+		t.Error("stats shown in the status line while off")
+	}
+	m.showStats = true
+	if !strings.Contains(m.renderStatusLine(), "Rate: 4.0 lines/s, 25.0% err") {
+		t.Errorf("status line missing stats: %q", m.renderStatusLine())
+	}
+
+	if m = m.refreshStats(base.Add(time.Minute)); m.statsText != "0.0 lines/s, 0.0% err" {
+		t.Errorf("stats after a quiet minute = %q", m.statsText)
+	}
+}
+
+func TestErrorNav_JumpsBetweenErrors(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+
+	for i := 0; i < 100; i++ {
+		e := core.LogEvent{Line: fmt.Sprintf("info-%03d", i), Level: core.SevInfo}
+		if i == 20 || i == 50 || i == 80 {
+			e = core.LogEvent{Line: fmt.Sprintf("error-%03d", i), Level: core.SevError}
+		}
+		ring.Append(e)
+	}
+	m = m.updateViewportContent()
+	m.vp.GotoTop()
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+	centered := func() string {
+		e, _ := m.eventAtRow(m.vp.YOffset + m.vp.Height/2)
+		return e.Line
+	}
+
+	before := m.vp.YOffset
+	press("]")
+	if m.vp.YOffset != before {
+		t.Fatalf("] moved the view with error navigation off")
+	}
+
+	m.SetErrorNav(true)
+	for _, want := range []string{"error-020", "error-050", "error-080"} {
+		press("]")
+		if got := centered(); got != want {
+			t.Errorf("after ]: centered %q, want %q", got, want)
+		}
+	}
+	press("]")
+	if !strings.Contains(m.errMsg, "No error lines below") || centered() != "error-080" {
+		t.Errorf("expected to stay on the last error, got %q (%q)", centered(), m.errMsg)
+	}
+	press("[")
+	if got := centered(); got != "error-050" {
+		t.Errorf("after [: centered %q, want error-050", got)
+	}
+}
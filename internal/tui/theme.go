@@ -1,6 +1,14 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Theme defines all styles used by the UI so we can swap palettes easily.
 type Theme struct {
@@ -19,6 +27,7 @@ type Theme struct {
 	// Inline emphasis
 	HighlightStyle lipgloss.Style
 	FindHitStyle   lipgloss.Style
+	LinkStyle      lipgloss.Style
 
 	// Selection highlight (mouse drag)
 	SelectionStyle lipgloss.Style
@@ -32,6 +41,13 @@ type Theme struct {
 	PromptStyle      lipgloss.Style
 }
 
+// hexColor pairs a truecolor hex value with the 256-color code to use when
+// the terminal can't show truecolor. lipgloss picks the variant matching the
+// detected color profile; 16-color terminals get the nearest ANSI color.
+func hexColor(hex, ansi256 string) lipgloss.TerminalColor {
+	return lipgloss.CompleteColor{TrueColor: hex, ANSI256: ansi256, ANSI: hex}
+}
+
 func DarkTheme() *Theme {
 	return &Theme{
 		Name:            "dark",
@@ -46,6 +62,7 @@ func DarkTheme() *Theme {
 
 		HighlightStyle: lipgloss.NewStyle().Background(lipgloss.Color("220")).Foreground(lipgloss.Color("0")),
 		FindHitStyle:   lipgloss.NewStyle().Background(lipgloss.Color("201")).Foreground(lipgloss.Color("15")).Bold(true),
+		LinkStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("75")).Underline(true),
 		SelectionStyle: lipgloss.NewStyle().Background(lipgloss.Color("24")).Foreground(lipgloss.Color("255")),
 
 		ToolbarStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("250")).Bold(true),
@@ -58,54 +75,56 @@ func DarkTheme() *Theme {
 }
 
 func DraculaTheme() *Theme {
-	// Dracula palette-ish
+	// Dracula palette (hex on truecolor terminals, 256-color codes otherwise)
 	return &Theme{
 		Name:            "dracula",
-		DebugBadgeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
-		InfoBadgeStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true),
-		WarnBadgeStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("221")).Bold(true),
-		ErrorBadgeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("197")).Bold(true),
-		OtherBadgeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true),
+		DebugBadgeStyle: lipgloss.NewStyle().Foreground(hexColor("#6272a4", "244")),
+		InfoBadgeStyle:  lipgloss.NewStyle().Foreground(hexColor("#8be9fd", "81")).Bold(true),
+		WarnBadgeStyle:  lipgloss.NewStyle().Foreground(hexColor("#ffb86c", "221")).Bold(true),
+		ErrorBadgeStyle: lipgloss.NewStyle().Foreground(hexColor("#ff5555", "197")).Bold(true),
+		OtherBadgeStyle: lipgloss.NewStyle().Foreground(hexColor("#bd93f9", "141")).Bold(true),
 
-		ContainerStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("117")).Bold(true),
-		TimestampStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("60")),
+		ContainerStyle: lipgloss.NewStyle().Foreground(hexColor("#50fa7b", "117")).Bold(true),
+		TimestampStyle: lipgloss.NewStyle().Foreground(hexColor("#6272a4", "60")),
 
-		HighlightStyle: lipgloss.NewStyle().Background(lipgloss.Color("228")).Foreground(lipgloss.Color("0")),
-		FindHitStyle:   lipgloss.NewStyle().Background(lipgloss.Color("141")).Foreground(lipgloss.Color("231")).Bold(true),
-		SelectionStyle: lipgloss.NewStyle().Background(lipgloss.Color("63")).Foreground(lipgloss.Color("231")),
+		HighlightStyle: lipgloss.NewStyle().Background(hexColor("#f1fa8c", "228")).Foreground(hexColor("#282a36", "0")),
+		FindHitStyle:   lipgloss.NewStyle().Background(hexColor("#bd93f9", "141")).Foreground(hexColor("#f8f8f2", "231")).Bold(true),
+		LinkStyle:      lipgloss.NewStyle().Foreground(hexColor("#8be9fd", "117")).Underline(true),
+		SelectionStyle: lipgloss.NewStyle().Background(hexColor("#44475a", "63")).Foreground(hexColor("#f8f8f2", "231")),
 
-		ToolbarStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("235")).Bold(true),
-		HotkeyPillStyle:  lipgloss.NewStyle().Background(lipgloss.Color("250")).Foreground(lipgloss.Color("235")).Padding(0, 0),
+		ToolbarStyle:     lipgloss.NewStyle().Foreground(hexColor("#282a36", "235")).Bold(true),
+		HotkeyPillStyle:  lipgloss.NewStyle().Background(hexColor("#f8f8f2", "250")).Foreground(hexColor("#282a36", "235")).Padding(0, 0),
 		HotkeyKeyStyle:   lipgloss.NewStyle().Bold(true),
-		HotkeyLabelStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("60")),
-		StatusStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Italic(true),
-		PromptStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true),
+		HotkeyLabelStyle: lipgloss.NewStyle().Foreground(hexColor("#6272a4", "60")),
+		StatusStyle:      lipgloss.NewStyle().Foreground(hexColor("#6272a4", "245")).Italic(true),
+		PromptStyle:      lipgloss.NewStyle().Foreground(hexColor("#bd93f9", "141")).Bold(true),
 	}
 }
 
 func NordTheme() *Theme {
-	// Nord palette-ish
+	// Nord palette (hex on truecolor terminals, 256-color codes otherwise)
 	return &Theme{
 		Name:            "nord",
-		DebugBadgeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
-		InfoBadgeStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("44")).Bold(true),
-		WarnBadgeStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("179")).Bold(true),
-		ErrorBadgeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("204")).Bold(true),
-		OtherBadgeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Bold(true),
+		DebugBadgeStyle: lipgloss.NewStyle().Foreground(hexColor("#616e88", "245")),
+		InfoBadgeStyle:  lipgloss.NewStyle().Foreground(hexColor("#88c0d0", "44")).Bold(true),
+		WarnBadgeStyle:  lipgloss.NewStyle().Foreground(hexColor("#ebcb8b", "179")).Bold(true),
+		ErrorBadgeStyle: lipgloss.NewStyle().Foreground(hexColor("#bf616a", "204")).Bold(true),
+		OtherBadgeStyle: lipgloss.NewStyle().Foreground(hexColor("#b48ead", "141")).Bold(true),
 
-		ContainerStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("81")).Bold(true),
-		TimestampStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("243")),
+		ContainerStyle: lipgloss.NewStyle().Foreground(hexColor("#81a1c1", "81")).Bold(true),
+		TimestampStyle: lipgloss.NewStyle().Foreground(hexColor("#616e88", "243")),
 
-		HighlightStyle: lipgloss.NewStyle().Background(lipgloss.Color("153")).Foreground(lipgloss.Color("234")),
-		FindHitStyle:   lipgloss.NewStyle().Background(lipgloss.Color("39")).Foreground(lipgloss.Color("230")).Bold(true),
-		SelectionStyle: lipgloss.NewStyle().Background(lipgloss.Color("24")).Foreground(lipgloss.Color("230")),
+		HighlightStyle: lipgloss.NewStyle().Background(hexColor("#88c0d0", "153")).Foreground(hexColor("#2e3440", "234")),
+		FindHitStyle:   lipgloss.NewStyle().Background(hexColor("#5e81ac", "39")).Foreground(hexColor("#eceff4", "230")).Bold(true),
+		LinkStyle:      lipgloss.NewStyle().Foreground(hexColor("#88c0d0", "110")).Underline(true),
+		SelectionStyle: lipgloss.NewStyle().Background(hexColor("#434c5e", "24")).Foreground(hexColor("#eceff4", "230")),
 
-		ToolbarStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Bold(true),
-		HotkeyPillStyle:  lipgloss.NewStyle().Background(lipgloss.Color("195")).Foreground(lipgloss.Color("0")).Padding(0, 0),
+		ToolbarStyle:     lipgloss.NewStyle().Foreground(hexColor("#3b4252", "238")).Bold(true),
+		HotkeyPillStyle:  lipgloss.NewStyle().Background(hexColor("#eceff4", "195")).Foreground(hexColor("#2e3440", "0")).Padding(0, 0),
 		HotkeyKeyStyle:   lipgloss.NewStyle().Bold(true),
-		HotkeyLabelStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("236")),
-		StatusStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Italic(true),
-		PromptStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true),
+		HotkeyLabelStyle: lipgloss.NewStyle().Foreground(hexColor("#3b4252", "236")),
+		StatusStyle:      lipgloss.NewStyle().Foreground(hexColor("#7b88a1", "245")).Italic(true),
+		PromptStyle:      lipgloss.NewStyle().Foreground(hexColor("#88c0d0", "39")).Bold(true),
 	}
 }
 
@@ -123,6 +142,7 @@ func LightTheme() *Theme {
 
 		HighlightStyle: lipgloss.NewStyle().Background(lipgloss.Color("227")).Foreground(lipgloss.Color("0")),
 		FindHitStyle:   lipgloss.NewStyle().Background(lipgloss.Color("171")).Foreground(lipgloss.Color("0")).Bold(true),
+		LinkStyle:      lipgloss.NewStyle().Foreground(lipgloss.Color("25")).Underline(true),
 		SelectionStyle: lipgloss.NewStyle().Background(lipgloss.Color("111")).Foreground(lipgloss.Color("0")),
 
 		ToolbarStyle:     lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Bold(true),
@@ -152,3 +172,96 @@ func themeNames() []string {
 	}
 	return out
 }
+
+// themeOverrideFields maps override keys to the style they replace and whether a
+// plain color applies to the background (emphasis styles) or the foreground.
+var themeOverrideFields = map[string]struct {
+	style      func(*Theme) *lipgloss.Style
+	background bool
+}{
+	"debug":     {func(t *Theme) *lipgloss.Style { return &t.DebugBadgeStyle }, false},
+	"info":      {func(t *Theme) *lipgloss.Style { return &t.InfoBadgeStyle }, false},
+	"warn":      {func(t *Theme) *lipgloss.Style { return &t.WarnBadgeStyle }, false},
+	"error":     {func(t *Theme) *lipgloss.Style { return &t.ErrorBadgeStyle }, false},
+	"other":     {func(t *Theme) *lipgloss.Style { return &t.OtherBadgeStyle }, false},
+	"container": {func(t *Theme) *lipgloss.Style { return &t.ContainerStyle }, false},
+	"timestamp": {func(t *Theme) *lipgloss.Style { return &t.TimestampStyle }, false},
+	"highlight": {func(t *Theme) *lipgloss.Style { return &t.HighlightStyle }, true},
+	"find":      {func(t *Theme) *lipgloss.Style { return &t.FindHitStyle }, true},
+	"link":      {func(t *Theme) *lipgloss.Style { return &t.LinkStyle }, false},
+	"selection": {func(t *Theme) *lipgloss.Style { return &t.SelectionStyle }, true},
+}
+
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ValidateThemeOverrides checks that every key names an overridable style and
+// every value is either a theme name (copy that style) or a color: an ANSI
+// index 0-255 or a #rgb/#rrggbb hex value.
+func ValidateThemeOverrides(overrides map[string]string) error {
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := themeOverrideFields[k]; !ok {
+			return fmt.Errorf("unknown theme override %q (want one of %s)", k, strings.Join(themeOverrideKeys(), ", "))
+		}
+		v := overrides[k]
+		if isThemeName(v) || isColor(v) {
+			continue
+		}
+		return fmt.Errorf("invalid value %q for theme override %q: want a theme name, 0-255, or #rrggbb", v, k)
+	}
+	return nil
+}
+
+// applyThemeOverrides returns a copy of base with the given overrides merged in.
+// Overrides must already be validated; the shared base theme is never mutated.
+func applyThemeOverrides(base *Theme, overrides map[string]string) *Theme {
+	if len(overrides) == 0 {
+		return base
+	}
+	t := *base
+	for k, v := range overrides {
+		field, ok := themeOverrideFields[k]
+		if !ok {
+			continue
+		}
+		dst := field.style(&t)
+		if isThemeName(v) {
+			*dst = *field.style(themeByName(v))
+		} else if field.background {
+			*dst = dst.Background(lipgloss.Color(v))
+		} else {
+			*dst = dst.Foreground(lipgloss.Color(v))
+		}
+	}
+	return &t
+}
+
+func themeOverrideKeys() []string {
+	keys := make([]string, 0, len(themeOverrideFields))
+	for k := range themeOverrideFields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isThemeName(name string) bool {
+	for _, t := range themes {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isColor(v string) bool {
+	if hexColorRe.MatchString(v) {
+		return true
+	}
+	n, err := strconv.Atoi(v)
+	return err == nil && n >= 0 && n <= 255
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/muesli/termenv"
+)
+
+func TestThemeOverrides_ErrorColorOnly(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	if err := m.SetThemeOverrides(map[string]string{"error": "#ff0000"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.SetTheme("dark")
+
+	base := DarkTheme()
+	if got := m.theme.ErrorBadgeStyle.GetForeground(); got != lipgloss.Color("#ff0000") {
+		t.Errorf("expected error badge foreground #ff0000, got %v", got)
+	}
+	if !m.theme.ErrorBadgeStyle.GetBold() {
+		t.Error("expected error badge to keep the base theme's bold attribute")
+	}
+
+	// Every other field must match the base theme
+	merged := *m.theme
+	merged.ErrorBadgeStyle = base.ErrorBadgeStyle
+	if !reflect.DeepEqual(&merged, base) {
+		t.Error("expected only the error badge style to differ from the base theme")
+	}
+
+	// The shared theme list must not be mutated
+	if themeByName("dark").ErrorBadgeStyle.GetForeground() != base.ErrorBadgeStyle.GetForeground() {
+		t.Error("expected base theme in themes list to be untouched")
+	}
+}
+
+func TestThemeOverrides_FromSecondaryTheme(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	if err := m.SetThemeOverrides(map[string]string{"highlight": "light"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.SetTheme("nord")
+
+	if !reflect.DeepEqual(m.theme.HighlightStyle, LightTheme().HighlightStyle) {
+		t.Error("expected highlight style copied from the light theme")
+	}
+	if m.theme.Name != "nord" {
+		t.Errorf("expected base theme nord, got %s", m.theme.Name)
+	}
+
+	m.cycleTheme(1)
+	if !reflect.DeepEqual(m.theme.HighlightStyle, LightTheme().HighlightStyle) {
+		t.Error("expected overrides to survive theme cycling")
+	}
+}
+
+func TestValidateThemeOverrides(t *testing.T) {
+	testCases := []struct {
+		overrides map[string]string
+		wantErr   bool
+	}{
+		{map[string]string{"error": "196"}, false},
+		{map[string]string{"error": "#f00", "find": "dracula"}, false},
+		{map[string]string{"errors": "196"}, true},
+		{map[string]string{"error": "256"}, true},
+		{map[string]string{"highlight": "#12345"}, true},
+		{map[string]string{"info": "solarized"}, true},
+	}
+
+	for i, tc := range testCases {
+		err := ValidateThemeOverrides(tc.overrides)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Test case %d: expected error=%t, got %v", i, tc.wantErr, err)
+		}
+	}
+
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m.SetTheme("dark")
+	if err := m.SetThemeOverrides(map[string]string{"error": "red"}); err == nil {
+		t.Error("expected invalid override to be rejected")
+	}
+	if !reflect.DeepEqual(m.theme, DarkTheme()) {
+		t.Error("expected rejected overrides to leave the theme untouched")
+	}
+}
+
+func TestHexTheme_DegradesByColorProfile(t *testing.T) {
+	fg := DraculaTheme().ErrorBadgeStyle.GetForeground()
+
+	tests := []struct {
+		profile termenv.Profile
+		want    string
+	}{
+		{termenv.TrueColor, "\x1b[38;2;255;85;85m"},
+		{termenv.ANSI256, "\x1b[38;5;197m"},
+		{termenv.ANSI, "\x1b[91m"},
+	}
+	for _, tt := range tests {
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetColorProfile(tt.profile)
+		got := r.NewStyle().Foreground(fg).Render("x")
+		if !strings.HasPrefix(got, tt.want) {
+			t.Errorf("profile %v: expected %q prefix, got %q", tt.profile, tt.want, got)
+		}
+	}
+
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.Ascii)
+	if got := r.NewStyle().Foreground(fg).Render("x"); got != "x" {
+		t.Errorf("expected no color under ascii profile, got %q", got)
+	}
+}
+
+func TestThemeCycle_RestoredOnNextRun(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	if m.theme.Name != "dark" {
+		t.Fatalf("expected the default theme, got %q", m.theme.Name)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	cycled := updated.(Model).theme.Name
+	if cycled == "dark" {
+		t.Fatal("expected t to change the theme")
+	}
+
+	next := NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	if next.theme.Name != cycled {
+		t.Errorf("expected %q restored, got %q", cycled, next.theme.Name)
+	}
+	// An explicit theme (--theme) applies on top without being saved
+	next.SetTheme("light")
+	if again := NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile); again.theme.Name != cycled {
+		t.Errorf("expected SetTheme not to persist, got %q", again.theme.Name)
+	}
+}
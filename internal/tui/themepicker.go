@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// themePickerState is the theme picker overlay (Ctrl+T): every theme with a
+// sample line drawn in its styles.
+type themePickerState struct {
+	open bool
+	sel  int
+}
+
+// openThemePicker lists the themes, starting at the current one
+func (m Model) openThemePicker() Model {
+	m.themePicker = themePickerState{open: true, sel: m.themeIdx}
+	return m
+}
+
+// handleThemePickerKey moves the selection; enter applies the selected theme
+func (m Model) handleThemePickerKey(key string) Model {
+	p := &m.themePicker
+	switch key {
+	case "up":
+		p.sel = max(p.sel-1, 0)
+	case "down":
+		p.sel = min(p.sel+1, len(themes)-1)
+	case "enter":
+		p.open = false
+		m.SetTheme(themes[p.sel].Name)
+		m.persistSettings()
+		return m.setError("Theme: " + themes[p.sel].Name)
+	case "esc", "q", "ctrl+t":
+		p.open = false
+	}
+	return m
+}
+
+// themeSample renders a short log line in the styles of t
+func themeSample(t *Theme) string {
+	return strings.Join([]string{
+		t.TimestampStyle.Render("12:04:05"),
+		t.ContainerStyle.Render("[api]"),
+		t.InfoBadgeStyle.Render("INFO"),
+		t.WarnBadgeStyle.Render("WARN"),
+		t.ErrorBadgeStyle.Render("ERROR"),
+		"GET " + t.LinkStyle.Render("/health") + " " + t.HighlightStyle.Render("timeout") + " " + t.FindHitStyle.Render("503"),
+	}, " ")
+}
+
+// renderThemePickerOverlay shows each theme name above its sample line
+func (m Model) renderThemePickerOverlay() string {
+	width := min(60, m.width-4)
+	lines := []string{"Themes", ""}
+	for i, t := range themes {
+		cursor := "  "
+		if i == m.themePicker.sel {
+			cursor = "> "
+		}
+		name := t.Name
+		if i == m.themeIdx {
+			name += " (current)"
+		}
+		lines = append(lines, cursor+name, "    "+themeSample(applyThemeOverrides(t, m.themeOverrides)), "")
+	}
+	lines = append(lines, "Up/Down: select • Enter: apply • Esc: close")
+
+	for i, line := range lines {
+		lines[i] = xansi.Truncate(line, width-2, "…")
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
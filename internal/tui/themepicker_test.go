@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/germanoeich/siftail/internal/core"
+	"github.com/muesli/termenv"
+)
+
+func TestThemeSample_UsesThemeStyles(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	samples := make(map[string]bool)
+	for _, theme := range themes {
+		sample := themeSample(theme)
+		for _, part := range []string{
+			theme.TimestampStyle.Render("12:04:05"),
+			theme.ErrorBadgeStyle.Render("ERROR"),
+			theme.HighlightStyle.Render("timeout"),
+		} {
+			if !strings.Contains(sample, part) {
+				t.Errorf("%s sample %q lacks %q", theme.Name, sample, part)
+			}
+		}
+		samples[sample] = true
+	}
+	if len(samples) != len(themes) {
+		t.Errorf("expected a distinct sample per theme, got %d for %d themes", len(samples), len(themes))
+	}
+}
+
+func TestThemePicker_AppliesSelection(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = nm.(Model)
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = nm.(Model)
+	if !m.themePicker.open {
+		t.Fatal("Ctrl+T did not open the theme picker")
+	}
+	view := m.View()
+	for _, theme := range themes {
+		if !strings.Contains(view, theme.Name) {
+			t.Errorf("picker does not list %s", theme.Name)
+		}
+	}
+
+	for _, key := range []tea.KeyType{tea.KeyDown, tea.KeyDown, tea.KeyEnter} {
+		nm, _ = m.Update(tea.KeyMsg{Type: key})
+		m = nm.(Model)
+	}
+	if m.themePicker.open || m.theme.Name != themes[2].Name {
+		t.Errorf("theme %q (picker open %v), want %q applied", m.theme.Name, m.themePicker.open, themes[2].Name)
+	}
+}
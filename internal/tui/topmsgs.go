@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// topMessagesRows is how many templates the overlay shows per page
+const topMessagesRows = 15
+
+// topMessagesState is the message histogram overlay: the visible lines
+// grouped by message template, most frequent first.
+type topMessagesState struct {
+	open   bool
+	counts []core.TemplateCount
+	total  int
+	sel    int
+}
+
+// openTopMessages counts the templates of the lines that pass the filters
+func (m Model) openTopMessages() Model {
+	plan := core.VisiblePlan{Include: m.textFilters(), LevelMap: m.levels, DockerVisible: m.dockerUI.Containers, SinceSeq: m.sinceSeq, UntilSeq: m.untilSeq}
+	events := core.ComputeVisible(m.ring.Snapshot(), plan)
+	if len(events) == 0 {
+		return m.setError("Nothing visible to count")
+	}
+	m.topMessages = topMessagesState{open: true, counts: core.TopTemplates(events, 0), total: len(events)}
+	return m
+}
+
+// handleTopMessagesKey scrolls the histogram
+func (m Model) handleTopMessagesKey(key string) Model {
+	t := &m.topMessages
+	last := len(t.counts) - 1
+	switch key {
+	case "up":
+		t.sel = max(t.sel-1, 0)
+	case "down":
+		t.sel = min(t.sel+1, last)
+	case "pgup":
+		t.sel = max(t.sel-topMessagesRows, 0)
+	case "pgdown":
+		t.sel = min(t.sel+topMessagesRows, last)
+	case "home":
+		t.sel = 0
+	case "end":
+		t.sel = last
+	case "esc", "q", "T", "enter":
+		t.open = false
+	}
+	return m
+}
+
+// renderTopMessagesOverlay ranks the templates with their counts and share
+// of the visible lines
+func (m Model) renderTopMessagesOverlay() string {
+	t := m.topMessages
+	width := min(100, m.width-4)
+	pages := (len(t.counts) + topMessagesRows - 1) / topMessagesRows
+	page := t.sel / topMessagesRows
+	lines := []string{
+		fmt.Sprintf("Top messages — %d templates in %d lines (page %d/%d)", len(t.counts), t.total, page+1, pages),
+		"",
+	}
+
+	start := page * topMessagesRows
+	for i := start; i < min(start+topMessagesRows, len(t.counts)); i++ {
+		cursor := "  "
+		if i == t.sel {
+			cursor = "> "
+		}
+		c := t.counts[i]
+		lines = append(lines, fmt.Sprintf("%s%4d. %7d %5.1f%%  %s", cursor, i+1, c.Count, 100*float64(c.Count)/float64(t.total), c.Template))
+	}
+	lines = append(lines, "", "Up/Down: select • PgUp/PgDn: page • Esc: close")
+
+	for i, line := range lines {
+		lines[i] = xansi.Truncate(line, width-2, "…")
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
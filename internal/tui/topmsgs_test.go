@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestTopMessages_RanksTemplatesOfVisibleLines(t *testing.T) {
+	ring := core.NewRing(100)
+	filters := core.NewFilters()
+	m := *NewModel(ring, filters, core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = nm.(Model)
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+	press("T")
+	if m.topMessages.open || !strings.Contains(m.errMsg, "Nothing visible") {
+		t.Fatalf("empty ring: open=%v status=%q", m.topMessages.open, m.errMsg)
+	}
+
+	for i := 0; i < 6; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("job %d finished in %dms", i, 10*i)})
+	}
+	for i := 0; i < 2; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("healthz ok %d", i)})
+	}
+	exclude, _ := core.NewMatcher("healthz")
+	filters.AddExclude(exclude)
+
+	press("T")
+	if !m.topMessages.open || len(m.topMessages.counts) != 1 || m.topMessages.total != 6 {
+		t.Fatalf("expected one template over the 6 visible lines, got %+v", m.topMessages)
+	}
+	if view := m.View(); !strings.Contains(view, "job <n> finished in <n>ms") || !strings.Contains(view, "100.0%") {
+		t.Errorf("overlay missing the ranked template:\n%s", view)
+	}
+
+	press("q")
+	if m.topMessages.open {
+		t.Error("q should close the overlay")
+	}
+}
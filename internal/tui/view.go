@@ -2,9 +2,9 @@ package tui
 
 import (
 	"fmt"
-	"regexp"
-	"sort"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	xansi "github.com/charmbracelet/x/ansi"
@@ -25,7 +25,7 @@ func (m Model) View() string {
 	sections = append(sections, m.renderStatusLine())
 
 	// Main viewport content
-	sections = append(sections, m.vp.View())
+	sections = append(sections, m.renderViewport())
 
 	// Prompt overlay or toolbar at bottom
 	if m.inPrompt {
@@ -36,6 +36,20 @@ func (m Model) View() string {
 
 	baseView := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
+	if m.peekOpen {
+		overlay := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("63")).
+			Padding(0, 1).
+			Width(min(100, m.width-4)).
+			Render(m.peekLine + "\n\n" + m.theme.StatusStyle.Render("(any key or click to close)"))
+		return lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height).
+			Render(overlay)
+	}
+
 	// Help overlay (if open) — precedence after Docker overlays
 	if m.helpOpen {
 		overlay := m.renderHelpOverlay()
@@ -68,6 +82,66 @@ func (m Model) View() string {
 		return overlayStyle.Render(overlay)
 	}
 
+	// Message histogram overlay (if open)
+	if m.topMessages.open {
+		overlay := m.renderTopMessagesOverlay()
+		overlayStyle := lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height)
+		return overlayStyle.Render(overlay)
+	}
+
+	// Command palette overlay (if open)
+	if m.palette.open {
+		overlay := m.renderPaletteOverlay()
+		overlayStyle := lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height)
+		return overlayStyle.Render(overlay)
+	}
+
+	// Theme picker overlay (if open)
+	if m.themePicker.open {
+		overlay := m.renderThemePickerOverlay()
+		overlayStyle := lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height)
+		return overlayStyle.Render(overlay)
+	}
+
+	// Find list overlay (if open)
+	if m.findList.open {
+		overlay := m.renderFindListOverlay()
+		overlayStyle := lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height)
+		return overlayStyle.Render(overlay)
+	}
+
+	// Disk find overlay (if open)
+	if m.diskFind.open {
+		overlay := m.renderDiskFindOverlay()
+		overlayStyle := lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height)
+		return overlayStyle.Render(overlay)
+	}
+
+	// Filters overlay (if open)
+	if m.filtersOpen {
+		overlay := m.renderFiltersOverlay()
+		overlayStyle := lipgloss.NewStyle().
+			Align(lipgloss.Center, lipgloss.Center).
+			Width(m.width).
+			Height(m.height)
+		return overlayStyle.Render(overlay)
+	}
+
 	// Settings overlay (if open)
 	if m.settingsMenuOpen {
 		overlay := m.renderSettingsMenu()
@@ -104,12 +178,24 @@ func (m Model) renderStatusLine() string {
 		modeStr = "STDIN"
 	case ModeDocker:
 		modeStr = "DOCKER"
+	case ModeCommand:
+		modeStr = "CMD"
 	}
 	parts = append(parts, fmt.Sprintf("[%s]", modeStr))
 
-	// Log count
-	totalEvents := m.ring.Size()
-	parts = append(parts, fmt.Sprintf("Lines: %d", totalEvents))
+	// Log count (or startup progress while the initial read is running)
+	if m.loading {
+		parts = append(parts, m.renderLoading())
+	} else {
+		totalEvents := m.ring.Size()
+		parts = append(parts, fmt.Sprintf("Lines: %d", totalEvents))
+		if m.visibleCount != totalEvents || len(m.filters.Include)+len(m.filters.Exclude) > 0 {
+			parts = append(parts, fmt.Sprintf("Visible: %d/%d", m.visibleCount, totalEvents))
+		}
+	}
+	if pos, ok := m.scrollPosition(); ok {
+		parts = append(parts, "Pos: "+pos)
+	}
 
 	// Active filters
 	if len(m.filters.Include) > 0 {
@@ -125,7 +211,15 @@ func (m Model) renderStatusLine() string {
 	// Find status
 	if m.search.IsActive() {
 		current, total := m.search.Position()
-		parts = append(parts, fmt.Sprintf("Find: %d/%d", current, total))
+		caseMode := "aa"
+		if m.search.GetMatcher().CaseSensitive() {
+			caseMode = "Aa"
+		}
+		find := fmt.Sprintf("Find: %d/%d %s", current, total, caseMode)
+		if m.findFollow {
+			find += " follow"
+		}
+		parts = append(parts, find)
 	}
 
 	// Docker container count (in docker mode)
@@ -137,6 +231,47 @@ func (m Model) renderStatusLine() string {
 			}
 		}
 		parts = append(parts, fmt.Sprintf("Containers: %d/%d", visibleContainers, len(m.dockerUI.Containers)))
+		if focus, ok := m.containerFocusStatus(); ok {
+			parts = append(parts, focus)
+		}
+	}
+
+	if !m.wrapLines {
+		parts = append(parts, fmt.Sprintf("NoWrap: +%d", m.xOffset))
+	}
+
+	if m.showControl {
+		parts = append(parts, "Ctrl: ^X")
+	}
+
+	if m.hideSource {
+		parts = append(parts, "NoNames")
+	}
+
+	if m.showStats {
+		parts = append(parts, "Rate: "+m.statsText)
+	}
+
+	if m.idlePaused {
+		parts = append(parts, "Idle: follow paused")
+	}
+
+	if m.peeking {
+		parts = append(parts, "PEEK")
+	}
+
+	if m.paused {
+		parts = append(parts, "PAUSED")
+	}
+
+	if m.overload.Dropped > 0 {
+		parts = append(parts, fmt.Sprintf("Dropping %d lines/s due to load", m.overload.Dropped))
+	} else if m.overload.Paused {
+		parts = append(parts, "Input paused: UI behind")
+	}
+
+	if m.sinceSeq != 0 || m.untilSeq != 0 {
+		parts = append(parts, "Window: "+m.windowText())
 	}
 
 	// Error message with timestamp
@@ -156,6 +291,28 @@ func (m Model) renderStatusLine() string {
 	return statusLine
 }
 
+var loadingFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// renderLoading shows a spinner with line/byte counts while the initial file read runs.
+func (m Model) renderLoading() string {
+	frame := loadingFrames[m.loadFrameIdx%len(loadingFrames)]
+	return fmt.Sprintf("%s Loading… %d lines, %s", frame, m.loadLines, formatBytes(m.loadBytes))
+}
+
+// formatBytes renders a byte count using binary units.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // renderToolbar displays the nano-style hotkey toolbar
 func (m Model) renderToolbar() string {
 	// First line: render hotkeys as per-element "pills"
@@ -171,12 +328,16 @@ func (m Model) renderToolbar() string {
 		hk{"c", "Clear"},
 		hk{"C", "ClearAll"},
 		hk{"t", "Theme"},
-		hk{"Mouse", "Drag-to-Copy"},
-		hk{"?", "Help"},
+		hk{"d", "Time"},
 	)
+	if m.mouseCapture {
+		keys = append(keys, hk{"Mouse", "Drag-to-Copy"})
+	}
+	keys = append(keys, hk{"?", "Help"})
 	if m.mode == ModeDocker {
-		keys = append(keys, hk{"Ctrl+D", "Containers"}, hk{"p", "Presets"})
+		keys = append(keys, hk{"Ctrl+D", "Containers"})
 	}
+	keys = append(keys, hk{"p", "Presets"})
 
 	renderHK := func(k hk) string {
 		// Only the key gets a background; the label stays plain/themed
@@ -228,40 +389,53 @@ func (m Model) renderClearMenu() string {
 	return overlay
 }
 
-// renderHelpOverlay shows a modal with the full command list
-func (m Model) renderHelpOverlay() string {
+// renderFiltersOverlay lists the include/exclude filters with how many of
+// the buffered lines each one matched, to spot filters doing no work.
+func (m Model) renderFiltersOverlay() string {
 	var lines []string
-	lines = append(lines, "Help — Key Bindings (Esc/? to close)")
-	lines = append(lines, "")
-	lines = append(lines, "Navigation:")
-	lines = append(lines, "  PgUp/PgDn  — scroll by page")
-	lines = append(lines, "  Home/End   — jump to top/bottom")
-	lines = append(lines, "  Wheel      — scroll")
-	lines = append(lines, "")
-	lines = append(lines, "Find/Highlight:")
-	lines = append(lines, "  Ctrl+F     — Find; Up/Down jump matches")
-	lines = append(lines, "  h          — Highlight (no jump)")
-	lines = append(lines, "  Esc        — Clear active Find")
-	lines = append(lines, "")
-	lines = append(lines, "Filters:")
-	lines = append(lines, "  I          — Filter In")
-	lines = append(lines, "  O          — Filter Out")
-	lines = append(lines, "  c / C      — Clear filters (menu / all)")
-	lines = append(lines, "")
-	lines = append(lines, "Severity:")
-	lines = append(lines, "  1..9       — Toggle buckets")
-	lines = append(lines, "  Shift+1..9 — Focus a bucket; press again to enable all")
-	lines = append(lines, "  0          — Enable all")
+	lines = append(lines, "Filters — lines matched (Esc/i to close)")
 	lines = append(lines, "")
-	lines = append(lines, "Docker:")
-	lines = append(lines, "  Ctrl+D     — Containers list")
-	lines = append(lines, "  p          — Presets")
-	lines = append(lines, "")
-	lines = append(lines, "Misc:")
-	lines = append(lines, "  Ctrl+O     — Settings (timestamps, theme)")
-	lines = append(lines, "  t          — Cycle theme")
-	lines = append(lines, "  Mouse drag — Select and copy")
-	lines = append(lines, "  ^Q         — Quit")
+	for i, f := range m.filters.Include {
+		lines = append(lines, fmt.Sprintf("include[%d] %q: %d", i, f.Raw(), hitCount(m.filterHits.Include, i)))
+	}
+	for i, f := range m.filters.Exclude {
+		lines = append(lines, fmt.Sprintf("exclude[%d] %q: %d", i, f.Raw(), hitCount(m.filterHits.Exclude, i)))
+	}
+	if len(m.filters.Include)+len(m.filters.Exclude) == 0 {
+		lines = append(lines, "No include/exclude filters")
+	}
+
+	content := strings.Join(lines, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("205")).
+		Padding(1).
+		Width(min(70, m.width-4)).
+		Render(content)
+}
+
+// hitCount returns counts[i], or 0 while the counts predate a new filter
+func hitCount(counts []int, i int) int {
+	if i < len(counts) {
+		return counts[i]
+	}
+	return 0
+}
+
+// renderHelpOverlay shows a modal with the full command list
+func (m Model) renderHelpOverlay() string {
+	lines := []string{"Help — Key Bindings (Esc/? to close)"}
+	section := ""
+	for _, a := range keyActions {
+		if !a.available(m) {
+			continue
+		}
+		if a.section != section {
+			section = a.section
+			lines = append(lines, "", section+":")
+		}
+		lines = append(lines, fmt.Sprintf("  %-10s — %s", a.keys, a.help))
+	}
 
 	content := strings.Join(lines, "\n")
 	width := min(72, m.width-4)
@@ -274,16 +448,29 @@ func (m Model) renderHelpOverlay() string {
 	return overlay
 }
 
-// renderSettingsMenu shows toggles for timestamps and theme selection.
+// settingsItems is the number of rows in the settings menu
+const settingsItems = 5
+
+// renderSettingsMenu shows toggles for timestamps, theme selection and links.
 func (m Model) renderSettingsMenu() string {
 	items := []string{
 		"Show Timestamps",
 		"Theme",
+		"Links (URLs/paths)",
+		"Decode copies (JSON/URL/base64)",
+		"Severity",
 	}
 
+	timestamps := "Off"
+	if m.showTimestamps {
+		timestamps = map[bool]string{true: "Compact", false: "On"}[m.compactTime]
+	}
 	vals := []string{
-		map[bool]string{true: "On", false: "Off"}[m.showTimestamps],
+		timestamps,
 		m.theme.Name,
+		map[bool]string{true: "On", false: "Off"}[m.linkify],
+		map[bool]string{true: "On", false: "Off"}[m.decodeCopies],
+		[]string{"Badge", "Bar", "None"}[m.severityDisplay],
 	}
 
 	var lines []string
@@ -357,6 +544,16 @@ func (m Model) renderPrompt() string {
 		promptLabel = "Filter Out: "
 	case PromptPresetName:
 		promptLabel = "Preset Name: "
+	case PromptDiskFind:
+		promptLabel = "Find on disk: "
+	case PromptGotoSeq:
+		promptLabel = "Go to line #: "
+	case PromptBufferSize:
+		promptLabel = "Buffer size: "
+	case PromptExportPath:
+		promptLabel = "Export to: "
+	case PromptLevelDiscovery:
+		promptLabel = "First line of level: "
 	}
 
 	prompt := lipgloss.JoinHorizontal(
@@ -364,6 +561,14 @@ func (m Model) renderPrompt() string {
 		m.theme.PromptStyle.Render(promptLabel),
 		m.input.View(),
 	)
+	switch {
+	case m.promptErr != "":
+		prompt += "  " + m.theme.ErrorBadgeStyle.Render(m.promptErr)
+	case m.patternErr != nil:
+		prompt += "  " + m.theme.ErrorBadgeStyle.Render("✗ "+m.patternErr.Error())
+	case m.patternValid:
+		prompt += "  " + m.theme.PromptStyle.Render("✓ valid")
+	}
 
 	return lipgloss.NewStyle().
 		Width(m.width).
@@ -371,6 +576,46 @@ func (m Model) renderPrompt() string {
 		Render(prompt)
 }
 
+// scrollPosition describes where the viewport sits in the content: Top, Bot
+// or a percentage; false when everything fits on screen.
+func (m Model) scrollPosition() (string, bool) {
+	total := len(m.contentLines)
+	if m.vp.Height <= 0 || total <= m.vp.Height {
+		return "", false
+	}
+	switch {
+	case m.vp.YOffset <= 0:
+		return "Top", true
+	case m.vp.YOffset >= total-m.vp.Height:
+		return "Bot", true
+	}
+	return fmt.Sprintf("%d%%", m.vp.YOffset*100/(total-m.vp.Height)), true
+}
+
+// emptyNoteDelay keeps the viewport blank for a moment after startup, so
+// lines arriving right away don't flash the "No log entries..." note
+const emptyNoteDelay = 500 * time.Millisecond
+
+// renderViewport shows the log rows, or a centered note telling an empty
+// buffer apart from one whose lines are all hidden
+func (m Model) renderViewport() string {
+	if len(m.contentLines) > 0 || m.vp.Height <= 0 {
+		return m.vp.View()
+	}
+	note := "No log entries..."
+	if m.bufferedCount > 0 {
+		note = m.hidden.note()
+	} else if time.Since(m.loadStarted) < emptyNoteDelay {
+		note = ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Width(m.vp.Width).
+		Height(m.vp.Height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(note)
+}
+
 // renderEventsWithFullStyling renders events with comprehensive styling
 func (m Model) renderEventsWithFullStyling(events []core.LogEvent) string {
 	if len(events) == 0 {
@@ -385,50 +630,120 @@ func (m Model) renderEventsWithFullStyling(events []core.LogEvent) string {
 	var lines []string
 	lines = make([]string, 0, len(events))
 	for i := 0; i < len(events); i++ {
-		line := m.renderEventWithFullStyling(events[i])
+		if i > 0 {
+			if sep, ok := m.burstSeparator(events[i-1].Time, events[i].Time); ok {
+				lines = append(lines, sep)
+			}
+		}
+		blankTime := i > 0 && m.sameSecond(events[i-1].Time, events[i].Time)
+		line := m.renderEventStyled(events[i], blankTime)
 		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// burstSeparator returns the faint rule drawn between two consecutive visible
+// lines more than burstGap apart, labeled with the length of the gap.
+func (m Model) burstSeparator(prev, cur time.Time) (string, bool) {
+	if m.burstGap <= 0 || prev.IsZero() || cur.IsZero() || cur.Sub(prev) <= m.burstGap {
+		return "", false
+	}
+	label := "── " + cur.Sub(prev).Round(time.Second).String() + " "
+	width := max(m.vp.Width, lipgloss.Width(label))
+	return m.theme.TimestampStyle.Render(label + strings.Repeat("─", width-lipgloss.Width(label))), true
+}
+
+// timestampLayout is the default format of the timestamp column
+const timestampLayout = "15:04:05.000"
+
+// sameSecond reports whether compact timestamps elide cur's timestamp, i.e.
+// it falls in the same second as the previous visible line.
+func (m Model) sameSecond(prev, cur time.Time) bool {
+	return m.compactTime && !prev.IsZero() && !cur.IsZero() && prev.Truncate(time.Second).Equal(cur.Truncate(time.Second))
+}
+
 // renderEventWithFullStyling applies comprehensive styling to a log event
 func (m Model) renderEventWithFullStyling(event core.LogEvent) string {
+	return m.renderEventStyled(event, false)
+}
+
+// renderEventStyled styles an event; blankTime keeps the timestamp column
+// but leaves it empty so the line stays aligned.
+func (m Model) renderEventStyled(event core.LogEvent, blankTime bool) string {
 	var parts []string
 
 	// 1. Timestamp prefix (optional, configurable)
 	if m.showTimestamps && !event.Time.IsZero() {
-		timestamp := event.Time.Format("15:04:05.000")
+		timestamp := event.Time.In(m.location).Format(m.timeLayout)
+		if blankTime {
+			timestamp = strings.Repeat(" ", lipgloss.Width(timestamp))
+		}
 		parts = append(parts, m.theme.TimestampStyle.Render(timestamp))
 	}
 
-	// 2. Container name prefix (Docker mode only)
-	if m.mode == ModeDocker && event.Container != "" {
-		container := fmt.Sprintf("[%s]", event.Container)
-		parts = append(parts, m.theme.ContainerStyle.Render(container))
+	// 2. Container name prefix (Docker mode only), or the file name when
+	// tailing several files; n hides both
+	if !m.hideSource {
+		if m.mode == ModeDocker && event.Container != "" {
+			container := fmt.Sprintf("[%s]", m.containerLabel(event.Container))
+			parts = append(parts, m.theme.ContainerStyle.Render(container))
+		} else if event.Origin != "" {
+			parts = append(parts, m.theme.ContainerStyle.Render("["+event.Origin+"]"))
+		}
 	}
 
-	// 3. Severity badge
-	if event.LevelStr != "" {
+	// 3. Severity badge; bar mode draws the level in the gutter instead
+	if event.LevelStr != "" && m.severityDisplay == severityBadge {
 		badge := m.renderSeverityBadge(event.Level, event.LevelStr)
 		parts = append(parts, badge)
 	}
 
-	// 4. Main log line with highlighting
-	logLine := m.applyHighlighting(event.Line, event.Seq)
-	parts = append(parts, logLine)
-
-	// Join all parts with single space
-	fullLine := strings.Join(parts, " ")
+	// 4. Main log line with highlighting. Unwrapped, only the message scrolls
+	// horizontally; the prefix columns stay pinned at the left edge.
+	line := event.Line
+	if m.showControl {
+		line = caretNotation(line)
+	}
+	if m.expandedJSON[event.Seq] {
+		if pretty, ok := prettyJSON(line); ok {
+			line = pretty
+		}
+	}
+	logLine := m.renderMessage(line, event.Seq)
+	var prefix string
+	if len(parts) > 0 || m.prefixPad > 0 {
+		prefix = strings.Join(parts, m.prefixSep)
+		if pad := m.prefixPad - lipgloss.Width(prefix); pad > 0 {
+			prefix += strings.Repeat(" ", pad)
+		}
+		prefix += m.prefixSep
+	}
+	if !m.wrapLines && m.xOffset > 0 {
+		// One column past the viewport so layoutRows can tell the row is
+		// clipped; expanded JSON is cut row by row
+		end := m.xOffset + max(m.vp.Width-lipgloss.Width(prefix), 1) + 1
+		rows := strings.Split(logLine, "\n")
+		for i, row := range rows {
+			rows[i] = xansi.Cut(row, m.xOffset, end)
+		}
+		logLine = strings.Join(rows, "\n")
+	}
 
 	// 5. Do not truncate here; wrapping happens during content build.
-	return fullLine
+	return prefix + logLine
 }
 
 // renderSeverityBadge creates a styled severity level indicator
 func (m Model) renderSeverityBadge(level core.Severity, levelStr string) string {
-	var style lipgloss.Style
+	// Normalize badge width for alignment
+	badge := fmt.Sprintf("%-5s", strings.ToUpper(levelStr))
+	return m.severityStyle(level).Render(badge)
+}
 
+// severityStyle is the theme style for a severity's badge or bar
+func (m Model) severityStyle(level core.Severity) lipgloss.Style {
+	var style lipgloss.Style
 	switch level {
 	case core.SevDebug:
 		style = m.theme.DebugBadgeStyle
@@ -441,16 +756,26 @@ func (m Model) renderSeverityBadge(level core.Severity, levelStr string) string
 	default:
 		style = m.theme.OtherBadgeStyle
 	}
-
-	// Normalize badge width for alignment
-	badge := fmt.Sprintf("%-5s", strings.ToUpper(levelStr))
-	return style.Render(badge)
+	return style
 }
 
 // applyHighlighting applies highlight and find match styling to text
 func (m Model) applyHighlighting(line string, seq uint64) string {
+	return m.applySpanHighlighting(line, line, seq)
+}
+
+// applySpanHighlighting styles span, a part of line such as the text between
+// links. Field rules and negations are decided on the whole line.
+func (m Model) applySpanHighlighting(span, line string, seq uint64) string {
 	// Check if this line should be highlighted
-	shouldHighlight := m.filters.ShouldHighlight(line)
+	var shouldHighlight bool
+	if span == line {
+		shouldHighlight = m.filters.ShouldHighlight(line)
+	} else {
+		shouldHighlight = slices.ContainsFunc(m.filters.Highlights, func(h core.TextMatcher) bool {
+			return matchSpan(h, span, line)
+		})
+	}
 
 	// Check if this is the current find hit
 	isCurrentFindHit := m.search.IsActive() && m.search.Current() == seq
@@ -460,46 +785,70 @@ func (m Model) applyHighlighting(line string, seq uint64) string {
 	var isFindMatch bool
 	if m.search.IsActive() {
 		findMatcher = m.search.GetMatcher()
-		isFindMatch = findMatcher.Match(line)
+		isFindMatch = matchSpan(findMatcher, span, line)
 	}
 
 	// If no highlighting needed, return as-is
 	if !shouldHighlight && !isCurrentFindHit && !isFindMatch {
-		return line
+		return span
 	}
 
 	// Apply styling based on priority: find hit > find match > highlight
 	if isCurrentFindHit {
 		// Highlight the entire line for current find hit
-		return m.theme.FindHitStyle.Render(line)
+		return m.theme.FindHitStyle.Render(span)
 	} else if isFindMatch {
 		// Apply find match styling to matching portions
-		return m.applyInlineHighlight(line, findMatcher, m.theme.FindHitStyle)
+		return m.applySpanHighlight(span, line, findMatcher, m.theme.FindHitStyle)
 	} else if shouldHighlight {
 		// Apply highlight styling to matching portions
-		return m.applyAllHighlights(line)
+		return m.applyAllHighlights(span, line)
 	}
 
-	return line
+	return span
 }
 
-// applyAllHighlights applies all highlight patterns to a line
-func (m Model) applyAllHighlights(line string) string {
-	result := line
+// matchSpan reports whether matcher matches span, a part of line; field
+// rules and negations match the line as a whole
+func matchSpan(matcher core.TextMatcher, span, line string) bool {
+	if matcher.IsField() || matcher.Negated() {
+		return matcher.Match(line)
+	}
+	return matcher.Match(span)
+}
+
+// applyAllHighlights applies all highlight patterns to span, a part of line
+func (m Model) applyAllHighlights(span, line string) string {
+	result := span
 
 	// Apply each highlight pattern
 	for _, highlight := range m.filters.Highlights {
-		result = m.applyInlineHighlight(result, highlight, m.theme.HighlightStyle)
+		result = m.applySpanHighlight(result, line, highlight, m.theme.HighlightStyle)
 	}
 
 	return result
 }
 
+// applySpanHighlight is applyInlineHighlight for span, a part of line
+func (m Model) applySpanHighlight(span, line string, matcher core.TextMatcher, style lipgloss.Style) string {
+	if matcher.IsField() || matcher.Negated() {
+		if matcher.Match(line) {
+			return style.Render(span)
+		}
+		return span
+	}
+	return m.applyInlineHighlight(span, matcher, style)
+}
+
 // applyInlineHighlight applies styling to matching substrings within a line
 func (m Model) applyInlineHighlight(line string, matcher core.TextMatcher, style lipgloss.Style) string {
-	if matcher.IsRegex() {
-		// For regex patterns, we need to compile and find matches
-		// This is a simplified implementation - could be optimized
+	if matcher.IsField() || matcher.Negated() {
+		// Field rules and negations match the line as a whole
+		if matcher.Match(stripANSI(line)) {
+			return style.Render(line)
+		}
+		return line
+	} else if matcher.IsRegex() {
 		return m.applyRegexHighlight(line, matcher, style)
 	} else {
 		// For substring patterns, use simple case-insensitive replacement
@@ -509,14 +858,18 @@ func (m Model) applyInlineHighlight(line string, matcher core.TextMatcher, style
 
 // applySubstringHighlight highlights all occurrences of a substring
 func (m Model) applySubstringHighlight(line string, matcher core.TextMatcher, style lipgloss.Style) string {
-	pattern := strings.TrimSpace(matcher.Raw())
+	pattern := matcher.Needle()
 	if pattern == "" {
 		return line
 	}
 
-	// Case-insensitive find and replace
-	lowerLine := strings.ToLower(line)
-	lowerPattern := strings.ToLower(pattern)
+	// Case-insensitive find and replace, unless the matcher is case-sensitive
+	fold := strings.ToLower
+	if matcher.CaseSensitive() {
+		fold = func(s string) string { return s }
+	}
+	lowerLine := fold(line)
+	lowerPattern := fold(pattern)
 
 	if !strings.Contains(lowerLine, lowerPattern) {
 		return line
@@ -527,7 +880,7 @@ func (m Model) applySubstringHighlight(line string, matcher core.TextMatcher, st
 	startIdx := 0
 
 	for {
-		idx := strings.Index(strings.ToLower(result[startIdx:]), lowerPattern)
+		idx := strings.Index(fold(result[startIdx:]), lowerPattern)
 		if idx == -1 {
 			break
 		}
@@ -549,24 +902,48 @@ func (m Model) applySubstringHighlight(line string, matcher core.TextMatcher, st
 	return result
 }
 
-// applyRegexHighlight highlights regex matches (simplified implementation)
+// applyRegexHighlight highlights regex matches, or just their first capture
+// group when the pattern has one
 func (m Model) applyRegexHighlight(line string, matcher core.TextMatcher, style lipgloss.Style) string {
-	// Extract regex pattern from matcher
-	raw := matcher.Raw()
-	if len(raw) < 3 || !strings.HasPrefix(raw, "/") || !strings.HasSuffix(raw, "/") {
+	regex := matcher.Regexp()
+	if regex == nil {
 		return line
 	}
 
-	pattern := raw[1 : len(raw)-1]
-	regex, err := regexp.Compile("(?i)" + pattern)
-	if err != nil {
-		return line // If regex is invalid, return original line
+	// Style capture group 1 when the pattern has one, else the whole match.
+	// Adjacent spans are merged so each run is styled once.
+	group := 0
+	if regex.NumSubexp() > 0 {
+		group = 1
 	}
-
-	// Find all matches and replace with styled versions
-	return regex.ReplaceAllStringFunc(line, func(match string) string {
-		return style.Render(match)
-	})
+	var sb strings.Builder
+	last, runStart, runEnd := 0, -1, -1
+	flush := func() {
+		if runStart < 0 {
+			return
+		}
+		sb.WriteString(line[last:runStart])
+		sb.WriteString(style.Render(line[runStart:runEnd]))
+		last = runEnd
+		runStart = -1
+	}
+	for _, loc := range regex.FindAllStringSubmatchIndex(line, -1) {
+		start, end := loc[2*group], loc[2*group+1]
+		if start < 0 || start == end || start < max(last, runEnd) {
+			continue
+		}
+		if start != runEnd {
+			flush()
+			runStart = start
+		}
+		runEnd = end
+	}
+	flush()
+	if last == 0 {
+		return line
+	}
+	sb.WriteString(line[last:])
+	return sb.String()
 }
 
 // wrapStyledToWidth soft-wraps an ANSI-styled string to the given display width.
@@ -622,15 +999,22 @@ func (m Model) renderDockerContainerList() string {
 		return ""
 	}
 
-	// Get sorted list of containers
-	var containers []string
-	for name := range m.dockerUI.Containers {
-		containers = append(containers, name)
+	// Pinned containers first, then the rest alphabetically
+	containers := m.sortedContainers()
+	label := func(name string) string {
+		if m.dockerUI.Pinned[name] {
+			return m.containerLabel(name) + " (pinned)"
+		}
+		return m.containerLabel(name)
+	}
+
+	nameWidth := 0
+	for _, name := range containers {
+		nameWidth = max(nameWidth, lipgloss.Width(label(name)))
 	}
-	sort.Strings(containers)
 
 	var lines []string
-	lines = append(lines, "Container List (Space: toggle, a: toggle all, Enter/Esc: close)")
+	lines = append(lines, "Container List (Space: toggle, a: toggle all, p: pin, s: rates, Enter/Esc: close)")
 	lines = append(lines, "")
 
 	// All toggle option
@@ -651,20 +1035,27 @@ func (m Model) renderDockerContainerList() string {
 			status = "[x]"
 		}
 
-		line := fmt.Sprintf("  %s %s", status, container)
+		line := fmt.Sprintf("  %s %s", status, label(container))
+		if m.showSparklines {
+			line = fmt.Sprintf("  %s %-*s %s", status, nameWidth, label(container), m.sparklines[container])
+		}
 		if m.dockerUI.SelectedContainer == i {
 			line = "> " + line[2:] // Highlight selection
 		}
 		lines = append(lines, line)
 	}
 
-	// Create bordered overlay
+	// Create bordered overlay; widen it to fit sparklines
+	listWidth := 60
+	if m.showSparklines {
+		listWidth = max(listWidth, nameWidth+sparkWindow+20)
+	}
 	content := strings.Join(lines, "\n")
 	overlay := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("36")).
 		Padding(1).
-		Width(min(60, m.width-4)).
+		Width(min(listWidth, m.width-4)).
 		Render(content)
 
 	return overlay
@@ -682,21 +1073,29 @@ func (m Model) renderDockerPresetManager() string {
 
 	if len(m.dockerUI.Presets) == 0 {
 		lines = append(lines, "No presets found.")
-		lines = append(lines, "Press 's' to save current container visibility as a preset.")
+		if m.mode == ModeDocker {
+			lines = append(lines, "Press 's' to save current container visibility as a preset.")
+		} else {
+			lines = append(lines, "Press 's' to save the current filters and highlights as a preset.")
+		}
 	} else {
 		// List presets
 		for i, preset := range m.dockerUI.Presets {
 			line := fmt.Sprintf("  %s", preset.Name)
 
-			// Show container count
-			visibleCount := 0
-			totalCount := len(preset.Visible)
-			for _, visible := range preset.Visible {
-				if visible {
-					visibleCount++
+			if preset.IsFilterPreset() {
+				line += fmt.Sprintf(" (%d in, %d out, %d highlights)", len(preset.Includes), len(preset.Excludes), len(preset.Highlights))
+			} else {
+				// Show container count
+				visibleCount := 0
+				totalCount := len(preset.Visible)
+				for _, visible := range preset.Visible {
+					if visible {
+						visibleCount++
+					}
 				}
+				line += fmt.Sprintf(" (%d/%d visible)", visibleCount, totalCount)
 			}
-			line += fmt.Sprintf(" (%d/%d visible)", visibleCount, totalCount)
 
 			// Highlight selected preset
 			if i == m.dockerUI.SelectedPreset {
@@ -1,11 +1,17 @@
 package tui
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
 	"github.com/germanoeich/siftail/internal/core"
+	"github.com/germanoeich/siftail/internal/persist"
 )
 
 // Ensure the help overlay renders when opened and can be closed by key.
@@ -71,3 +77,658 @@ func TestSoftWrap_LongLine(t *testing.T) {
 		t.Fatalf("unexpected ellipsis found in wrapped output: %q", joined)
 	}
 }
+
+func TestSoftWrap_RowCountAndJump(t *testing.T) {
+	ring := core.NewRing(100)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 20, Height: 6})
+	m = nm.(Model)
+	m.showTimestamps = false
+
+	for i := 0; i < 10; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("short %d", i)})
+	}
+	ascii := ring.Append(core.LogEvent{Line: strings.Repeat("a", 50)})
+	wide := ring.Append(core.LogEvent{Line: strings.Repeat("日", 15)})
+	target := ring.Append(core.LogEvent{Line: "target"})
+	m = m.updateViewportContent()
+
+	if m.vp.Width != 20 {
+		t.Fatalf("viewport width %d, want 20", m.vp.Width)
+	}
+	if got := len(m.contentLines); got != 10+3+2+1 {
+		t.Errorf("got %d rows, want 50 columns in 3 rows and 15 wide runes in 2", got)
+	}
+	if m.seqIndex[wide.Seq] != m.seqIndex[ascii.Seq]+3 || m.seqIndex[target.Seq] != m.seqIndex[wide.Seq]+2 {
+		t.Errorf("seqIndex does not follow wrapped rows: %v", m.seqIndex)
+	}
+	for _, row := range m.contentPlainLines {
+		if w := xansi.StringWidth(row); w > 20 {
+			t.Errorf("row %q is %d columns wide", row, w)
+		}
+	}
+
+	// Jumping rebuilds a stale mapping the same way
+	want := m.seqIndex[target.Seq]
+	m.seqIndex = nil
+	m = m.scrollToSequence(target.Seq)
+	if got := m.seqIndex[target.Seq]; got != want {
+		t.Errorf("rebuilt mapping puts target at row %d, want %d", got, want)
+	}
+	if off := m.vp.YOffset; want < off || want >= off+m.vp.Height {
+		t.Errorf("target row %d outside viewport at offset %d", want, off)
+	}
+}
+
+func TestContainerAlias_RenderedInPrefixAndList(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	m.SetContainerAliases(map[string]string{"shop_payments_1": "payments"})
+	m.dockerUI.Containers["shop_payments_1"] = true
+
+	line := xansi.Strip(m.renderEventWithFullStyling(core.LogEvent{Container: "shop_payments_1", Line: "charged"}))
+	if !strings.Contains(line, "[payments]") || strings.Contains(line, "shop_payments_1") {
+		t.Errorf("expected aliased prefix, got %q", line)
+	}
+
+	m.dockerUI.ContainerListOpen = true
+	if list := m.renderDockerContainerList(); !strings.Contains(list, "payments") || strings.Contains(list, "shop_payments_1") {
+		t.Errorf("expected alias in container list, got %q", list)
+	}
+
+	// Visibility still keys off the real name
+	m.dockerUI.Containers["shop_payments_1"] = false
+	m.ring.Append(core.LogEvent{Source: core.SourceDocker, Container: "shop_payments_1", Line: "hidden"})
+	m = m.updateViewportContent()
+	if strings.Contains(strings.Join(m.contentPlainLines, "\n"), "hidden") {
+		t.Error("expected hiding the real container name to hide aliased lines")
+	}
+}
+
+func TestFileOrigin_RenderedAsPrefix(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m.showTimestamps = false
+
+	if got := xansi.Strip(m.renderEventWithFullStyling(core.LogEvent{Origin: "b.log", Line: "started"})); got != "[b.log] started" {
+		t.Errorf("expected origin prefix, got %q", got)
+	}
+	if got := xansi.Strip(m.renderEventWithFullStyling(core.LogEvent{Line: "started"})); got != "started" {
+		t.Errorf("expected no prefix for a single file, got %q", got)
+	}
+}
+
+func TestHideSource_TogglesContainerColumn(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	m = nm.(Model)
+	m.showTimestamps = false
+	m.dockerUI.Containers["api"] = true
+	ring.Append(core.LogEvent{Source: core.SourceDocker, Container: "api", Line: "hello world"})
+	m = m.updateViewportContent()
+	if got := m.contentPlainLines[0]; got != "[api] hello world" {
+		t.Fatalf("expected container prefix, got %q", got)
+	}
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = nm.(Model).updateViewportContent()
+	if got := m.contentPlainLines[0]; got != "hello world" {
+		t.Errorf("expected no prefix with names hidden, got %q", got)
+	}
+	if !strings.Contains(m.renderStatusLine(), "NoNames") {
+		t.Errorf("status line should show the names are hidden: %q", m.renderStatusLine())
+	}
+
+	// Selection columns follow the rendered line
+	m.selStartX, m.selEndX, m.selStartY, m.selEndY = 0, 5, 0, 0
+	if got := m.extractSelectedText(); got != "hello" {
+		t.Errorf("selection with names hidden = %q, want %q", got, "hello")
+	}
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = nm.(Model).updateViewportContent()
+	if got := m.contentPlainLines[0]; got != "[api] hello world" {
+		t.Errorf("expected the prefix back, got %q", got)
+	}
+}
+
+func TestHorizontalScroll_PrefixStaysPinned(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = nm.(Model)
+	m.dockerUI.Containers["api"] = true
+	m.ring.Append(core.LogEvent{Source: core.SourceDocker, Container: "api", LevelStr: "INFO", Level: core.SevInfo,
+		Line: "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"})
+
+	press := func(s string) {
+		key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+		if s == "right" {
+			key = tea.KeyMsg{Type: tea.KeyRight}
+		}
+		nm, _ := m.Update(key)
+		m = nm.(Model)
+	}
+
+	press("w")
+	press("right")
+	m = m.updateViewportContent()
+	if len(m.contentPlainLines) != 1 {
+		t.Fatalf("expected one unwrapped row, got %d", len(m.contentPlainLines))
+	}
+	row := m.contentPlainLines[0]
+	prefix := "[api] INFO"
+	if !strings.HasPrefix(row, prefix) {
+		t.Fatalf("expected prefix %q to stay pinned, got %q", prefix, row)
+	}
+	if msg := strings.TrimLeft(strings.TrimPrefix(row, prefix), " "); !strings.HasPrefix(msg, "89abcdef") {
+		t.Errorf("expected message scrolled by %d columns, got %q", hScrollStep, msg)
+	}
+	if w := xansi.StringWidth(row); w > 40 {
+		t.Errorf("expected row clipped to viewport width, got %d columns", w)
+	}
+
+	// Turning wrap back on resets the offset and wraps the full line
+	press("w")
+	m = m.updateViewportContent()
+	if len(m.contentPlainLines) < 2 || !strings.Contains(m.contentPlainLines[0], "01234567") {
+		t.Errorf("expected wrapped full line, got %q", m.contentPlainLines)
+	}
+}
+
+func TestNoWrap_ClipMarkerOnlyOnClippedRows(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 20, Height: 10})
+	m = nm.(Model)
+	m.wrapLines = false
+	ring.Append(core.LogEvent{Line: "fits"})
+	ring.Append(core.LogEvent{Line: "exactly twenty cols!"})
+	ring.Append(core.LogEvent{Line: "this line is much wider than the viewport"})
+	m = m.updateViewportContent()
+
+	var rows []string
+	for _, row := range m.contentLines {
+		rows = append(rows, stripANSI(row))
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %q", rows)
+	}
+	for i, clipped := range []bool{false, false, true} {
+		if got := strings.HasSuffix(rows[i], clipMarker); got != clipped {
+			t.Errorf("row %d %q: marker %v, want %v", i, rows[i], got, clipped)
+		}
+		if w := xansi.StringWidth(rows[i]); w > 20 {
+			t.Errorf("row %d is %d columns wide", i, w)
+		}
+	}
+
+	// Copies get the text under the marker, not the marker
+	if got := m.contentPlainLines[2]; got != "this line is much wi" {
+		t.Errorf("plain row %q, want the clipped text without the marker", got)
+	}
+	m.selStartX, m.selStartY, m.selEndX, m.selEndY = 0, 2, 20, 2
+	if got := m.extractSelectedText(); got != "this line is much wi" {
+		t.Errorf("copied %q", got)
+	}
+
+	// Scrolled to the end of the long line, nothing is hidden to the right
+	m.xOffset = 30
+	m = m.updateViewportContent()
+	if strings.HasSuffix(stripANSI(m.contentLines[2]), clipMarker) {
+		t.Errorf("scrolled to the end, row still marked: %q", m.contentLines[2])
+	}
+	m.xOffset = 8
+	m = m.updateViewportContent()
+	if !strings.HasSuffix(stripANSI(m.contentLines[2]), clipMarker) {
+		t.Errorf("scrolled mid-line, row not marked: %q", m.contentLines[2])
+	}
+}
+
+func TestPrefixSeparator_Custom(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	if err := m.settingsStore.Save(persist.Settings{PrefixSeparator: " | ", PrefixWidth: 16}); err != nil {
+		t.Fatal(err)
+	}
+	m = *NewModel(m.ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = nm.(Model)
+	m.dockerUI.Containers["api"], m.dockerUI.Containers["db"] = true, true
+	m.ring.Append(core.LogEvent{Source: core.SourceDocker, Container: "api", LevelStr: "INFO", Level: core.SevInfo, Line: "started"})
+	m.ring.Append(core.LogEvent{Source: core.SourceDocker, Container: "db", Line: "0123456789abcdefghijklmnopqrstuvwxyz"})
+	m = m.updateViewportContent()
+
+	want := []string{"[api] | INFO     | started", "[db]             | 0123456789abcdefghijk", "lmnopqrstuvwxyz"}
+	if !reflect.DeepEqual(m.contentPlainLines, want) {
+		t.Fatalf("rows\n%q\nwant\n%q", m.contentPlainLines, want)
+	}
+
+	// Copy takes the message columns past the wider prefix
+	m.selStartX, m.selStartY, m.selEndX, m.selEndY = 19, 0, 26, 0
+	if got := m.extractSelectedText(); got != "started" {
+		t.Errorf("selected %q, want started", got)
+	}
+
+	// Scrolling keeps the padded prefix pinned and the row within the viewport
+	m.wrapLines = false
+	m.xOffset = 10
+	m = m.updateViewportContent()
+	if row := stripANSI(m.contentLines[1]); row != "[db]             | abcdefghijklmnopqrst»" {
+		t.Errorf("scrolled row %q", row)
+	}
+}
+
+func TestPeek_ClickOnClippedRowShowsFullLine(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 30, Height: 10})
+	m = nm.(Model)
+	m.wrapLines = false
+	long := "request failed: upstream timed out after 30s (id=abc-123)"
+	ring.Append(core.LogEvent{Line: "short"})
+	ring.Append(core.LogEvent{Line: long})
+	m.followTail = false
+	m = m.updateViewportContent()
+
+	if _, ok := m.clippedLineAt(0); ok {
+		t.Error("expected a line that fits not to be peekable")
+	}
+	if line, ok := m.clippedLineAt(1); !ok || line != long {
+		t.Errorf("expected row 1 to map to the full line, got %q (ok=%v)", line, ok)
+	}
+
+	click := func(y int, action tea.MouseAction) {
+		nm, _ := m.Update(tea.MouseMsg{X: 3, Y: y, Button: tea.MouseButtonLeft, Action: action})
+		m = nm.(Model)
+	}
+	click(2, tea.MouseActionPress) // viewport starts at screen row 1
+	click(2, tea.MouseActionRelease)
+	if !m.peekOpen || !strings.Contains(m.View(), "(id=abc-123)") {
+		t.Fatalf("expected peek popup with the full line, got open=%v", m.peekOpen)
+	}
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = nm.(Model)
+	if m.peekOpen {
+		t.Error("expected any key to close the peek popup")
+	}
+}
+
+func TestCaretNotation_ShowsControlBytes(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 10})
+	m = nm.(Model)
+	ring.Append(core.LogEvent{Line: "start\x01mid\tend\x7f\xff"})
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m = nm.(Model).updateViewportContent()
+	if got, want := m.contentPlainLines[0], `start^Amid^Iend^?\xFF`; got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+	if got := ring.Snapshot()[0].Line; got != "start\x01mid\tend\x7f\xff" {
+		t.Errorf("stored line changed to %q", got)
+	}
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m = nm.(Model).updateViewportContent()
+	if strings.Contains(m.contentPlainLines[0], "^A") {
+		t.Errorf("expected raw rendering after toggling off, got %q", m.contentPlainLines[0])
+	}
+}
+
+func TestCompactTimestamps_OnlyOnSecondChange(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	m = nm.(Model)
+	m.showTimestamps, m.compactTime = true, true
+	m.SetLocation(time.UTC)
+
+	base := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	var events []core.LogEvent
+	for i, offset := range []time.Duration{0, 100 * time.Millisecond, 900 * time.Millisecond, 1200 * time.Millisecond} {
+		events = append(events, ring.Append(core.LogEvent{Time: base.Add(offset), Line: fmt.Sprintf("line %d", i)}))
+	}
+
+	want := []string{
+		"15:04:05.000 line 0",
+		"             line 1",
+		"             line 2",
+		"15:04:06.200 line 3",
+	}
+	if got := strings.Split(stripANSI(m.renderEventsWithFullStyling(events)), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("rendered\n%q\nwant\n%q", got, want)
+	}
+
+	// The viewport uses the previous visible line, so hiding the first
+	// event moves the timestamp to the next one
+	m = m.updateViewportContent()
+	if !reflect.DeepEqual(m.contentPlainLines, want) {
+		t.Errorf("viewport rows\n%q\nwant\n%q", m.contentPlainLines, want)
+	}
+	matcher, _ := core.NewMatcher("/line [123]/")
+	m.filters.AddInclude(matcher)
+	m = m.updateViewportContent()
+	if got := m.contentPlainLines[0]; got != "15:04:05.100 line 1" {
+		t.Errorf("first visible row = %q, want its timestamp shown", got)
+	}
+}
+
+func TestFiltersOverlay_ShowsHitCounts(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = nm.(Model)
+	for _, line := range []string{"error a", "error b", "info c"} {
+		ring.Append(core.LogEvent{Line: line})
+	}
+	errMatcher, _ := core.NewMatcher("error")
+	m.filters.AddInclude(errMatcher)
+	nope, _ := core.NewMatcher("nope")
+	m.filters.AddExclude(nope)
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = nm.(Model)
+	view := m.View()
+	for _, want := range []string{`include[0] "error": 2`, `exclude[0] "nope": 0`} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in filters overlay:\n%s", want, view)
+		}
+	}
+
+	nm, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if nm.(Model).filtersOpen {
+		t.Error("expected Esc to close the filters overlay")
+	}
+}
+
+func TestTimestamps_ShownInLocation(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeDocker)
+	m.showTimestamps = true
+	e := core.LogEvent{Time: time.Date(2025, 6, 1, 22, 30, 0, 0, time.UTC), Line: "deployed"}
+
+	m.SetLocation(time.UTC)
+	if got := stripANSI(m.renderEventWithFullStyling(e)); got != "22:30:00.000 deployed" {
+		t.Errorf("UTC: got %q", got)
+	}
+
+	before := m.styleKey()
+	m.SetLocation(time.FixedZone("UTC+5:30", 5*3600+30*60))
+	if got := stripANSI(m.renderEventWithFullStyling(e)); got != "04:00:00.000 deployed" {
+		t.Errorf("UTC+5:30: got %q", got)
+	}
+	if m.styleKey() == before {
+		t.Error("expected the render cache key to change with the time zone")
+	}
+}
+
+func TestTimestamps_ToggledWithD(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	m.SetLocation(time.UTC)
+	ring.Append(core.LogEvent{Time: time.Date(2024, 5, 1, 12, 34, 56, 0, time.UTC), Line: "deployed"})
+
+	if got := stripANSI(m.renderEventWithFullStyling(ring.Snapshot()[0])); !strings.Contains(got, "12:34:56.000") {
+		t.Fatalf("expected a timestamp by default, got %q", got)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = updated.(Model)
+	if m.showTimestamps || !m.dirty {
+		t.Fatal("expected d to turn timestamps off and mark the view dirty")
+	}
+	if got := stripANSI(m.renderEventWithFullStyling(ring.Snapshot()[0])); got != "deployed" {
+		t.Errorf("expected no timestamp, got %q", got)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !updated.(Model).showTimestamps {
+		t.Error("expected d to turn timestamps back on")
+	}
+}
+
+func TestBurstGap_SeparatesDistantLines(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = nm.(Model)
+	m.showTimestamps = false
+
+	base := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	events := []core.LogEvent{
+		ring.Append(core.LogEvent{Time: base, Line: "first"}),
+		ring.Append(core.LogEvent{Time: base.Add(time.Second), Line: "second"}),
+		ring.Append(core.LogEvent{Time: base.Add(2 * time.Minute), Line: "third"}),
+	}
+
+	if got := stripANSI(m.renderEventsWithFullStyling(events)); got != "first\nsecond\nthird" {
+		t.Errorf("expected no separator by default, got %q", got)
+	}
+
+	m.SetBurstGap(5 * time.Second)
+	got := strings.Split(stripANSI(m.renderEventsWithFullStyling(events)), "\n")
+	if len(got) != 4 || got[1] != "second" || !strings.HasPrefix(got[2], "── 1m59s ──") || got[3] != "third" {
+		t.Errorf("expected one separator before the distant line, got %q", got)
+	}
+
+	m = m.updateViewportContent()
+	if !reflect.DeepEqual(m.contentPlainLines, got) {
+		t.Errorf("viewport rows\n%q\nwant\n%q", m.contentPlainLines, got)
+	}
+	if row := m.seqIndex[events[2].Seq]; row != 3 {
+		t.Errorf("third line starts at row %d, want 3", row)
+	}
+	m.seqIndex = nil
+	if row := m.scrollToSequence(events[2].Seq).seqIndex[events[2].Seq]; row != 3 {
+		t.Errorf("rebuilt mapping puts the third line at row %d, want 3", row)
+	}
+}
+
+func TestRegexHighlight_CaptureGroupOnly(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	mark := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	line := "login user=alice ok, user=bob failed"
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{`/user=\w+/`, "login [user=alice] ok, [user=bob] failed"},
+		{`/user=(\w+)/`, "login user=[alice] ok, user=[bob] failed"},
+		{`/(?:user=)\w+/`, "login [user=alice] ok, [user=bob] failed"},
+		{`/(\w)/`, "[login] [user]=[alice] [ok], [user]=[bob] [failed]"},
+		{`/nomatch-(\w+)/`, line},
+	}
+	for _, tt := range tests {
+		matcher, err := core.NewMatcher(tt.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.applyRegexHighlight(line, matcher, mark); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestInlineHighlight_CaseFlags(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	mark := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	line := "error: ERROR in handler"
+
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"cs:ERROR", "error: [ERROR] in handler"},
+		{"/ERR(OR)/c", "error: ERR[OR] in handler"},
+		{"+cs:ERROR", "error: [ERROR] in handler"},
+		{"error", "[error]: [ERROR] in handler"},
+	}
+	for _, tt := range tests {
+		matcher, err := core.NewIncludeMatcher(tt.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := m.applyInlineHighlight(line, matcher, mark); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestInlineHighlight_EscapedBang(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	mark := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	matcher, err := core.NewMatcher(`\!boom`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.applyInlineHighlight("it went !boom", matcher, mark), "it went [!boom]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFieldHighlight_MarksWholeLine(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	mark := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	matcher, err := core.NewMatcher("field:status>=500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for line, want := range map[string]string{
+		`{"status":502}`: `[{"status":502}]`,
+		`{"status":200}`: `{"status":200}`,
+	} {
+		if got := m.applyInlineHighlight(line, matcher, mark); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestSeverityBar_ReplacesBadge(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 20, Height: 10})
+	m = nm.(Model)
+	m.showTimestamps = false
+	m.wrapLines = true
+	ring.Append(core.LogEvent{Line: "boom went the disk, twice over", Level: core.SevError, LevelStr: "ERROR"})
+	ring.Append(core.LogEvent{Line: "plain"})
+
+	m.severityDisplay = severityBar
+	m = m.updateViewportContent()
+	if len(m.contentPlainLines) < 3 {
+		t.Fatalf("expected the first event to wrap, got %q", m.contentPlainLines)
+	}
+	last := len(m.contentPlainLines) - 1
+	for i, row := range m.contentPlainLines[:last] {
+		if !strings.HasPrefix(row, severityBarGlyph) || strings.Contains(row, "ERROR") {
+			t.Errorf("row %d = %q, want the bar and no badge", i, row)
+		}
+		if w := ansiStringWidth(row); w > m.vp.Width {
+			t.Errorf("row %d is %d columns wide, viewport is %d", i, w, m.vp.Width)
+		}
+	}
+	if got := m.contentPlainLines[last]; got != " plain" {
+		t.Errorf("event without a level = %q, want a blank bar column", got)
+	}
+
+	m.selStartX, m.selEndX, m.selStartY, m.selEndY = 0, 5, 0, 0
+	if got := m.extractSelectedText(); got != "boom" {
+		t.Errorf("selection = %q, want the bar column skipped", got)
+	}
+
+	m.severityDisplay = severityNone
+	m = m.updateViewportContent()
+	if got := m.contentPlainLines[0]; !strings.HasPrefix(got, "boom") {
+		t.Errorf("none mode row = %q, want neither bar nor badge", got)
+	}
+}
+
+func TestStatusLine_ScrollPosition(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 13})
+	m = nm.(Model)
+	for i := 0; i < 3; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+	m = m.updateViewportContent()
+	if strings.Contains(m.renderStatusLine(), "Pos:") {
+		t.Errorf("content fits, expected no position: %q", m.renderStatusLine())
+	}
+
+	for i := 3; i < 110; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+	m = m.updateViewportContent()
+	span := len(m.contentLines) - m.vp.Height
+	for _, tt := range []struct {
+		offset int
+		want   string
+	}{
+		{span, "Pos: Bot"},
+		{0, "Pos: Top"},
+		{span / 2, "Pos: 50%"},
+	} {
+		m.vp.SetYOffset(tt.offset)
+		if got := m.renderStatusLine(); !strings.Contains(got, tt.want) {
+			t.Errorf("offset %d: status %q, want %q", tt.offset, got, tt.want)
+		}
+	}
+
+	// More content below the same offset moves the mark up
+	m.followTail = false
+	for i := 110; i < 210; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line %d", i)})
+	}
+	m = m.updateViewportContent()
+	if got := m.renderStatusLine(); !strings.Contains(got, "Pos: 2") {
+		t.Errorf("after growth: status %q, want about 25%%", got)
+	}
+}
+
+func TestStatusLine_Overload(t *testing.T) {
+	m := *NewModel(core.NewRing(10), core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 13})
+	m = nm.(Model)
+	for _, tt := range []struct {
+		msg  OverloadMsg
+		want string
+	}{
+		{OverloadMsg{Dropped: 1200}, "Dropping 1200 lines/s due to load"},
+		{OverloadMsg{Paused: true}, "Input paused: UI behind"},
+		{OverloadMsg{}, ""},
+	} {
+		nm, _ := m.Update(tt.msg)
+		m = nm.(Model)
+		got := m.renderStatusLine()
+		if tt.want == "" && (strings.Contains(got, "Dropping") || strings.Contains(got, "paused")) {
+			t.Errorf("%+v: indicator not cleared: %q", tt.msg, got)
+		} else if !strings.Contains(got, tt.want) {
+			t.Errorf("%+v: status %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestChronological_MergesFilesByTimestamp(t *testing.T) {
+	ring := core.NewRing(10)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	m = nm.(Model)
+	m.showTimestamps = false
+	m.hideSource = true
+	ring.Append(core.LogEvent{Origin: "a.log", Line: "2024-05-01T12:00:03Z a late"})
+	ring.Append(core.LogEvent{Origin: "b.log", Line: "2024-05-01T12:00:01Z b early"})
+	ring.Append(core.LogEvent{Origin: "b.log", Line: "  continued"})
+
+	m.SetChronological(true)
+	m = m.updateViewportContent()
+	want := []string{"2024-05-01T12:00:01Z b early", "  continued", "2024-05-01T12:00:03Z a late"}
+	if !reflect.DeepEqual(m.contentPlainLines, want) {
+		t.Errorf("rows = %q, want %q", m.contentPlainLines, want)
+	}
+	if m.seqIndex[1] != 2 {
+		t.Errorf("seqIndex[1] = %d, want row 2", m.seqIndex[1])
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+// toggleCut hides the events after (trailing) or before (leading) the
+// centered event, or removes that cut when it is already set. The two cuts
+// together bound a window; lines arriving past a trailing cut stay hidden.
+func (m Model) toggleCut(trailing bool) Model {
+	cut := &m.sinceSeq
+	if trailing {
+		cut = &m.untilSeq
+	}
+	if *cut != 0 {
+		*cut = 0
+		m.dirty = true
+		return m.setError("Cut removed")
+	}
+
+	e, ok := m.centeredEvent()
+	if !ok {
+		return m.setError("No event to cut at")
+	}
+	*cut = e.Seq
+	m.dirty = true
+	m = m.updateViewportContent()
+	m = m.scrollToSequence(e.Seq)
+	if trailing {
+		return m.setError(fmt.Sprintf("Hiding lines after #%d", e.Seq))
+	}
+	return m.setError(fmt.Sprintf("Hiding lines before #%d", e.Seq))
+}
+
+// windowText describes the sequence window for the status line
+func (m Model) windowText() string {
+	since, until := "start", "end"
+	if m.sinceSeq != 0 {
+		since = fmt.Sprintf("#%d", m.sinceSeq)
+	}
+	if m.untilSeq != 0 {
+		until = fmt.Sprintf("#%d", m.untilSeq)
+	}
+	return since + "–" + until
+}
+
+// togglePeek shows every line regardless of the include/exclude filters, or
+// applies them again. The filters themselves are left untouched.
+func (m Model) togglePeek() Model {
+	e, ok := m.centeredEvent()
+	m.peeking = !m.peeking
+	m.dirty = true
+	m = m.updateViewportContent()
+	if ok && !m.followTail {
+		m = m.scrollToSequence(e.Seq)
+	}
+	if m.peeking {
+		return m.setError("Peek: filters bypassed")
+	}
+	return m.setError("Peek off: filters applied")
+}
+
+// textFilters returns the include/exclude filters in effect, none while
+// peeking
+func (m Model) textFilters() *core.Filters {
+	if m.peeking {
+		return nil
+	}
+	return m.filters
+}
+
+// togglePause freezes the view: new lines still go into the ring but are not
+// shown or scrolled to until the pause ends, when a followed view catches up
+// with the tail.
+func (m Model) togglePause() Model {
+	m.paused = !m.paused
+	if m.paused {
+		m.pauseEnd = m.ring.CurrentSeq() + 1
+		return m.setError("Paused: new lines are buffered but not shown")
+	}
+	m.pauseEnd = 0
+	m.dirty = true
+	m = m.updateViewportContent()
+	return m.setError("Resumed")
+}
@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/germanoeich/siftail/internal/core"
+)
+
+func TestCut_TrailingAndLeadingWindow(t *testing.T) {
+	ring := core.NewRing(100)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeFile)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 1; i <= 50; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line-%02d", i)})
+	}
+	m = m.updateViewportContent()
+	m.followTail = false
+	m = m.scrollToSequence(20)
+
+	press := func(key string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+	shown := func() string {
+		return strings.Join(m.contentPlainLines, "\n")
+	}
+
+	center, _ := m.centeredEvent()
+	press("}")
+	if m.untilSeq != center.Seq || !strings.Contains(m.errMsg, fmt.Sprintf("after #%d", center.Seq)) {
+		t.Fatalf("trailing cut at %d, want %d (%q)", m.untilSeq, center.Seq, m.errMsg)
+	}
+	ring.Append(core.LogEvent{Line: "line-51"})
+	m = m.updateViewportContent()
+	if strings.Contains(shown(), "line-51") || strings.Contains(shown(), "line-50") || !strings.Contains(shown(), center.Line) {
+		t.Errorf("expected lines after %q hidden:\n%s", center.Line, shown())
+	}
+
+	m = m.scrollToSequence(10)
+	press("{")
+	if m.sinceSeq == 0 || strings.Contains(shown(), "line-01") {
+		t.Errorf("expected a leading cut hiding the first lines:\n%s", shown())
+	}
+	if want := fmt.Sprintf("Window: #%d–#%d", m.sinceSeq, m.untilSeq); !strings.Contains(m.renderStatusLine(), want) {
+		t.Errorf("status line missing %q: %q", want, m.renderStatusLine())
+	}
+
+	press("}")
+	m = m.updateViewportContent()
+	if m.untilSeq != 0 || !strings.Contains(shown(), "line-51") {
+		t.Error("expected } to remove the trailing cut")
+	}
+}
+
+func TestPeek_BypassesFiltersUntilToggledOff(t *testing.T) {
+	ring := core.NewRing(100)
+	filters := core.NewFilters()
+	m := *NewModel(ring, filters, core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	m = nm.(Model)
+	for _, line := range []string{"keep one", "drop two", "keep three"} {
+		ring.Append(core.LogEvent{Line: line})
+	}
+	include, _ := core.NewIncludeMatcher("keep")
+	filters.AddInclude(include)
+	m = m.updateViewportContent()
+
+	shown := func() string { return strings.Join(m.contentPlainLines, "\n") }
+	press := func() {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+		m = updated.(Model)
+	}
+	if strings.Contains(shown(), "drop two") {
+		t.Fatalf("filtered line shown before peek:\n%s", shown())
+	}
+
+	press()
+	if !strings.Contains(shown(), "drop two") || !strings.Contains(m.renderStatusLine(), "PEEK") {
+		t.Errorf("peek should show every line and PEEK in the status line:\n%s\n%s", shown(), m.renderStatusLine())
+	}
+	if len(filters.Include) != 1 {
+		t.Errorf("peek changed the filters: %d includes", len(filters.Include))
+	}
+
+	press()
+	if strings.Contains(shown(), "drop two") || !strings.Contains(shown(), "keep three") || strings.Contains(m.renderStatusLine(), "PEEK") {
+		t.Errorf("toggling peek off should re-hide filtered lines:\n%s", shown())
+	}
+}
+
+func TestPause_FreezesViewWhileBuffering(t *testing.T) {
+	ring := core.NewRing(1000)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	for i := 1; i <= 50; i++ {
+		ring.Append(core.LogEvent{Line: fmt.Sprintf("line-%03d", i)})
+	}
+	m = m.updateViewportContent()
+	if !m.followTail {
+		t.Fatal("expected to follow the tail before pausing")
+	}
+
+	press := func() {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+		m = updated.(Model)
+	}
+	press()
+	if !m.paused || !strings.Contains(m.renderStatusLine(), "PAUSED") {
+		t.Fatalf("space should pause: %q", m.renderStatusLine())
+	}
+	offset := m.vp.YOffset
+	for i := 51; i <= 80; i++ {
+		ev := ring.Append(core.LogEvent{Line: fmt.Sprintf("line-%03d", i)})
+		nm, _ = m.Update(LogAppendedMsg{Event: ev})
+		m = nm.(Model)
+	}
+	m.lastRender = time.Time{}
+	nm, _ = m.Update(tickMsg(time.Now()))
+	m = nm.(Model)
+	m = m.updateViewportContent()
+	if m.vp.YOffset != offset || strings.Contains(strings.Join(m.contentPlainLines, "\n"), "line-051") {
+		t.Errorf("paused view moved: offset %d -> %d", offset, m.vp.YOffset)
+	}
+	if ring.Size() != 80 {
+		t.Errorf("ring has %d events, want 80 buffered while paused", ring.Size())
+	}
+
+	press()
+	if m.paused || !strings.Contains(m.vp.View(), "line-080") {
+		t.Errorf("resuming should catch up with the tail:\n%s", m.vp.View())
+	}
+}
+
+func TestPause_FiltersApplyToFrozenLines(t *testing.T) {
+	ring := core.NewRing(100)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	ring.Append(core.LogEvent{Line: "keep me"})
+	ring.Append(core.LogEvent{Line: "drop me"})
+	m = m.updateViewportContent()
+
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	press(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	ev := ring.Append(core.LogEvent{Line: "late arrival"})
+	nm, _ = m.Update(LogAppendedMsg{Event: ev})
+	m = nm.(Model)
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	m.input.SetValue("drop")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	m.lastRender = time.Time{}
+	nm, _ = m.Update(tickMsg(time.Now()))
+	m = nm.(Model)
+
+	view := strings.Join(m.contentPlainLines, "\n")
+	if !m.paused || !strings.Contains(view, "keep me") || strings.Contains(view, "drop me") {
+		t.Errorf("the filter did not apply while paused:\n%s", view)
+	}
+	if strings.Contains(view, "late arrival") {
+		t.Errorf("a line arriving while paused was shown:\n%s", view)
+	}
+}
+
+func TestPause_OnEmptyBufferHidesNewLines(t *testing.T) {
+	ring := core.NewRing(100)
+	m := *NewModel(ring, core.NewFilters(), core.NewSearchState(), core.NewLevelMap(), ModeStdin)
+	nm, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 13})
+	m = nm.(Model)
+	m = m.togglePause()
+
+	ev := ring.Append(core.LogEvent{Line: "first line"})
+	nm, _ = m.Update(LogAppendedMsg{Event: ev})
+	m = nm.(Model).updateViewportContent()
+	if strings.Contains(strings.Join(m.contentPlainLines, "\n"), "first line") {
+		t.Error("a line arriving while paused on an empty buffer was shown")
+	}
+
+	m = m.togglePause()
+	if !strings.Contains(m.vp.View(), "first line") {
+		t.Errorf("resuming should show the buffered line:\n%s", m.vp.View())
+	}
+}